@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/internal/handlers"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/auth"
+)
+
+// apikeyCommand creates an API key straight in the database, which is how
+// the first key is made before any can call POST /api-keys.
+func apikeyCommand(args []string) error {
+	fs := flag.NewFlagSet("apikey", flag.ExitOnError)
+	name := fs.String("name", "", "name of the key, e.g. the service or person using it")
+	roleName := fs.String("role", string(auth.RoleAdmin), "role of the key: viewer, editor or admin")
+	fs.Parse(args)
+
+	if strings.TrimSpace(*name) == "" {
+		return errors.New("apikey: -name is required")
+	}
+	role, ok := auth.ParseRole(*roleName)
+	if !ok {
+		return fmt.Errorf("apikey: unknown role %q", *roleName)
+	}
+
+	key, record, err := handlers.CreateAPIKey(context.Background(), strings.TrimSpace(*name), role)
+	if err != nil {
+		return fmt.Errorf("apikey: %w", err)
+	}
+
+	fmt.Printf("Created %s API key %d (%s). It is shown only once:\n\n%s\n", record.Role, record.ID, record.Name, key)
+	return nil
+}
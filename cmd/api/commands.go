@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// commands are the subcommands that can be run instead of the API server,
+// e.g. `api sitemap -site 1 -paths paths.txt`.
+var commands = map[string]func(args []string) error{
+	"apikey":  apikeyCommand,
+	"diff":    diffCommand,
+	"purge":   purgeCommand,
+	"seed":    seedCommand,
+	"sitemap": sitemapCommand,
+	"sync":    syncCommand,
+}
+
+func runCommand(name string, args []string) error {
+	command, ok := commands[name]
+	if !ok {
+		var names []string
+		for n := range commands {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown command %q, available commands: %s", name, strings.Join(names, ", "))
+	}
+	return command(args)
+}
@@ -15,49 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/country": {
+        "/api-keys": {
             "get": {
-                "description": "Retrieves a list of countries filtered by language IDs",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List every API key, including revoked ones. Keys themselves are never returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Country"
-                ],
-                "summary": "Get filtered countries",
-                "parameters": [
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Filter by language IDs",
-                        "name": "language_ids",
-                        "in": "query"
-                    }
+                    "API keys"
                 ],
+                "summary": "List API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                                "$ref": "#/definitions/handlers.APIKeyResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid language_ids parameter",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
-                        "description": "Failed to get countries",
+                        "description": "Database query error",
                         "schema": {
                             "type": "string"
                         }
@@ -65,7 +52,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new country with the provided information",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an API key. The key is returned only in this response; store it, as only its hash is kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -73,17 +68,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Country"
+                    "API keys"
                 ],
-                "summary": "Create a new country",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Country Data",
-                        "name": "country",
+                        "description": "API key",
+                        "name": "key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.InsertCountryRequest"
+                            "$ref": "#/definitions/handlers.APIKeyRequest"
                         }
                     }
                 ],
@@ -91,7 +86,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                            "$ref": "#/definitions/handlers.APIKeySecretResponse"
                         }
                     },
                     "400": {
@@ -103,23 +98,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/country/{id}": {
+        "/api-keys/{id}": {
             "get": {
-                "description": "Retrieves a country by the provided ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get an API key by ID, with the time it was last used",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Country"
+                    "API keys"
                 ],
-                "summary": "Get country by ID",
+                "summary": "Get an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -129,48 +129,62 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                            "$ref": "#/definitions/handlers.APIKeyResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid item ID",
+                    "404": {
+                        "description": "API key not found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/language": {
-            "get": {
-                "description": "Retrieve all language tags with their associated variants",
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Revoke an API key. It stays listed, with the time it was revoked.",
                 "tags": [
-                    "Language tags"
+                    "API keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all language tags",
                 "responses": {
-                    "200": {
-                        "description": "List of Language Tags with variants",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LanguageTagGetAllResponse"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "500": {
-                        "description": "Failed to get language tags",
+                    "404": {
+                        "description": "API key not found or already revoked",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Insert a new language tag and its associated variants",
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the role of an API key. It applies from the next request made with the key.",
                 "consumes": [
                     "application/json"
                 ],
@@ -178,25 +192,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Language tags"
+                    "API keys"
                 ],
-                "summary": "Create a new language tag",
+                "summary": "Change the role of an API key",
                 "parameters": [
                     {
-                        "description": "Language Tag with Variants",
-                        "name": "languageTag",
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.LanguageTagBody"
+                            "$ref": "#/definitions/handlers.APIKeyRoleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created Language Tag with variants",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                            "$ref": "#/definitions/handlers.APIKeyResponse"
                         }
                     },
                     "400": {
@@ -205,8 +226,8 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Failed to insert language tag or variants",
+                    "404": {
+                        "description": "API key not found",
                         "schema": {
                             "type": "string"
                         }
@@ -214,50 +235,133 @@ const docTemplate = `{
                 }
             }
         },
-        "/language-variant": {
-            "get": {
-                "description": "Get a list of language tag variants with pagination",
-                "consumes": [
-                    "application/json"
+        "/api-keys/{id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Replace the key of an API key with a new one, keeping its ID and name. The old key stops working at once.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Language variants"
+                    "API keys"
                 ],
-                "summary": "Get paginated language tag variants",
+                "summary": "Rotate an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Language Tag ID",
-                        "name": "languageTagId",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIKeySecretResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found or revoked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List audit entries, newest first by default. Every column can be filtered and sorted on, e.g. entity=country\u0026id=12, actor=api_key:3, request_id=..., occurred_at\u003e=2026-01-01T00:00:00Z or after~=Deutschland. id is the id of the changed row; seq orders the entries.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Search the audit log",
+                "parameters": [
+                    {
+                        "enum": [
+                            "api_key",
+                            "country",
+                            "country_language",
+                            "language",
+                            "locale_url",
+                            "localized_name",
+                            "redirect",
+                            "site",
+                            "slug",
+                            "variant",
+                            "webhook"
+                        ],
+                        "type": "string",
+                        "description": "Entity of the changed row",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the changed row",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated columns, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. operation in (update, delete)",
+                        "name": "filter",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Limit of items per page",
+                        "description": "Number of entries per page (max 100)",
                         "name": "page_size",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
+                        "type": "string",
+                        "description": "Cursor of the page to fetch",
                         "name": "page_token",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching entries",
+                        "name": "include_total_count",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PaginatedVariantsResponse"
+                            "$ref": "#/definitions/handlers.PaginatedAuditResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid languageTagId or page_token",
+                        "description": "Invalid sort, filter or page_token parameter",
                         "schema": {
                             "type": "string"
                         }
@@ -269,45 +373,64 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new language tag variant",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/audit/{entity}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get every audit entry of a row, oldest first, with the fields each one changed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Language variants"
+                    "Audit"
                 ],
-                "summary": "Create a new language tag variant",
+                "summary": "Get the history of a row",
                 "parameters": [
                     {
-                        "description": "Language Tag Variant",
-                        "name": "variant",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LanguageTagVariantsRequest"
-                            }
-                        }
+                        "enum": [
+                            "api_key",
+                            "country",
+                            "country_language",
+                            "language",
+                            "locale_url",
+                            "localized_name",
+                            "redirect",
+                            "site",
+                            "slug",
+                            "variant",
+                            "webhook"
+                        ],
+                        "type": "string",
+                        "description": "Entity",
+                        "name": "entity",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the row",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
-                            }
+                            "$ref": "#/definitions/handlers.AuditHistoryResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request payload",
+                    "404": {
+                        "description": "Unknown entity or no history",
                         "schema": {
                             "type": "string"
                         }
@@ -321,52 +444,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/language-variant/{id}": {
-            "put": {
-                "description": "Update an existing language tag variant",
-                "consumes": [
-                    "application/json"
+        "/changes/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Stream the creations, updates, deletions and restorations of countries, languages and variants as server-sent events, named \u003centity\u003e.\u003coperation\u003e, e.g. country.updated.\nEvent IDs are a sequence increasing in the order changes become visible, the same on every replica. Reconnecting with Last-Event-ID resumes after that event; without it the stream starts with the next change. A heartbeat comment is sent every 15 seconds.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Language variants"
+                    "Changes"
                 ],
-                "summary": "Update an existing language tag variant",
+                "summary": "Stream changes",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Variant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "country",
+                            "language",
+                            "variant"
+                        ],
+                        "type": "string",
+                        "description": "Comma-separated entities to stream, all by default",
+                        "name": "entity",
+                        "in": "query"
                     },
                     {
-                        "description": "Language Tag Variant",
-                        "name": "variant",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LanguageTagVariantsRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID of the last event received, to resume after it",
+                        "name": "Last-Event-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Stream of events whose data is a ChangeEventResponse",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                            "$ref": "#/definitions/handlers.ChangeEventResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid entity or Last-Event-ID",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Variant not found",
+                    "405": {
+                        "description": "Method not allowed",
                         "schema": {
                             "type": "string"
                         }
@@ -380,190 +509,5917 @@ const docTemplate = `{
                 }
             }
         },
-        "/language/{id}": {
+        "/country": {
             "get": {
-                "description": "Retrieve a specific language tag and its variants by ID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of countries, leaving out deleted and retired ones unless include_deleted or include_retired is true. Any column can be filtered with col=v (repeat for any of several values), col~=v (case-insensitive substring), col!=v, col\u003e=v and col\u003c=v, or with filter expressions such as \"iso3166_2_a1 in (PT, BR)\". Looking a code up with iso3166_2_a1=v, iso3166_2_a3=v or iso3166_numeric=v returns retired countries too, and when a single one matches and it is deprecated or retired, the response has a Deprecation header and a successor-version Link to its replacement. Columns: id, name, official_state_name, tld, iso3166_2_a1, iso3166_2_a3, iso3166_numeric, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Language tags"
+                    "Country"
                 ],
-                "summary": "Get language tag by ID",
+                "summary": "Get filtered countries",
                 "parameters": [
                     {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by language IDs",
+                        "name": "language_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "List the countries as they were at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted countries",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include retired countries",
+                        "name": "include_retired",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "name,-created_at",
+                        "description": "Comma-separated columns, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter expressions",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
                         "type": "integer",
-                        "description": "Language Tag ID",
+                        "default": 10,
+                        "description": "Limit of items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to fetch",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching countries",
+                        "name": "include_total_count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PaginatedCountriesResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "On a code lookup matching a deprecated or retired country, when it was deprecated, as @ and a Unix time"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "On a code lookup, successor-version link to the country replacing the one found"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort, filter, language_ids, as_of, include_deleted, include_retired or page_token parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get countries",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new country with the provided information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Country"
+                ],
+                "summary": "Create a new country",
+                "parameters": [
+                    {
+                        "description": "Country Data",
+                        "name": "country",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InsertCountryRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/country/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a country by the provided ID. Deleted countries are returned too, with their deleted_at. Deprecated and retired countries come with a Deprecation header and a successor-version Link to their replacement.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Country"
+                ],
+                "summary": "Get country by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Get the country as it was at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the country, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Language Tag with variants",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "When a deprecated or retired country was deprecated, as @ and a Unix time"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the country, for If-Match and If-None-Match"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "successor-version link to the country replacing it"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or as_of",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Language tag not found",
+                        "description": "Country not found",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     },
-                    "500": {
-                        "description": "Failed to get variants",
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a country as deleted. It is left out of lists but can still be read by ID, and restored until it is purged after the retention period.",
+                "tags": [
+                    "Country"
+                ],
+                "summary": "Delete a country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the country",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Country not found or already deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The country has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/country/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a deleted country that has not been purged yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Country"
+                ],
+                "summary": "Restore a country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted country not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/country/{id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copies a previous version of a country back into it, which records it as a new version. Versions are numbered from 1 and listed by GET /audit/country/{id}, or read with as_of.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Country"
+                ],
+                "summary": "Revert a country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to restore",
+                        "name": "version",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Country or version not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The version conflicts with another country",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/country/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the status of a country, e.g. when its code is withdrawn. deprecated_at defaults to now, and replaced_by names the country to use instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Country"
+                ],
+                "summary": "Deprecate, retire or reactivate a country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID, status or replaced_by",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Country not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/diff": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compares an export of another instance, or any file POST /import accepts, with the data of this instance by ISO codes, and returns what importing it would add and update.\nOnly the entities in the file are compared. Rows only this instance has are listed as removals, which POST /import never applies.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Import and export"
+                ],
+                "summary": "Compare a data file with this instance",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV, zipped CSV, JSON Lines or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format, inferred from the file name by default",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "country",
+                            "language",
+                            "variant",
+                            "country_language"
+                        ],
+                        "type": "string",
+                        "description": "Entity of a single CSV file or of JSON Lines records without an entity field",
+                        "name": "entity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON column mapping, as for POST /import",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "text"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Response format",
+                        "name": "output",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/datadiff.Changeset"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file, format, entity or mapping",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams countries, languages, variants and the languages of each country keyed by ISO codes rather than IDs, in the columns POST /import accepts.\nCSV exports of a single entity are a plain CSV file; several entities are zipped as one \u003centity\u003e.csv file each. JSON Lines records name their entity in an \"entity\" field. XLSX workbooks have one sheet per entity.",
+                "produces": [
+                    "text/csv",
+                    "application/zip",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Import and export"
+                ],
+                "summary": "Export reference data",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "country",
+                                "language",
+                                "variant",
+                                "country_language"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Entities to export, all by default",
+                        "name": "entities",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid entities or format parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/hreflang": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the rel=\"alternate\" hreflang set of a canonical path, including x-default, as JSON, HTML link tags or an HTTP Link header value.\nEvery locale must be a valid language(-region) code whose language and country exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/html",
+                    "text/plain"
+                ],
+                "tags": [
+                    "URLs"
+                ],
+                "summary": "Generate hreflang alternates",
+                "parameters": [
+                    {
+                        "description": "Canonical path and output format",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HreflangRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alternate links",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HreflangResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Site not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid hreflang values",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or updates countries, languages, variants and the languages of each country from a file in any format GET /export produces. Rows are matched to existing data by ISO codes: countries by iso3166_2_a1, languages by iso_639_1, variants by language, country and variant_tag, and country languages by country and language. Nothing is deleted.\nEvery row is validated before anything is written, and the import is applied in a single transaction only if all rows are valid. With dry_run=true the report of what would change is returned without writing.\nmapping renames file columns to import columns, either for all entities, e.g. {\"Code\": \"iso3166_2_a1\"}, or per entity, e.g. {\"country\": {\"Code\": \"iso3166_2_a1\"}}. Mapping a column to \"\" ignores it. Columns that are neither known nor ignored are rejected.\nOptional columns absent from the file are left unchanged on existing rows.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import and export"
+                ],
+                "summary": "Import reference data",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV, zipped CSV, JSON Lines or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "File format, inferred from the file name by default",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "country",
+                            "language",
+                            "variant",
+                            "country_language"
+                        ],
+                        "type": "string",
+                        "description": "Entity of a single CSV file or of JSON Lines records without an entity field, inferred from the file name by default",
+                        "name": "entity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON column mapping",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report without writing",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file, format, entity or mapping",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Database error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of language tags with their variant counts. Any column can be filtered with col=v (repeat for any of several values), col~=v (case-insensitive substring), col!=v, col\u003e=v and col\u003c=v, or with filter expressions such as \"iso_639_1 in (en, fr)\". Columns: id, name, iso_639_1, iso_639_2, created_at, updated_at. Looking a code up with iso_639_1=v or iso_639_2=v returns retired language tags too, and when a single one matches and it is deprecated or retired, the response has a Deprecation header and a successor-version Link to its replacement.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Get all language tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "name,-created_at",
+                        "description": "Comma-separated columns, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter expressions",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to fetch",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching language tags",
+                        "name": "include_total_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "countries",
+                                "locales"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Extra per-language counts to include",
+                        "name": "include_counts",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "List the language tags and their variant counts as they were at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted language tags",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include retired language tags",
+                        "name": "include_retired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of Language Tags with variant counts",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PaginatedLanguageTagsResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "On a code lookup matching a deprecated or retired language tag, when it was deprecated, as @ and a Unix time"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "On a code lookup, successor-version link to the language tag replacing the one found"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort, filter, include_counts, as_of, include_deleted, include_retired or page_token parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get language tags",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert a new language tag and its associated variants",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Create a new language tag",
+                "parameters": [
+                    {
+                        "description": "Language Tag with Variants",
+                        "name": "languageTag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created Language Tag with variants",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to insert language tag or variants",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language-variant": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of language tag variants ordered by ID. Page tokens are opaque cursors taken from next_page_token or prev_page_token, and are only valid with the same languageTagId, as_of, include_deleted and include_retired they were issued for. Retired variants are left out unless include_retired is true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Get paginated language tag variants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "languageTagId",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to fetch",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching variants",
+                        "name": "include_total_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "List the variants as they were at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted variants",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include retired variants",
+                        "name": "include_retired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PaginatedVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid languageTagId, as_of, include_deleted, include_retired or page_token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create one or more language tag variants with batched inserts in a single transaction.\nIn atomic mode (the default) either every variant is created and the response is 201 with their IDs, or none is and the response is 422 with the status of each item.\nIn partial mode the valid items are created and the response is always 207 with the status of each item. Items the database refuses are rolled back on their own and reported with 409, 422 or 500.\nA language_id or country_id of 0 leaves the variant without one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Create language tag variants",
+                "parameters": [
+                    {
+                        "enum": [
+                            "atomic",
+                            "partial"
+                        ],
+                        "type": "string",
+                        "default": "atomic",
+                        "description": "Failure handling",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Language Tag Variants",
+                        "name": "variant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LanguageTagVariantsRequest"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Every variant was created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                            }
+                        }
+                    },
+                    "207": {
+                        "description": "Per-item results in partial mode",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VariantBulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid items in atomic mode; nothing was created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VariantBulkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language-variant/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a language tag variant by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Get a language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Get the variant as it was at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the variant, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the variant, for If-Match and If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or as_of",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Variant not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace every field of an existing language tag variant. A country_id of 0 clears the country.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Update an existing language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the variant",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Language Tag Variant",
+                        "name": "variant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Variant not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The variant has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a language tag variant as deleted. It is left out of lists but can still be read by ID, and restored until it is purged after the retention period.",
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Delete a language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the variant",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Variant not found or already deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The variant has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change only the fields present in the body. null clears language_id, country_id or description; variant_tag cannot be null.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Partially update a language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the variant",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "variant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Variant not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The variant has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language-variant/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a deleted language tag variant that has not been purged yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Restore a language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted variant not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language-variant/{id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copy a previous version of a language tag variant back into it, which records it as a new version. Versions are numbered from 1 and listed by GET /audit/variant/{id}, or read with as_of.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Revert a language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to restore",
+                        "name": "version",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Variant or version not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The language or country of the version no longer exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language-variant/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the status of a language tag variant. deprecated_at defaults to now, and replaced_by names the variant to use instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language variants"
+                ],
+                "summary": "Deprecate, retire or reactivate a language tag variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID, status or replaced_by",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Variant not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific language tag and its variants by ID. Deleted language tags are returned too, with their deleted_at. Deprecated and retired language tags come with a Deprecation header and a successor-version Link to their replacement.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Get language tag by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Get the language tag as it was at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the language tag, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Language Tag with variants",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "When a deprecated or retired language tag was deprecated, as @ and a Unix time"
+                            },
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the language tag, for If-Match and If-None-Match"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "successor-version link to the language tag replacing it"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or as_of",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Language tag not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get variants",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a language tag as deleted. It is left out of lists but can still be read by ID, and restored until it is purged after the retention period, along with its variants.",
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Delete a language tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the language tag",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Language tag not found or already deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The language tag has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore a deleted language tag that has not been purged yet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Restore a language tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored Language Tag",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted language tag not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language/{id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copy a previous version of a language tag back into it, which records it as a new version. Versions are numbered from 1 and listed by GET /audit/language/{id}, or read with as_of.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Revert a language tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version to restore",
+                        "name": "version",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reverted Language Tag",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID or version",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Language tag or version not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The version conflicts with another language tag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language/{id}/status": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the status of a language tag, e.g. when its code is withdrawn as iw was for he. deprecated_at defaults to now, and replaced_by names the language tag to use instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "Deprecate, retire or reactivate a language tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Language Tag with its new status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LanguageTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID, status or replaced_by",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Language tag not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/language/{id}/variants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every variant of a language tag, ordered by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Language tags"
+                ],
+                "summary": "List the variants of a language tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Language Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the variants as they were at this RFC 3339 time or date",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include deleted variants",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include retired variants",
+                        "name": "include_retired",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID, as_of, include_deleted or include_retired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Language tag not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/locale-url": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the URL strategy of every locale configured for a site",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locale URLs"
+                ],
+                "summary": "List locale URL strategies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Site ID",
+                        "name": "site_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LocaleURLResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid site_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Configure how a locale of a site is encoded in its URLs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locale URLs"
+                ],
+                "summary": "Create a locale URL strategy",
+                "parameters": [
+                    {
+                        "description": "Locale URL strategy",
+                        "name": "localeUrl",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocaleURLRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocaleURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Locale already configured for this site, or a request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/locale-url/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a locale URL strategy by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locale URLs"
+                ],
+                "summary": "Get a locale URL strategy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Locale URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the locale URL, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocaleURLResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "When the locale URL last changed, for If-Match and If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Locale URL not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the URL strategy of a locale. The site cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locale URLs"
+                ],
+                "summary": "Update a locale URL strategy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Locale URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the locale URL",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Locale URL strategy",
+                        "name": "localeUrl",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocaleURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocaleURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Locale URL not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Locale already configured for this site",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The locale URL has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a locale from a site's URL configuration",
+                "tags": [
+                    "Locale URLs"
+                ],
+                "summary": "Delete a locale URL strategy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Locale URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the locale URL",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Locale URL not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The locale URL has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/localized-name": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the localized names of countries and languages, which search matches besides their English names",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Localized names"
+                ],
+                "summary": "List localized names",
+                "parameters": [
+                    {
+                        "enum": [
+                            "country",
+                            "language"
+                        ],
+                        "type": "string",
+                        "description": "Only names of this kind of entity",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only names of this entity",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only names in this locale",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LocalizedNameResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid entity_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add the name of a country or language in a locale. The locale is stored in canonical hreflang case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Localized names"
+                ],
+                "summary": "Create a localized name",
+                "parameters": [
+                    {
+                        "description": "Localized name",
+                        "name": "localizedName",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocalizedNameRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocalizedNameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The entity already has a name in this locale, or a request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/localized-name/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a localized name by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Localized names"
+                ],
+                "summary": "Get a localized name",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Localized name ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the localized name, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocalizedNameResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "When the localized name last changed, for If-Match and If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Localized name not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the locale and name of a localized name. The entity cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Localized names"
+                ],
+                "summary": "Update a localized name",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Localized name ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the localized name",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Localized name",
+                        "name": "localizedName",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocalizedNameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LocalizedNameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Localized name not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The entity already has a name in this locale",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The localized name has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the name of a country or language in a locale",
+                "tags": [
+                    "Localized names"
+                ],
+                "summary": "Delete a localized name",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Localized name ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the localized name",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Localized name not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The localized name has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the role of the caller and the actions it may perform on each resource, so that clients can hide what the caller cannot use",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get the caller's permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PermissionsResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/redirects": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List redirect rules in priority order, including those created by slug changes, optionally for a single locale",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "List redirects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.RedirectResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an exact, prefix or regex redirect rule. A {locale} placeholder in the target is replaced by the request's locale.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "Create a redirect",
+                "parameters": [
+                    {
+                        "description": "Redirect rule",
+                        "name": "redirect",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Redirect already exists, or a request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Redirect would create a loop, or Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/redirects/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the active redirect rules as an nginx map, Apache RewriteRules or JSON.\nWithout a locale only rules that apply to every locale are exported.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "Export redirects",
+                "parameters": [
+                    {
+                        "enum": [
+                            "nginx",
+                            "apache",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale to export rules for",
+                        "name": "locale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported configuration",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown export format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/redirects/test": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Evaluate a URL or path for a locale, returning the matching rule with a trace of every rule considered and the full redirect chain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "Test a URL against the redirect rules",
+                "parameters": [
+                    {
+                        "description": "URL and locale",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectTestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectTestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/redirects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a redirect rule by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "Get a redirect",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Redirect ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the redirect, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "When the redirect last changed, for If-Match and If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Redirect not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a redirect rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "Update a redirect",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Redirect ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the redirect",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Redirect rule",
+                        "name": "redirect",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RedirectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Redirect not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Redirect already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The redirect has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Redirect would create a loop",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a redirect rule",
+                "tags": [
+                    "Redirects"
+                ],
+                "summary": "Delete a redirect",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Redirect ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the redirect",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Redirect not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The redirect has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fuzzy, accent-insensitive search over names, localized names, ISO codes, TLDs and variant descriptions.\nCombines trigram similarity, substring and prefix matching and full-text search, and returns the best matching field of each entity, ranked by score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search countries, languages and variants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "country",
+                                "language",
+                                "variant"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Entity types to search",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Maximum number of results per type",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid q, types or limit parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/site": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all sites without their locale configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Get all sites",
+                "responses": {
+                    "200": {
+                        "description": "List of sites",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SiteResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get sites",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert a new site whose locale URLs can then be configured",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Create a new site",
+                "parameters": [
+                    {
+                        "description": "Site",
+                        "name": "site",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SiteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created site",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SiteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to insert site",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/site/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a site together with the URL strategy of each of its locales",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sites"
+                ],
+                "summary": "Get site by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Site ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Site with its locale URL configuration",
+                        "schema": {
+                            "$ref": "#/definitions/urlpattern.Site"
+                        }
+                    },
+                    "404": {
+                        "description": "Site not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get site locales",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate sitemap XML with xhtml:link alternates for every canonical path in every locale of a site.\nPaths are posted as JSON or uploaded as a multipart \"paths\" file with one path per line.\nA single sitemap is returned as XML; once the 50,000 URL / 50 MB limits are hit, a zip with a sitemap index and its sitemaps is returned instead.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "text/xml",
+                    "application/gzip",
+                    "application/zip"
+                ],
+                "tags": [
+                    "URLs"
+                ],
+                "summary": "Generate a multilingual sitemap",
+                "parameters": [
+                    {
+                        "description": "Site, paths and options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SitemapRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Site ID (multipart upload)",
+                        "name": "site_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Newline separated canonical paths (multipart upload)",
+                        "name": "paths",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Compress the generated files (multipart upload)",
+                        "name": "gzip",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL the sitemap files are served from (multipart upload)",
+                        "name": "base_url",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sitemap, or zip of the sitemap index and sitemaps",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Site not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/slug": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List slugs, optionally filtered by locale and resource key",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slugs"
+                ],
+                "summary": "List slugs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource key",
+                        "name": "resource_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SlugResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register the slug of a resource in a locale. When slug is omitted, one is suggested from title.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slugs"
+                ],
+                "summary": "Create a slug",
+                "parameters": [
+                    {
+                        "description": "Slug",
+                        "name": "slug",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe: a retry with the same key and body gets the first response again",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug already in use, or a request with this Idempotency-Key is still being processed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/slug/suggest": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suggest a slug for a title, transliterating non-Latin scripts and avoiding slugs already used in the locale",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slugs"
+                ],
+                "summary": "Suggest a slug",
+                "parameters": [
+                    {
+                        "description": "Title and locale",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugSuggestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugSuggestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/slug/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a slug together with the slugs it replaced, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slugs"
+                ],
+                "summary": "Get a slug",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slug ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a copy of the slug, to get 304 if it has not changed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "When the slug last changed, for If-Match and If-None-Match"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Slug not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change a slug. The previous slug is kept in the history and a 301 redirect from it to the new slug is created, with paths built by the URL strategy of every site that configures the locale (e.g. /en/about-us).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Slugs"
+                ],
+                "summary": "Change a slug",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slug ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the slug",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New slug",
+                        "name": "slug",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlugResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Slug not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug already in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "The slug has changed since its ETag",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/build": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn a canonical path into the URL of one of the site's locales",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URLs"
+                ],
+                "summary": "Build a localized URL",
+                "parameters": [
+                    {
+                        "description": "Canonical path and locale",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BuildURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Localized URL",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BuildURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Site not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/resolve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Split an incoming URL into its locale and canonical path",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "URLs"
+                ],
+                "summary": "Resolve a localized URL",
+                "parameters": [
+                    {
+                        "description": "Incoming URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResolveURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Locale and canonical path",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResolveURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "URL does not match any locale",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every registered webhook. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.WebhookResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register an endpoint that changes to the reference data are POSTed to as CloudEvents, signed in the Webhook-Signature header as t=\u003cunix time\u003e,v1=\u003chex HMAC-SHA256 of \"\u003ct\u003e.\u003cbody\u003e\"\u003e with the secret.\nThe secret is returned only in this response. Event types are uurl.\u003centity\u003e.\u003ccreated|updated|deleted|restored\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookSecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create webhook",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a webhook by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a webhook along with its deliveries. Pending deliveries are not sent.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Remove a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the deliveries of a webhook, newest first by default, with the outcome of their last attempt. Every column can be filtered and sorted on, e.g. status=dead or event_type=uurl.country.updated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List the deliveries of a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated columns, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. attempts\u003e=3",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries per page (max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to fetch",
+                        "name": "page_token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching deliveries",
+                        "name": "include_total_count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PaginatedWebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sort, filter or page_token parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Database query error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a delivery again as soon as possible, whatever its status, with a fresh set of attempts. Dead deliveries are revived this way once the endpoint is fixed.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver an event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.Action": {
+            "type": "string",
+            "enum": [
+                "read",
+                "write",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "ActionRead",
+                "ActionWrite",
+                "ActionDelete"
+            ]
+        },
+        "datadiff.Change": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datadiff.FieldChange"
+                    }
+                },
+                "key": {
+                    "description": "Key joins the natural key values with \"/\", e.g. \"pt/BR/pt-BR\".",
+                    "type": "string"
+                },
+                "op": {
+                    "$ref": "#/definitions/datadiff.Op"
+                },
+                "row": {
+                    "description": "Row holds the source values of an added or updated row and the target\nvalues of a removed row.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "datadiff.Changeset": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datadiff.Change"
+                    }
+                },
+                "summary": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/datadiff.Summary"
+                    }
+                }
+            }
+        },
+        "datadiff.FieldChange": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "datadiff.Op": {
+            "type": "string",
+            "enum": [
+                "add",
+                "update",
+                "remove"
+            ],
+            "x-enum-varnames": [
+                "Add",
+                "Update",
+                "Remove"
+            ]
+        },
+        "datadiff.Summary": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "removed": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role is viewer, editor or admin; viewer by default.",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.APIKeyRoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.APIKeySecretResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "rotated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID identifies the row, as country_id:language_id for country_language.",
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AuditHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AuditVersionResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AuditVersionResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID identifies the row, as country_id:language_id for country_language.",
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BuildURLRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "site_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BuildURLResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ChangeEventResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data is the row after the change, or before it for deletions.",
+                    "type": "object"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "Operation is created, updated, deleted or restored.",
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.GetAllCountriesResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is set on deleted countries, which lists only return with\ninclude_deleted=true.",
+                    "type": "string"
+                },
+                "deprecated_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "iso3166_2_a1": {
+                    "type": "string"
+                },
+                "iso3166_2_a3": {
+                    "type": "string"
+                },
+                "iso3166_numeric": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "official_state_name": {
+                    "type": "string"
+                },
+                "replaced_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is active, deprecated or retired. Lists only return retired\ncountries with include_retired=true.",
+                    "type": "string"
+                },
+                "tld": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.HreflangRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "default": "json",
+                    "enum": [
+                        "json",
+                        "html",
+                        "header"
+                    ]
+                },
+                "path": {
+                    "type": "string"
+                },
+                "site_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.HreflangResponse": {
+            "type": "object",
+            "properties": {
+                "alternates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hreflang.Link"
+                    }
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ImportEntityReport": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportRowError"
+                    }
+                },
+                "inserted": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ImportReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "entities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportEntityReport"
+                    }
+                },
+                "error_count": {
+                    "description": "ErrorCount counts every error found; at most 1000 are listed.",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Row is the 1-based position of the row among the entity's data rows,\nnot counting the header or blank rows.",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.InsertCountryRequest": {
+            "type": "object",
+            "properties": {
+                "iso3166_2_a1": {
+                    "type": "string"
+                },
+                "iso3166_2_a3": {
+                    "type": "string"
+                },
+                "iso3166_numeric": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "official_state_name": {
+                    "type": "string"
+                },
+                "tld": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LanguageTagBody": {
+            "type": "object",
+            "properties": {
+                "iso_639_1": {
+                    "type": "string"
+                },
+                "iso_639_2": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LanguageTagGetAllResponse": {
+            "type": "object",
+            "properties": {
+                "countries_count": {
+                    "description": "CountriesCount and LocalesCount are only set when requested with\ninclude_counts.",
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set on deleted language tags, which lists only return\nwith include_deleted=true.",
+                    "type": "string"
+                },
+                "deprecated_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "iso_639_1": {
+                    "type": "string"
+                },
+                "iso_639_2": {
+                    "type": "string"
+                },
+                "locales_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "replaced_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is active, deprecated or retired. Lists only return retired\nlanguage tags with include_retired=true.",
+                    "type": "string"
+                },
+                "variants_count": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.LanguageTagResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt is set on deleted language tags.",
+                    "type": "string"
+                },
+                "deprecated_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "iso_639_1": {
+                    "type": "string"
+                },
+                "iso_639_2": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "replaced_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is active, deprecated or retired.",
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.LanguageTagVariantPatchRequest": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "language_id": {
+                    "type": "integer"
+                },
+                "variant_tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LanguageTagVariantsRequest": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "language_id": {
+                    "type": "integer"
+                },
+                "variant_tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LanguageTagVariantsResponse": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is set on deleted variants, which lists only return with\ninclude_deleted=true.",
+                    "type": "string"
+                },
+                "deprecated_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language_tag_id": {
+                    "type": "integer"
+                },
+                "replaced_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is active, deprecated or retired. Lists only return retired\nvariants with include_retired=true.",
+                    "type": "string"
+                },
+                "variant_tag": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.LocaleURLRequest": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "language_id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "site_id": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "type": "string",
+                    "enum": [
+                        "path_prefix",
+                        "subdomain",
+                        "cctld",
+                        "query_param"
+                    ]
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LocaleURLResponse": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language_id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "site_id": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LocalizedNameRequest": {
+            "type": "object",
+            "properties": {
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "country",
+                        "language"
+                    ]
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LocalizedNameResponse": {
+            "type": "object",
+            "properties": {
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PaginatedAuditResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AuditEntryResponse"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                },
+                "prev_page_token": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PaginatedCountriesResponse": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.GetAllCountriesResponse"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                },
+                "prev_page_token": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PaginatedLanguageTagsResponse": {
+            "type": "object",
+            "properties": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.LanguageTagGetAllResponse"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                },
+                "prev_page_token": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PaginatedVariantsResponse": {
+            "type": "object",
+            "properties": {
+                "next_page_token": {
+                    "type": "string"
+                },
+                "prev_page_token": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                    }
+                }
+            }
+        },
+        "handlers.PaginatedWebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.WebhookDeliveryResponse"
+                    }
+                },
+                "next_page_token": {
+                    "type": "string"
+                },
+                "prev_page_token": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "authenticated": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "Permissions lists the actions, read, write and delete, allowed on each\nresource; resources the caller can do nothing with are left out.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/auth.Action"
+                        }
+                    }
+                },
+                "role": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RedirectHop": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RedirectRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "match_type": {
+                    "type": "string",
+                    "default": "exact",
+                    "enum": [
+                        "exact",
+                        "prefix",
+                        "regex"
+                    ]
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "source_path": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "default": 301,
+                    "enum": [
+                        301,
+                        302,
+                        307,
+                        308
+                    ]
+                },
+                "target_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RedirectResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "match_type": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "source_path": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "target_path": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RedirectTestRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RedirectTestResponse": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RedirectHop"
+                    }
+                },
+                "loop": {
+                    "type": "boolean"
+                },
+                "result": {
+                    "$ref": "#/definitions/redirect.Result"
+                }
+            }
+        },
+        "handlers.ResolveURLRequest": {
+            "type": "object",
+            "properties": {
+                "site_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ResolveURLResponse": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "query": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SearchResult"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.GetAllCountriesResponse": {
+        },
+        "handlers.SearchResult": {
             "type": "object",
             "properties": {
+                "field": {
+                    "description": "Field is the field that matched best, e.g. name, iso_639_1 or\nlocalized_name.",
+                    "type": "string"
+                },
+                "highlight": {
+                    "description": "Highlight is Value with matched words wrapped in \u003cmark\u003e tags.",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "iso3166_2_a1": {
+                "label": {
                     "type": "string"
                 },
-                "iso3166_2_a3": {
+                "locale": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "country",
+                        "language",
+                        "variant"
+                    ]
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SiteRequest": {
+            "type": "object",
+            "properties": {
+                "default_locale": {
+                    "type": "string"
+                },
+                "host": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "official_state_name": {
+                "query_param": {
                     "type": "string"
                 },
-                "tld": {
+                "scheme": {
                     "type": "string"
                 }
             }
         },
-        "handlers.InsertCountryRequest": {
+        "handlers.SiteResponse": {
             "type": "object",
             "properties": {
-                "iso3166_2_a1": {
+                "default_locale": {
                     "type": "string"
                 },
-                "iso3166_2_a3": {
+                "host": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "official_state_name": {
+                "query_param": {
                     "type": "string"
                 },
-                "tld": {
+                "scheme": {
                     "type": "string"
                 }
             }
         },
-        "handlers.LanguageTagBody": {
+        "handlers.SitemapRequest": {
             "type": "object",
             "properties": {
-                "iso_639_1": {
+                "base_url": {
                     "type": "string"
                 },
-                "iso_639_2": {
+                "gzip": {
+                    "type": "boolean"
+                },
+                "paths": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "site_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.SlugHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "replaced_at": {
                     "type": "string"
                 },
-                "name": {
+                "slug": {
                     "type": "string"
                 }
             }
         },
-        "handlers.LanguageTagGetAllResponse": {
+        "handlers.SlugRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "resource_key": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SlugResponse": {
             "type": "object",
             "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SlugHistoryEntry"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "iso_639_1": {
+                "locale": {
                     "type": "string"
                 },
-                "iso_639_2": {
+                "resource_key": {
                     "type": "string"
                 },
-                "name": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SlugSuggestRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
                     "type": "string"
                 },
-                "variants_count": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SlugSuggestResponse": {
+            "type": "object",
+            "properties": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SlugUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.StatusRequest": {
+            "type": "object",
+            "properties": {
+                "deprecated_at": {
+                    "description": "DeprecatedAt defaults to now for deprecated and retired records, and\nis cleared along with ReplacedBy for active ones.",
+                    "type": "string"
+                },
+                "replaced_by": {
+                    "description": "ReplacedBy is the ID of the record to use instead.",
                     "type": "integer"
+                },
+                "status": {
+                    "description": "Status is active, deprecated or retired.",
+                    "type": "string"
                 }
             }
         },
-        "handlers.LanguageTagResponse": {
+        "handlers.VariantBulkItemResult": {
             "type": "object",
             "properties": {
+                "error": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "iso_639_1": {
+                "index": {
+                    "description": "Index is the position of the item in the request.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.VariantBulkResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
                     "type": "string"
                 },
-                "iso_639_2": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.VariantBulkItemResult"
+                    }
+                }
+            }
+        },
+        "handlers.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is when a pending delivery is sent next.",
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is pending, delivered or dead.",
                     "type": "string"
                 }
             }
         },
-        "handlers.LanguageTagVariantsRequest": {
+        "handlers.WebhookRequest": {
             "type": "object",
             "properties": {
-                "country_id": {
+                "event_types": {
+                    "description": "EventTypes are the events to send, e.g. uurl.country.updated; every\nevent when empty.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "integer"
                 },
-                "description": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.WebhookSecretResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "language_id": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
                     "type": "integer"
                 },
-                "variant_tag": {
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "handlers.LanguageTagVariantsResponse": {
+        "hreflang.Link": {
             "type": "object",
             "properties": {
-                "description": {
+                "href": {
+                    "type": "string"
+                },
+                "hreflang": {
+                    "type": "string"
+                }
+            }
+        },
+        "redirect.MatchType": {
+            "type": "string",
+            "enum": [
+                "exact",
+                "prefix",
+                "regex"
+            ],
+            "x-enum-varnames": [
+                "Exact",
+                "Prefix",
+                "Regex"
+            ]
+        },
+        "redirect.Result": {
+            "type": "object",
+            "properties": {
+                "matched": {
+                    "type": "boolean"
+                },
+                "rule": {
+                    "$ref": "#/definitions/redirect.Rule"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "trace": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/redirect.Step"
+                    }
+                }
+            }
+        },
+        "redirect.Rule": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "language_tag_id": {
+                "locale": {
+                    "type": "string"
+                },
+                "match_type": {
+                    "$ref": "#/definitions/redirect.MatchType"
+                },
+                "priority": {
                     "type": "integer"
                 },
-                "variant_tag": {
+                "source": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "target": {
                     "type": "string"
                 }
             }
         },
-        "handlers.PaginatedVariantsResponse": {
+        "redirect.Step": {
             "type": "object",
             "properties": {
-                "next_page_token": {
+                "outcome": {
                     "type": "string"
                 },
-                "variants": {
+                "rule_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "urlpattern.Locale": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "$ref": "#/definitions/urlpattern.Strategy"
+                },
+                "tld": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "urlpattern.Site": {
+            "type": "object",
+            "properties": {
+                "default_locale": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "locales": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.LanguageTagVariantsResponse"
+                        "$ref": "#/definitions/urlpattern.Locale"
                     }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "query_param": {
+                    "type": "string"
+                },
+                "scheme": {
+                    "type": "string"
                 }
             }
+        },
+        "urlpattern.Strategy": {
+            "type": "string",
+            "enum": [
+                "path_prefix",
+                "subdomain",
+                "cctld",
+                "query_param"
+            ],
+            "x-enum-varnames": [
+                "PathPrefix",
+                "Subdomain",
+                "CCTLD",
+                "QueryParam"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "An API key, created with ` + "`" + `api apikey -name NAME` + "`" + ` or POST /api-keys",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "\"Bearer \" followed by an API key or a JWT signed by a key of AUTH_JWKS_FILE",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
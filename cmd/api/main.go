@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 
 	_ "github.com/LeonardoFreitas1/uurl-admin/cmd/api/docs"
 	"github.com/LeonardoFreitas1/uurl-admin/internal/handlers"
@@ -15,7 +17,24 @@ import (
 // @description	API documentation for UURL Admin service.
 // @host			localhost:8080
 // @BasePath		/
+//
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-API-Key
+// @description				An API key, created with `api apikey -name NAME` or POST /api-keys
+//
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				"Bearer " followed by an API key or a JWT signed by a key of AUTH_JWKS_FILE
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	http.HandleFunc("/language", handlers.LanguageTagHandler)
 	http.HandleFunc("/language/", handlers.LanguageTagHandler)
 
@@ -25,12 +44,54 @@ func main() {
 	http.HandleFunc("/country", handlers.CountryHandler)
 	http.HandleFunc("/country/", handlers.CountryHandler)
 
+	http.HandleFunc("/site", handlers.SiteHandler)
+	http.HandleFunc("/site/", handlers.SiteHandler)
+
+	http.HandleFunc("/locale-url", handlers.LocaleURLHandler)
+	http.HandleFunc("/locale-url/", handlers.LocaleURLHandler)
+
+	http.HandleFunc("/localized-name", handlers.LocalizedNameHandler)
+	http.HandleFunc("/localized-name/", handlers.LocalizedNameHandler)
+
+	http.HandleFunc("/url/build", handlers.URLBuildHandler)
+	http.HandleFunc("/url/resolve", handlers.URLResolveHandler)
+	http.HandleFunc("/hreflang", handlers.HreflangHandler)
+	http.HandleFunc("/sitemap", handlers.SitemapHandler)
+
+	http.HandleFunc("/slug", handlers.SlugHandler)
+	http.HandleFunc("/slug/", handlers.SlugHandler)
+	http.HandleFunc("/slug/suggest", handlers.SlugSuggestHandler)
+
+	http.HandleFunc("/redirects", handlers.RedirectHandler)
+	http.HandleFunc("/redirects/", handlers.RedirectHandler)
+
+	http.HandleFunc("/search", handlers.SearchHandler)
+
+	http.HandleFunc("/export", handlers.ExportHandler)
+	http.HandleFunc("/import", handlers.ImportHandler)
+	http.HandleFunc("/diff", handlers.DiffHandler)
+
+	http.HandleFunc("/api-keys", handlers.APIKeyHandler)
+	http.HandleFunc("/api-keys/", handlers.APIKeyHandler)
+	http.HandleFunc("/me/permissions", handlers.MePermissionsHandler)
+
+	http.HandleFunc("/audit", handlers.AuditHandler)
+	http.HandleFunc("/audit/", handlers.AuditHandler)
+
+	http.HandleFunc("/changes/stream", handlers.ChangeStreamHandler)
+
+	http.HandleFunc("/webhooks", handlers.WebhookHandler)
+	http.HandleFunc("/webhooks/", handlers.WebhookHandler)
+
 	http.Handle("/swagger-ui/", httpSwagger.WrapHandler)
 
+	go handlers.DeliverWebhooks(context.Background())
+	go handlers.ListenForChanges(context.Background())
+
 	fmt.Println("Server running at :8080")
 	server := &http.Server{
 		Addr:    ":8080",
-		Handler: http.DefaultServeMux,
+		Handler: handlers.RequestID(handlers.Authenticate(handlers.Authorize(handlers.Idempotency(http.DefaultServeMux)))),
 	}
 	log.Fatal(server.ListenAndServe())
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/internal/handlers"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+)
+
+// purgeCommand removes the countries, languages and variants that were
+// deleted longer than the retention ago, and the expired responses kept for
+// Idempotency-Key retries. It is meant to run periodically, e.g. from cron.
+func purgeCommand(args []string) error {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	retention := fs.Duration("retention", config.GetSoftDeleteRetention(), "how long deleted rows are kept, e.g. 720h")
+	fs.Parse(args)
+
+	if *retention <= 0 {
+		return errors.New("purge: -retention must be positive")
+	}
+
+	before := time.Now().Add(-*retention)
+	result, err := handlers.PurgeDeleted(context.Background(), before)
+	if err != nil {
+		return fmt.Errorf("purge: %w", err)
+	}
+
+	fmt.Printf("Purged rows deleted before %s: %d countries, %d languages, %d variants\n",
+		before.Format(time.RFC3339), result.Countries, result.Languages, result.Variants)
+
+	expired, err := handlers.PurgeExpiredIdempotencyKeys(context.Background())
+	if err != nil {
+		return fmt.Errorf("purge: %w", err)
+	}
+	fmt.Printf("Purged %d expired idempotency keys\n", expired)
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/seed"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+)
+
+func seedCommand(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	only := fs.String("only", "", "comma-separated datasets to load, all by default: "+strings.Join(seed.Datasets, ", "))
+	fs.Parse(args)
+
+	var datasets []string
+	for _, name := range strings.Split(*only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			datasets = append(datasets, name)
+		}
+	}
+
+	results, err := seed.Load(context.Background(), config.GetDB(), datasets)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		line := fmt.Sprintf("%-16s %4d inserted, %4d already present", r.Dataset, r.Inserted, r.Present)
+		if r.Skipped > 0 {
+			line += fmt.Sprintf(", %d skipped for a missing country or language", r.Skipped)
+		}
+		if r.NumericFilled > 0 {
+			line += fmt.Sprintf(", %d numeric codes filled in", r.NumericFilled)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
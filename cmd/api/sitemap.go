@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/LeonardoFreitas1/uurl-admin/internal/handlers"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/sitemap"
+)
+
+func sitemapCommand(args []string) error {
+	fs := flag.NewFlagSet("sitemap", flag.ExitOnError)
+	siteID := fs.Int("site", 0, "ID of the site whose locales are used")
+	pathsFile := fs.String("paths", "-", "file with one canonical path per line, - for stdin")
+	out := fs.String("out", "sitemaps", "directory the sitemap files are written to")
+	compress := fs.Bool("gzip", false, "gzip-compress the generated files")
+	baseURL := fs.String("base-url", "", "URL the sitemap files are served from, defaults to the site root")
+	fs.Parse(args)
+
+	if *siteID == 0 {
+		return errors.New("sitemap: -site is required")
+	}
+
+	var in io.Reader = os.Stdin
+	if *pathsFile != "-" {
+		f, err := os.Open(*pathsFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	paths, err := sitemap.ReadPaths(in)
+	if err != nil {
+		return err
+	}
+
+	site, err := handlers.LoadSite(context.Background(), int32(*siteID))
+	if err != nil {
+		return fmt.Errorf("sitemap: loading site %d: %w", *siteID, err)
+	}
+
+	files, err := sitemap.Generate(site, paths, sitemap.Options{BaseURL: *baseURL, Gzip: *compress})
+	if err != nil {
+		return err
+	}
+
+	if err := sitemap.WriteDir(*out, files); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		fmt.Printf("Wrote %s (%d bytes)\n", f.Name, len(f.Data))
+	}
+	return nil
+}
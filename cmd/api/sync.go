@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/internal/handlers"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/datadiff"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/tabular"
+)
+
+// diff and sync talk to both instances through GET /export and POST /import,
+// so they work against any deployment the caller can reach.
+
+var syncClient = &http.Client{Timeout: 5 * time.Minute}
+
+type syncFlags struct {
+	source, target, entities string
+	sourceKey, targetKey     string
+}
+
+func (f *syncFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.source, "source", "", "base URL of the instance whose data is copied, e.g. https://staging.example.com")
+	fs.StringVar(&f.target, "target", "", "base URL of the instance that is compared with the source")
+	fs.StringVar(&f.sourceKey, "source-key", os.Getenv("UURL_API_KEY"), "API key for the source, $UURL_API_KEY by default")
+	fs.StringVar(&f.targetKey, "target-key", os.Getenv("UURL_API_KEY"), "API key for the target, $UURL_API_KEY by default")
+	fs.StringVar(&f.entities, "entities", "", "comma-separated entities to compare, all by default: country, language, variant, country_language")
+}
+
+func (f *syncFlags) changeset(ctx context.Context, command string) (datadiff.Changeset, error) {
+	if f.source == "" || f.target == "" {
+		return datadiff.Changeset{}, fmt.Errorf("%s: -source and -target are required", command)
+	}
+
+	source, err := fetchExport(ctx, f.source, f.sourceKey, f.entities)
+	if err != nil {
+		return datadiff.Changeset{}, fmt.Errorf("%s: reading source: %w", command, err)
+	}
+	target, err := fetchExport(ctx, f.target, f.targetKey, f.entities)
+	if err != nil {
+		return datadiff.Changeset{}, fmt.Errorf("%s: reading target: %w", command, err)
+	}
+	return datadiff.Diff(source, target, handlers.ExchangeKeys())
+}
+
+func diffCommand(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	var f syncFlags
+	f.register(fs)
+	asJSON := fs.Bool("json", false, "write the changeset as JSON")
+	fs.Parse(args)
+
+	changes, err := f.changeset(context.Background(), "diff")
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+	return changes.WriteText(os.Stdout)
+}
+
+func syncCommand(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	var f syncFlags
+	f.register(fs)
+	apply := fs.Bool("apply", false, "import the changes into the target; without it the target only validates them")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), `Usage: api sync -source URL -target URL [-apply] [flags]
+
+Imports the rows that the source has and the target lacks or holds with other
+values into the target. Sync never removes rows: rows only in the target are
+listed with "-" and left in place, to be deleted through the API if they
+should go.
+
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	ctx := context.Background()
+	changes, err := f.changeset(ctx, "sync")
+	if err != nil {
+		return err
+	}
+	if err := changes.WriteText(os.Stdout); err != nil {
+		return err
+	}
+
+	upserts := changes.Upserts()
+	if len(upserts) == 0 {
+		fmt.Println("\nNothing to import.")
+		return nil
+	}
+
+	report, err := postImport(ctx, f.target, f.targetKey, upserts, !*apply)
+	if err != nil {
+		return fmt.Errorf("sync: importing into target: %w", err)
+	}
+
+	fmt.Println()
+	for _, e := range report.Entities {
+		fmt.Printf("%-16s %d inserted, %d updated, %d unchanged\n", e.Entity, e.Inserted, e.Updated, e.Unchanged)
+		for _, rowErr := range e.Errors {
+			fmt.Printf("  row %d %s: %s\n", rowErr.Row, rowErr.Column, rowErr.Message)
+		}
+	}
+	switch {
+	case report.ErrorCount > 0:
+		return fmt.Errorf("sync: the target rejected %d rows, nothing was imported", report.ErrorCount)
+	case report.DryRun:
+		fmt.Println("\nDry run: the target validated the changes. Run again with -apply to import them.")
+	default:
+		fmt.Println("\nApplied. Rows only in the target are left in place.")
+	}
+	return nil
+}
+
+func fetchExport(ctx context.Context, baseURL, apiKey, entities string) ([]tabular.Table, error) {
+	query := url.Values{"format": {"jsonl"}}
+	if entities != "" {
+		query.Set("entities", entities)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/export?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	setAPIKey(req, apiKey)
+	resp, err := syncClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	return tabular.ReadJSONL(resp.Body, "entity", "")
+}
+
+func postImport(ctx context.Context, baseURL, apiKey string, tables []tabular.Table, dryRun bool) (handlers.ImportReport, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if err := mw.WriteField("dry_run", fmt.Sprint(dryRun)); err != nil {
+		return handlers.ImportReport{}, err
+	}
+	fw, err := mw.CreateFormFile("file", "changes.jsonl")
+	if err != nil {
+		return handlers.ImportReport{}, err
+	}
+	if err := tabular.WriteJSONL(fw, "entity", tables...); err != nil {
+		return handlers.ImportReport{}, err
+	}
+	if err := mw.Close(); err != nil {
+		return handlers.ImportReport{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/import", &body)
+	if err != nil {
+		return handlers.ImportReport{}, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	setAPIKey(req, apiKey)
+
+	resp, err := syncClient.Do(req)
+	if err != nil {
+		return handlers.ImportReport{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnprocessableEntity {
+		return handlers.ImportReport{}, responseError(resp)
+	}
+	var report handlers.ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return handlers.ImportReport{}, err
+	}
+	return report, nil
+}
+
+func setAPIKey(req *http.Request, apiKey string) {
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+}
+
+func responseError(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	text := strings.TrimSpace(string(message))
+	if text == "" {
+		return errors.New(resp.Status)
+	}
+	return fmt.Errorf("%s: %s", resp.Status, text)
+}
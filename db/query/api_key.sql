@@ -0,0 +1,26 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_key (name, role, key_id, key_hash) VALUES ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetAPIKey :one
+SELECT * FROM api_key WHERE id = $1;
+
+-- name: GetAPIKeyByKeyID :one
+SELECT * FROM api_key WHERE key_id = $1;
+
+-- name: ListAPIKeys :many
+SELECT * FROM api_key ORDER BY id;
+
+-- name: RotateAPIKey :one
+UPDATE api_key SET key_id = $2, key_hash = $3, rotated_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_key SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: SetAPIKeyRole :one
+UPDATE api_key SET role = $2 WHERE id = $1 RETURNING *;
+
+-- name: TouchAPIKey :exec
+UPDATE api_key SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
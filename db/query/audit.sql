@@ -0,0 +1,9 @@
+-- name: SetAuditContext :exec
+-- Identifies the caller in the audit entries of the current transaction.
+SELECT set_config('uurl.actor', sqlc.arg(actor)::text, true),
+       set_config('uurl.request_id', sqlc.arg(request_id)::text, true);
+
+-- name: GetAuditHistory :many
+SELECT * FROM audit_log
+WHERE entity = $1 AND entity_id = $2
+ORDER BY id;
@@ -1,5 +1,5 @@
 -- name: GetAllCountries :many
-SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3 FROM country;
+SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3, iso3166_numeric FROM country WHERE deleted_at IS NULL ORDER BY id;
 
 -- name: InsertCountry :one
 INSERT INTO country(
@@ -8,16 +8,46 @@ INSERT INTO country(
     tld,
     iso3166_2_a1,
     iso3166_2_a3,
+    iso3166_numeric,
     created_at,
     updated_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id;
 
 -- name: GetCountryById :one
-SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3 FROM country where id = $1;
+SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3, iso3166_numeric, version, deleted_at, status, deprecated_at, replaced_by FROM country where id = $1;
 
--- name: GetFilteredCountry :many
-SELECT DISTINCT ON (ctr.id) id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3
-FROM country ctr
-         LEFT JOIN country_language cl ON ctr.id = cl.country_id
-WHERE ($1::int[] IS NULL OR language_id = ANY($1::int[]));
+-- name: GetExistingCountryIDs :many
+SELECT id FROM country WHERE id = ANY(sqlc.arg(ids)::int[]) AND deleted_at IS NULL;
+
+-- name: UpdateCountry :exec
+UPDATE country SET name = $2, official_state_name = $3, tld = $4, iso3166_2_a3 = $5, iso3166_numeric = $6, updated_at = NOW() WHERE id = $1;
+
+-- name: GetCountryLanguagesForExport :many
+SELECT c.iso3166_2_a1, l.iso_639_1
+FROM country_language cl
+         JOIN country c ON c.id = cl.country_id
+         JOIN language l ON l.id = cl.language_id
+WHERE c.deleted_at IS NULL AND l.deleted_at IS NULL
+ORDER BY c.iso3166_2_a1, l.iso_639_1;
+
+-- name: InsertCountryLanguage :exec
+INSERT INTO country_language (country_id, language_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: DeleteCountry :execrows
+UPDATE country SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreCountry :execrows
+UPDATE country SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: PurgeCountries :execrows
+DELETE FROM country WHERE deleted_at < sqlc.arg(deleted_before)::timestamptz;
+
+-- name: SetCountryStatus :execrows
+UPDATE country
+SET status = sqlc.arg(status), deprecated_at = sqlc.narg(deprecated_at), replaced_by = sqlc.narg(replaced_by), updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: LockCountry :one
+-- Locks the row for the rest of the transaction.
+SELECT version FROM country WHERE id = $1 FOR UPDATE;
@@ -0,0 +1,71 @@
+-- name: GetCountryByIdAsOf :one
+SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3, iso3166_numeric, version, deleted_at, status, deprecated_at, replaced_by FROM country_history
+WHERE id = sqlc.arg(id) AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of));
+
+-- name: GetLanguageTagByIDAsOf :one
+SELECT id, name, iso_639_1, iso_639_2, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by FROM language_history
+WHERE id = sqlc.arg(id) AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of));
+
+-- name: GetVariantByIDAsOf :one
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant_history
+WHERE id = sqlc.arg(id) AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of));
+
+-- name: GetVariantsByLanguageTagIDAsOf :many
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant_history
+WHERE language_id = sqlc.arg(language_id) AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of))
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired')
+ORDER BY id;
+
+-- name: GetVariantsAfterAsOf :many
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant_history
+WHERE (sqlc.narg(language_id)::integer IS NULL OR language_id = sqlc.narg(language_id)::integer)
+  AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of))
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired')
+  AND id > sqlc.arg(after_id)::integer
+ORDER BY id
+LIMIT sqlc.arg(row_limit)::integer;
+
+-- name: GetVariantsBeforeAsOf :many
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant_history
+WHERE (sqlc.narg(language_id)::integer IS NULL OR language_id = sqlc.narg(language_id)::integer)
+  AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of))
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired')
+  AND id < sqlc.arg(before_id)::integer
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit)::integer;
+
+-- name: CountVariantsAsOf :one
+SELECT count(id) FROM variant_history
+WHERE (sqlc.narg(language_id)::integer IS NULL OR language_id = sqlc.narg(language_id)::integer)
+  AND valid_from <= sqlc.arg(as_of) AND (valid_to IS NULL OR valid_to > sqlc.arg(as_of))
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired');
+
+-- name: RevertCountry :execrows
+-- Copies a version back into the row, which records it as a new version.
+UPDATE country c
+SET name = h.name, official_state_name = h.official_state_name, tld = h.tld, iso3166_2_a1 = h.iso3166_2_a1,
+    iso3166_2_a3 = h.iso3166_2_a3, iso3166_numeric = h.iso3166_numeric, status = h.status,
+    deprecated_at = h.deprecated_at, replaced_by = h.replaced_by, updated_at = NOW()
+FROM country_history h
+WHERE c.id = sqlc.arg(id) AND h.id = c.id AND h.version = sqlc.arg(version);
+
+-- name: RevertLanguage :execrows
+-- Copies a version back into the row, which records it as a new version.
+UPDATE language l
+SET name = h.name, iso_639_1 = h.iso_639_1, iso_639_2 = h.iso_639_2, status = h.status, deprecated_at = h.deprecated_at,
+    replaced_by = h.replaced_by, updated_at = NOW()
+FROM language_history h
+WHERE l.id = sqlc.arg(id) AND h.id = l.id AND h.version = sqlc.arg(version);
+
+-- name: RevertVariant :execrows
+-- Copies a version back into the row, which records it as a new version.
+UPDATE variant v
+SET language_id = h.language_id, country_id = h.country_id, variant_tag = h.variant_tag,
+    description = h.description, status = h.status, deprecated_at = h.deprecated_at, replaced_by = h.replaced_by,
+    updated_at = NOW()
+FROM variant_history h
+WHERE v.id = sqlc.arg(id) AND h.id = v.id AND h.version = sqlc.arg(version);
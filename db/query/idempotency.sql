@@ -0,0 +1,27 @@
+-- name: ClaimIdempotencyKey :execrows
+-- Claims a key that is unused or expired; no rows are affected when the key
+-- is already in use.
+INSERT INTO idempotency_key (principal, key, fingerprint, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (principal, key) DO UPDATE
+SET fingerprint = EXCLUDED.fingerprint,
+    status_code = NULL,
+    content_type = NULL,
+    response_body = NULL,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_key.expires_at <= NOW();
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_key WHERE principal = $1 AND key = $2;
+
+-- name: SaveIdempotentResponse :exec
+UPDATE idempotency_key
+SET status_code = $3, content_type = $4, response_body = $5, expires_at = $6
+WHERE principal = $1 AND key = $2;
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_key WHERE principal = $1 AND key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_key WHERE expires_at <= NOW();
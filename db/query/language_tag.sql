@@ -1,8 +1,51 @@
 -- name: GetAllLanguageTags :many
-SELECT id, name, iso_639_1, iso_639_2 FROM language;
+SELECT id, name, iso_639_1, iso_639_2, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by FROM language WHERE deleted_at IS NULL ORDER BY id;
 
 -- name: GetLanguageTagByID :one
-SELECT id, name, iso_639_1, iso_639_2 FROM language WHERE id = $1;
+SELECT id, name, iso_639_1, iso_639_2, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by FROM language WHERE id = $1;
 
 -- name: InsertLanguageTag :one
 INSERT INTO language (name, iso_639_1, iso_639_2) VALUES ($1, $2, $3) RETURNING id;
+
+-- name: GetLanguageCounts :many
+SELECT l.id,
+       v.variant_count,
+       c.country_count,
+       lu.locale_count
+FROM unnest(sqlc.arg(ids)::int[]) AS l(id)
+         CROSS JOIN LATERAL (SELECT count(*) AS variant_count FROM (
+                                 SELECT id FROM variant
+                                 WHERE sqlc.narg(as_of)::timestamptz IS NULL AND language_id = l.id AND deleted_at IS NULL
+                                 UNION ALL
+                                 SELECT id FROM variant_history
+                                 WHERE language_id = l.id AND deleted_at IS NULL AND valid_from <= sqlc.narg(as_of)::timestamptz
+                                   AND (valid_to IS NULL OR valid_to > sqlc.narg(as_of)::timestamptz)) vs) v
+         CROSS JOIN LATERAL (SELECT count(*) AS country_count FROM country_language
+                             WHERE sqlc.arg(with_countries)::boolean AND language_id = l.id) c
+         CROSS JOIN LATERAL (SELECT count(DISTINCT locale) AS locale_count FROM locale_url
+                             WHERE sqlc.arg(with_locales)::boolean AND language_id = l.id) lu;
+
+-- name: GetExistingLanguageIDs :many
+SELECT id FROM language WHERE id = ANY(sqlc.arg(ids)::int[]) AND deleted_at IS NULL;
+
+-- name: UpdateLanguageTag :exec
+UPDATE language SET name = $2, iso_639_2 = $3, updated_at = NOW() WHERE id = $1;
+
+-- name: DeleteLanguage :execrows
+UPDATE language SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreLanguage :execrows
+UPDATE language SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: PurgeLanguages :execrows
+-- Also removes the variants of the languages, deleted or not.
+DELETE FROM language WHERE deleted_at < sqlc.arg(deleted_before)::timestamptz;
+
+-- name: SetLanguageStatus :execrows
+UPDATE language
+SET status = sqlc.arg(status), deprecated_at = sqlc.narg(deprecated_at), replaced_by = sqlc.narg(replaced_by), updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: LockLanguage :one
+-- Locks the row for the rest of the transaction.
+SELECT version FROM language WHERE id = $1 FOR UPDATE;
@@ -1,24 +1,98 @@
 -- name: GetVariantsByLanguageTagID :many
-SELECT id, created_at, updated_at, variant_tag, description
-FROM variant WHERE language_id = $1;
+SELECT * FROM variant
+WHERE language_id = sqlc.arg(language_id) AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired')
+ORDER BY id;
+
+-- name: GetVariantByID :one
+SELECT * FROM variant WHERE id = $1;
 
--- name: InsertVariant :exec
+-- name: InsertVariant :one
 INSERT INTO variant (language_id, variant_tag, description, country_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;
+
+-- name: InsertVariants :many
+-- Returns each row with the 1-based position of its item in the arrays. The
+-- IDs are drawn before the insert, since RETURNING does not keep the order of
+-- the input.
+WITH input AS (
+    SELECT nextval(pg_get_serial_sequence('variant', 'id'))::int AS id, u.*
+    FROM unnest(sqlc.arg(language_ids)::int[], sqlc.arg(country_ids)::int[],
+                sqlc.arg(variant_tags)::varchar[], sqlc.arg(descriptions)::text[])
+             WITH ORDINALITY AS u(language_id, country_id, variant_tag, description, position)
+),
+inserted AS (
+    INSERT INTO variant (id, language_id, country_id, variant_tag, description)
+    SELECT id, NULLIF(language_id, 0), NULLIF(country_id, 0), variant_tag, description
+    FROM input
+    RETURNING *
+)
+SELECT input.position::int AS position, inserted.*
+FROM inserted
+         JOIN input ON input.id = inserted.id
+ORDER BY input.position;
+
+-- name: UpdateVariant :execrows
+UPDATE variant set language_id = $2, variant_tag = $3, description = $4, updated_at = $5, country_id = $6 where id = $1 AND deleted_at IS NULL;
+
+-- name: PatchVariant :execrows
+UPDATE variant SET
+    language_id = CASE WHEN sqlc.arg(set_language_id)::boolean THEN sqlc.narg(language_id)::integer ELSE language_id END,
+    country_id = CASE WHEN sqlc.arg(set_country_id)::boolean THEN sqlc.narg(country_id)::integer ELSE country_id END,
+    variant_tag = COALESCE(sqlc.narg(variant_tag)::varchar, variant_tag),
+    description = CASE WHEN sqlc.arg(set_description)::boolean THEN sqlc.narg(description)::text ELSE description END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: DeleteVariant :execrows
+UPDATE variant SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;
 
--- name: UpdateVariant :exec
-UPDATE variant set language_id = $2, variant_tag = $3, description = $4, updated_at = $5 where id = $1;
+-- name: RestoreVariant :execrows
+UPDATE variant SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: PurgeVariants :execrows
+DELETE FROM variant WHERE deleted_at < sqlc.arg(deleted_before)::timestamptz;
 
 -- name: GetVariantCount :one
-SELECT count(id) FROM variant WHERE language_id = $1;
+SELECT count(id) FROM variant WHERE language_id = $1 AND deleted_at IS NULL;
 
--- name: GetPaginatedVariantsWithFilter :many
+-- name: GetVariantsAfter :many
 SELECT * FROM variant
-WHERE language_id = sqlc.arg(language_id)::integer
+WHERE (sqlc.narg(language_id)::integer IS NULL OR language_id = sqlc.narg(language_id)::integer)
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired')
+  AND id > sqlc.arg(after_id)::integer
 ORDER BY id
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg(row_limit)::integer;
 
--- name: GetPaginatedVariantsWithoutFilter :many
+-- name: GetVariantsBefore :many
 SELECT * FROM variant
-ORDER BY id
-LIMIT $1 OFFSET $2;
+WHERE (sqlc.narg(language_id)::integer IS NULL OR language_id = sqlc.narg(language_id)::integer)
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired')
+  AND id < sqlc.arg(before_id)::integer
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit)::integer;
+
+-- name: CountVariants :one
+SELECT count(id) FROM variant
+WHERE (sqlc.narg(language_id)::integer IS NULL OR language_id = sqlc.narg(language_id)::integer)
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.arg(include_retired)::boolean OR status <> 'retired');
+
+-- name: GetVariantsForExport :many
+SELECT v.id, l.iso_639_1, c.iso3166_2_a1, v.variant_tag, v.description
+FROM variant v
+         LEFT JOIN language l ON l.id = v.language_id
+         LEFT JOIN country c ON c.id = v.country_id
+WHERE v.deleted_at IS NULL AND l.deleted_at IS NULL AND c.deleted_at IS NULL
+ORDER BY v.id;
+
+-- name: SetVariantStatus :execrows
+UPDATE variant
+SET status = sqlc.arg(status), deprecated_at = sqlc.narg(deprecated_at), replaced_by = sqlc.narg(replaced_by), updated_at = NOW()
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: LockVariant :one
+-- Locks the row for the rest of the transaction.
+SELECT version FROM variant WHERE id = $1 FOR UPDATE;
@@ -0,0 +1,37 @@
+-- name: GetLocaleURLsBySiteID :many
+SELECT lu.id, lu.site_id, lu.locale, lu.language_id, lu.country_id, lu.strategy, lu.value, c.tld
+FROM locale_url lu
+         LEFT JOIN country c ON c.id = lu.country_id
+WHERE lu.site_id = $1
+ORDER BY lu.id;
+
+-- name: GetLocaleURLsByLocale :many
+-- Returns the URL configuration of a locale on every site that has it.
+SELECT lu.site_id, s.scheme, s.host, s.query_param, lu.locale, lu.strategy, lu.value, c.tld
+FROM locale_url lu
+         JOIN site s ON s.id = lu.site_id
+         LEFT JOIN country c ON c.id = lu.country_id
+WHERE lower(lu.locale) = lower(sqlc.arg(locale))
+ORDER BY lu.site_id;
+
+-- name: GetLocaleURLLocales :many
+SELECT DISTINCT locale FROM locale_url ORDER BY locale;
+
+-- name: GetLocaleURLByID :one
+SELECT id, site_id, locale, language_id, country_id, strategy, value, updated_at FROM locale_url WHERE id = $1;
+
+-- name: InsertLocaleURL :one
+INSERT INTO locale_url (site_id, locale, language_id, country_id, strategy, value)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;
+
+-- name: UpdateLocaleURL :execrows
+UPDATE locale_url
+SET locale = $2, language_id = $3, country_id = $4, strategy = $5, value = $6, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteLocaleURL :execrows
+DELETE FROM locale_url WHERE id = $1;
+
+-- name: LockLocaleURL :one
+-- Locks the row for the rest of the transaction.
+SELECT updated_at FROM locale_url WHERE id = $1 FOR UPDATE;
@@ -0,0 +1,24 @@
+-- name: GetLocalizedNames :many
+SELECT id, entity_type, entity_id, locale, name, created_at, updated_at
+FROM localized_name
+WHERE (sqlc.narg(entity_type)::varchar IS NULL OR entity_type = sqlc.narg(entity_type)::varchar)
+  AND (sqlc.narg(entity_id)::int IS NULL OR entity_id = sqlc.narg(entity_id)::int)
+  AND (sqlc.narg(locale)::varchar IS NULL OR lower(locale) = lower(sqlc.narg(locale)::varchar))
+ORDER BY entity_type, entity_id, locale;
+
+-- name: GetLocalizedNameByID :one
+SELECT id, entity_type, entity_id, locale, name, created_at, updated_at FROM localized_name WHERE id = $1;
+
+-- name: InsertLocalizedName :one
+INSERT INTO localized_name (entity_type, entity_id, locale, name)
+VALUES ($1, $2, $3, $4) RETURNING id;
+
+-- name: UpdateLocalizedName :execrows
+UPDATE localized_name SET locale = $2, name = $3, updated_at = NOW() WHERE id = $1;
+
+-- name: DeleteLocalizedName :execrows
+DELETE FROM localized_name WHERE id = $1;
+
+-- name: LockLocalizedName :one
+-- Locks the row for the rest of the transaction.
+SELECT updated_at FROM localized_name WHERE id = $1 FOR UPDATE;
@@ -0,0 +1,38 @@
+-- name: LockChangeSequencer :exec
+-- Makes sequencers take turns, until the end of the transaction.
+SELECT pg_advisory_xact_lock(7368224950);
+
+-- name: SequenceOutboxEvents :one
+-- Numbers the committed events that have no seq yet, in the order of their
+-- ids, and returns the last seq given, or 0 when there were none.
+WITH sequenced AS (
+    UPDATE outbox_event
+    SET seq = numbered.seq
+    FROM (
+        SELECT id, nextval('outbox_event_seq') AS seq
+        FROM (
+            SELECT id FROM outbox_event
+            WHERE seq IS NULL
+            ORDER BY id
+            FOR UPDATE
+        ) unsequenced
+    ) numbered
+    WHERE outbox_event.id = numbered.id
+    RETURNING outbox_event.seq
+)
+SELECT COALESCE(MAX(seq), 0)::bigint AS last_seq FROM sequenced;
+
+-- name: NotifyChanges :exec
+-- Tells the listeners on the changes channel, once the transaction commits,
+-- that events up to seq can be read.
+SELECT pg_notify('changes', sqlc.arg(seq)::bigint::text);
+
+-- name: GetLastChangeSeq :one
+SELECT COALESCE(MAX(seq), 0)::bigint AS last_seq FROM outbox_event;
+
+-- name: ListChanges :many
+SELECT seq::bigint AS seq, type, entity, entity_id, occurred_at, data
+FROM outbox_event
+WHERE seq > sqlc.arg(after_seq)::bigint AND entity = ANY(sqlc.arg(entities)::text[])
+ORDER BY seq
+LIMIT sqlc.arg(max_changes);
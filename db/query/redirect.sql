@@ -0,0 +1,43 @@
+-- name: GetRedirects :many
+SELECT id, locale, match_type, source_path, target_path, status_code, priority, expires_at, created_at, updated_at
+FROM redirect
+WHERE (sqlc.narg(locale)::varchar IS NULL OR locale = sqlc.narg(locale)::varchar)
+ORDER BY priority DESC, id;
+
+-- name: GetRedirectByID :one
+SELECT id, locale, match_type, source_path, target_path, status_code, priority, expires_at, created_at, updated_at
+FROM redirect WHERE id = $1;
+
+-- name: InsertRedirect :one
+INSERT INTO redirect (locale, match_type, source_path, target_path, status_code, priority, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;
+
+-- name: UpdateRedirect :execrows
+UPDATE redirect
+SET locale = $2, match_type = $3, source_path = $4, target_path = $5, status_code = $6, priority = $7, expires_at = $8, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteRedirect :execrows
+DELETE FROM redirect WHERE id = $1;
+
+-- name: UpsertRedirect :exec
+INSERT INTO redirect (locale, match_type, source_path, target_path, status_code)
+VALUES ($1, 'exact', $2, $3, $4)
+ON CONFLICT (locale, match_type, source_path) DO UPDATE
+SET target_path = EXCLUDED.target_path, status_code = EXCLUDED.status_code, expires_at = NULL, updated_at = NOW();
+
+-- name: RetargetRedirects :exec
+UPDATE redirect SET target_path = sqlc.arg(new_target), updated_at = NOW()
+WHERE locale = sqlc.arg(locale) AND match_type = 'exact' AND target_path = sqlc.arg(old_target);
+
+-- name: DeleteRedirectBySource :exec
+DELETE FROM redirect WHERE locale = $1 AND match_type = 'exact' AND source_path = $2;
+
+-- name: LockRedirect :one
+-- Locks the row for the rest of the transaction.
+SELECT updated_at FROM redirect WHERE id = $1 FOR UPDATE;
+
+-- name: LockRedirectRules :exec
+-- Makes writers of redirect rules take turns, until the end of the
+-- transaction, so that each loop check sees the rules it is checked against.
+SELECT pg_advisory_xact_lock(7368224951);
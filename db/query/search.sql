@@ -0,0 +1,40 @@
+-- name: Search :many
+WITH params AS (
+    SELECT immutable_unaccent(lower(sqlc.arg(q)::text)) AS q,
+           to_tsquery('uurl_search', sqlc.arg(tsquery)::text) AS tsq
+),
+matches AS (
+    SELECT e.entity_type, e.entity_id, e.label, e.field, e.locale, e.value,
+           CASE
+               WHEN immutable_unaccent(lower(e.value)) = p.q THEN 1.0
+               ELSE greatest(
+                       similarity(immutable_unaccent(lower(e.value)), p.q),
+                       CASE WHEN immutable_unaccent(lower(e.value)) LIKE replace(replace(replace(p.q, '\', '\\'), '%', '\%'), '_', '\_') || '%' THEN 0.8 ELSE 0 END,
+                       ts_rank(to_tsvector('uurl_search', e.value), p.tsq))
+           END AS score
+    FROM search_entry e, params p
+    WHERE (cardinality(sqlc.arg(types)::text[]) = 0 OR e.entity_type = ANY(sqlc.arg(types)::text[]))
+      AND (immutable_unaccent(lower(e.value)) % p.q
+        OR immutable_unaccent(lower(e.value)) LIKE '%' || replace(replace(replace(p.q, '\', '\\'), '%', '\%'), '_', '\_') || '%'
+        OR to_tsvector('uurl_search', e.value) @@ p.tsq)
+),
+best AS (
+    SELECT DISTINCT ON (entity_type, entity_id) *
+    FROM matches
+    ORDER BY entity_type, entity_id, score DESC
+),
+ranked AS (
+    SELECT *, row_number() OVER (PARTITION BY entity_type ORDER BY score DESC, entity_id) AS position
+    FROM best
+)
+SELECT r.entity_type::text AS entity_type,
+       r.entity_id::integer AS entity_id,
+       r.label::text AS label,
+       r.field::text AS field,
+       r.locale,
+       r.value::text AS value,
+       r.score::float8 AS score,
+       ts_headline('uurl_search', r.value, p.tsq, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS highlight
+FROM ranked r, params p
+WHERE r.position <= sqlc.arg(per_type_limit)::integer
+ORDER BY r.score DESC, r.entity_type, r.entity_id;
@@ -0,0 +1,80 @@
+-- name: SeedCountries :one
+WITH input AS (
+    SELECT *
+    FROM unnest(sqlc.arg(codes)::varchar[], sqlc.arg(alpha3_codes)::varchar[], sqlc.arg(numeric_codes)::varchar[],
+                sqlc.arg(names)::varchar[], sqlc.arg(official_state_names)::varchar[], sqlc.arg(tlds)::varchar[])
+             AS u(iso3166_2_a1, iso3166_2_a3, iso3166_numeric, name, official_state_name, tld)
+),
+filled AS (
+    UPDATE country c SET iso3166_numeric = i.iso3166_numeric, updated_at = NOW()
+    FROM input i
+    WHERE upper(c.iso3166_2_a1) = i.iso3166_2_a1 AND c.iso3166_numeric IS NULL
+    RETURNING c.id
+),
+inserted AS (
+    INSERT INTO country (name, official_state_name, tld, iso3166_2_a1, iso3166_2_a3, iso3166_numeric)
+    SELECT i.name, NULLIF(i.official_state_name, ''), i.tld, i.iso3166_2_a1, i.iso3166_2_a3, i.iso3166_numeric
+    FROM input i
+    WHERE NOT EXISTS (SELECT 1 FROM country c WHERE upper(c.iso3166_2_a1) = i.iso3166_2_a1)
+    RETURNING id
+)
+SELECT (SELECT count(*) FROM inserted)::bigint AS inserted,
+       (SELECT count(*) FROM filled)::bigint   AS numeric_filled;
+
+-- name: SeedLanguages :one
+WITH input AS (
+    SELECT *
+    FROM unnest(sqlc.arg(codes)::varchar[], sqlc.arg(alpha3_codes)::varchar[], sqlc.arg(names)::varchar[])
+             AS u(iso_639_1, iso_639_2, name)
+),
+inserted AS (
+    INSERT INTO language (name, iso_639_1, iso_639_2)
+    SELECT i.name, i.iso_639_1, i.iso_639_2
+    FROM input i
+    WHERE NOT EXISTS (SELECT 1 FROM language l WHERE lower(l.iso_639_1) = i.iso_639_1)
+    RETURNING id
+)
+SELECT count(*) FROM inserted;
+
+-- name: SeedVariants :one
+WITH input AS (
+    SELECT u.country, u.variant_tag, u.description, l.id AS language_id, c.id AS country_id
+    FROM unnest(sqlc.arg(languages)::varchar[], sqlc.arg(countries)::varchar[],
+                sqlc.arg(variant_tags)::varchar[], sqlc.arg(descriptions)::text[])
+             AS u(language, country, variant_tag, description)
+             LEFT JOIN LATERAL (SELECT id FROM language WHERE lower(iso_639_1) = u.language ORDER BY id LIMIT 1) l ON true
+             LEFT JOIN LATERAL (SELECT id FROM country WHERE upper(iso3166_2_a1) = u.country ORDER BY id LIMIT 1) c ON true
+),
+resolved AS (
+    SELECT * FROM input WHERE language_id IS NOT NULL AND (country = '' OR country_id IS NOT NULL)
+),
+inserted AS (
+    INSERT INTO variant (language_id, country_id, variant_tag, description)
+    SELECT r.language_id, r.country_id, r.variant_tag, NULLIF(r.description, '')
+    FROM resolved r
+    WHERE NOT EXISTS (SELECT 1 FROM variant v
+                      WHERE v.language_id = r.language_id
+                        AND v.country_id IS NOT DISTINCT FROM r.country_id
+                        AND v.variant_tag = r.variant_tag)
+    RETURNING id
+)
+SELECT (SELECT count(*) FROM inserted)::bigint                                  AS inserted,
+       ((SELECT count(*) FROM input) - (SELECT count(*) FROM resolved))::bigint AS skipped;
+
+-- name: SeedCountryLanguages :one
+WITH input AS (
+    SELECT c.id AS country_id, l.id AS language_id
+    FROM unnest(sqlc.arg(countries)::varchar[], sqlc.arg(languages)::varchar[]) AS u(country, language)
+             LEFT JOIN LATERAL (SELECT id FROM country WHERE upper(iso3166_2_a1) = u.country ORDER BY id LIMIT 1) c ON true
+             LEFT JOIN LATERAL (SELECT id FROM language WHERE lower(iso_639_1) = u.language ORDER BY id LIMIT 1) l ON true
+),
+inserted AS (
+    INSERT INTO country_language (country_id, language_id)
+    SELECT country_id, language_id
+    FROM input
+    WHERE country_id IS NOT NULL AND language_id IS NOT NULL
+    ON CONFLICT DO NOTHING
+    RETURNING country_id
+)
+SELECT (SELECT count(*) FROM inserted)::bigint                                       AS inserted,
+       (SELECT count(*) FROM input WHERE country_id IS NULL OR language_id IS NULL)::bigint AS skipped;
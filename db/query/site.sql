@@ -0,0 +1,9 @@
+-- name: GetAllSites :many
+SELECT id, name, scheme, host, query_param, default_locale FROM site ORDER BY id;
+
+-- name: GetSiteByID :one
+SELECT id, name, scheme, host, query_param, default_locale FROM site WHERE id = $1;
+
+-- name: InsertSite :one
+INSERT INTO site (name, scheme, host, query_param, default_locale)
+VALUES ($1, $2, $3, $4, $5) RETURNING id;
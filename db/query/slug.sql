@@ -0,0 +1,27 @@
+-- name: GetSlugs :many
+SELECT id, resource_key, locale, slug FROM slug
+WHERE (sqlc.narg(locale)::varchar IS NULL OR locale = sqlc.narg(locale)::varchar)
+  AND (sqlc.narg(resource_key)::varchar IS NULL OR resource_key = sqlc.narg(resource_key)::varchar)
+ORDER BY resource_key, locale;
+
+-- name: GetSlugByID :one
+SELECT id, resource_key, locale, slug, updated_at FROM slug WHERE id = $1;
+
+-- name: SlugExists :one
+SELECT EXISTS(SELECT 1 FROM slug WHERE locale = $1 AND slug = $2);
+
+-- name: InsertSlug :one
+INSERT INTO slug (resource_key, locale, slug) VALUES ($1, $2, $3) RETURNING id;
+
+-- name: UpdateSlug :exec
+UPDATE slug SET slug = $2, updated_at = NOW() WHERE id = $1;
+
+-- name: InsertSlugHistory :exec
+INSERT INTO slug_history (slug_id, slug) VALUES ($1, $2);
+
+-- name: GetSlugHistory :many
+SELECT slug, replaced_at FROM slug_history WHERE slug_id = $1 ORDER BY replaced_at DESC, id DESC;
+
+-- name: LockSlug :one
+-- Locks the row for the rest of the transaction.
+SELECT updated_at FROM slug WHERE id = $1 FOR UPDATE;
@@ -0,0 +1,72 @@
+-- name: CreateWebhook :one
+INSERT INTO webhook (url, secret, event_types) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetWebhook :one
+SELECT * FROM webhook WHERE id = $1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhook ORDER BY id;
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhook WHERE id = $1;
+
+-- name: DispatchOutboxEvents :one
+-- Queues up to max_events undispatched events for the webhooks subscribed to
+-- them and returns how many events there were.
+WITH events AS (
+    UPDATE outbox_event SET dispatched_at = NOW()
+    WHERE id IN (
+        SELECT id FROM outbox_event
+        WHERE dispatched_at IS NULL
+        ORDER BY id
+        LIMIT sqlc.arg(max_events)
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, type
+), queued AS (
+    INSERT INTO webhook_delivery (webhook_id, event_id)
+    SELECT webhook.id, events.id
+    FROM events
+    JOIN webhook ON cardinality(webhook.event_types) = 0 OR events.type = ANY(webhook.event_types)
+    ON CONFLICT (webhook_id, event_id) DO NOTHING
+    RETURNING id
+)
+SELECT COUNT(*) FROM events;
+
+-- name: ClaimDueDeliveries :many
+-- Leases up to max_deliveries pending deliveries that are due until
+-- lease_until, so that other workers skip them while they are sent.
+WITH due AS (
+    UPDATE webhook_delivery SET next_attempt_at = sqlc.arg(lease_until)
+    WHERE id IN (
+        SELECT id FROM webhook_delivery
+        WHERE status = 'pending' AND next_attempt_at <= NOW()
+        ORDER BY next_attempt_at
+        LIMIT sqlc.arg(max_deliveries)
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, webhook_id, event_id, attempts
+)
+SELECT due.id, due.attempts, webhook.url, webhook.secret,
+       outbox_event.id AS event_id, outbox_event.type, outbox_event.entity,
+       outbox_event.entity_id, outbox_event.occurred_at, outbox_event.data
+FROM due
+JOIN webhook ON webhook.id = due.webhook_id
+JOIN outbox_event ON outbox_event.id = due.event_id
+ORDER BY due.id;
+
+-- name: RecordDeliveryAttempt :exec
+UPDATE webhook_delivery
+SET status = $2,
+    attempts = attempts + 1,
+    next_attempt_at = $3,
+    last_attempt_at = NOW(),
+    response_status = $4,
+    last_error = $5
+WHERE id = $1;
+
+-- name: RedeliverWebhookDelivery :execrows
+-- Sends a delivery again as soon as possible, with a fresh set of attempts.
+UPDATE webhook_delivery
+SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+WHERE id = $1 AND webhook_id = $2;
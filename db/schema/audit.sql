@@ -0,0 +1,96 @@
+-- Every change to the tables below, written by triggers in the transaction
+-- that made it, so that no code path can change data without a trace.
+-- before is null for creations and after for deletions.
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    entity VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    operation VARCHAR(6) NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+    before JSONB NOT NULL DEFAULT 'null',
+    after JSONB NOT NULL DEFAULT 'null'
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log(entity, entity_id, id);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END
+$$;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+-- audit_row records the change of a row. Its arguments are the entity name
+-- and the one or two columns that identify the row.
+--
+-- The application identifies the caller with the transaction-local settings
+-- uurl.actor and uurl.request_id; changes made without them, e.g. from psql,
+-- are attributed to the database user. API key hashes and webhook secrets are
+-- never recorded, and updates that only touch updated_at or last_used_at are
+-- not worth an entry.
+CREATE OR REPLACE FUNCTION audit_row() RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+DECLARE
+    before_row JSONB := 'null';
+    after_row JSONB := 'null';
+    row_data JSONB;
+    row_id TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        before_row := to_jsonb(OLD) - 'key_hash' - 'secret';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        after_row := to_jsonb(NEW) - 'key_hash' - 'secret';
+    END IF;
+    IF TG_OP = 'UPDATE' AND before_row - 'updated_at' - 'last_used_at' = after_row - 'updated_at' - 'last_used_at' THEN
+        RETURN NULL;
+    END IF;
+
+    row_data := CASE WHEN TG_OP = 'DELETE' THEN before_row ELSE after_row END;
+    row_id := row_data ->> TG_ARGV[1];
+    IF TG_NARGS > 2 THEN
+        row_id := row_id || ':' || (row_data ->> TG_ARGV[2]);
+    END IF;
+
+    INSERT INTO audit_log (actor, request_id, entity, entity_id, operation, before, after)
+    VALUES (COALESCE(NULLIF(current_setting('uurl.actor', true), ''), 'db:' || session_user),
+            COALESCE(current_setting('uurl.request_id', true), ''),
+            TG_ARGV[0],
+            row_id,
+            CASE TG_OP WHEN 'INSERT' THEN 'create' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END,
+            before_row,
+            after_row);
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER audit_api_key AFTER INSERT OR UPDATE OR DELETE ON api_key
+    FOR EACH ROW EXECUTE FUNCTION audit_row('api_key', 'id');
+CREATE TRIGGER audit_country AFTER INSERT OR UPDATE OR DELETE ON country
+    FOR EACH ROW EXECUTE FUNCTION audit_row('country', 'id');
+CREATE TRIGGER audit_country_language AFTER INSERT OR UPDATE OR DELETE ON country_language
+    FOR EACH ROW EXECUTE FUNCTION audit_row('country_language', 'country_id', 'language_id');
+CREATE TRIGGER audit_language AFTER INSERT OR UPDATE OR DELETE ON language
+    FOR EACH ROW EXECUTE FUNCTION audit_row('language', 'id');
+CREATE TRIGGER audit_locale_url AFTER INSERT OR UPDATE OR DELETE ON locale_url
+    FOR EACH ROW EXECUTE FUNCTION audit_row('locale_url', 'id');
+CREATE TRIGGER audit_localized_name AFTER INSERT OR UPDATE OR DELETE ON localized_name
+    FOR EACH ROW EXECUTE FUNCTION audit_row('localized_name', 'id');
+CREATE TRIGGER audit_redirect AFTER INSERT OR UPDATE OR DELETE ON redirect
+    FOR EACH ROW EXECUTE FUNCTION audit_row('redirect', 'id');
+CREATE TRIGGER audit_site AFTER INSERT OR UPDATE OR DELETE ON site
+    FOR EACH ROW EXECUTE FUNCTION audit_row('site', 'id');
+CREATE TRIGGER audit_slug AFTER INSERT OR UPDATE OR DELETE ON slug
+    FOR EACH ROW EXECUTE FUNCTION audit_row('slug', 'id');
+CREATE TRIGGER audit_variant AFTER INSERT OR UPDATE OR DELETE ON variant
+    FOR EACH ROW EXECUTE FUNCTION audit_row('variant', 'id');
+CREATE TRIGGER audit_webhook AFTER INSERT OR UPDATE OR DELETE ON webhook
+    FOR EACH ROW EXECUTE FUNCTION audit_row('webhook', 'id');
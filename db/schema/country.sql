@@ -5,6 +5,14 @@ CREATE TABLE country (
     tld varchar(3) not null,
     iso3166_2_A1 varchar(2) not null,
     iso3166_2_A3 varchar(3) not null,
+    iso3166_numeric char(3),
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    version INT NOT NULL DEFAULT 1,
+    deleted_at TIMESTAMPTZ,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'deprecated', 'retired')),
+    deprecated_at TIMESTAMPTZ,
+    replaced_by INT REFERENCES country(id) ON DELETE SET NULL
 );
+
+CREATE INDEX idx_country_deleted_at ON country(deleted_at) WHERE deleted_at IS NOT NULL;
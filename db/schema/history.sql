@@ -0,0 +1,93 @@
+-- Every version of the country, language and variant rows, the current one
+-- with a null valid_to, kept by the triggers below. A version is valid from
+-- valid_from included to valid_to excluded. Rows are copied whole, so the
+-- history tables must keep the columns of their table, in the same order,
+-- followed by valid_from and valid_to.
+CREATE TABLE country_history (
+    LIKE country,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    PRIMARY KEY (id, version)
+);
+
+CREATE TABLE language_history (
+    LIKE language,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    PRIMARY KEY (id, version)
+);
+
+CREATE TABLE variant_history (
+    LIKE variant,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_to TIMESTAMPTZ,
+    PRIMARY KEY (id, version)
+);
+
+CREATE INDEX idx_country_history_valid ON country_history(valid_from, valid_to);
+CREATE INDEX idx_language_history_valid ON language_history(valid_from, valid_to);
+CREATE INDEX idx_variant_history_valid ON variant_history(valid_from, valid_to);
+CREATE INDEX idx_variant_history_language_id ON variant_history(language_id);
+
+-- Rows that predate versioning start their history when they were created.
+INSERT INTO country_history SELECT country.*, created_at, NULL FROM country;
+INSERT INTO language_history SELECT language.*, created_at, NULL FROM language;
+INSERT INTO variant_history SELECT variant.*, created_at, NULL FROM variant;
+
+-- number_version numbers the versions of a row: 1 when it is created, and
+-- one more on each update that changes it. Clients cannot set it.
+CREATE OR REPLACE FUNCTION number_version() RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.version := 1;
+        RETURN NEW;
+    END IF;
+
+    -- Like the audit log, updates that only touch updated_at are no change.
+    NEW.version := OLD.version;
+    IF to_jsonb(NEW) - 'updated_at' IS DISTINCT FROM to_jsonb(OLD) - 'updated_at' THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END
+$$;
+
+-- record_version closes the current version of a row in its history table
+-- and, unless the row was deleted, opens the new one.
+CREATE OR REPLACE FUNCTION record_version() RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+DECLARE
+    history TEXT := TG_TABLE_NAME || '_history';
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.version = OLD.version THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP <> 'INSERT' THEN
+        EXECUTE format('UPDATE %I SET valid_to = NOW() WHERE id = $1 AND valid_to IS NULL', history)
+            USING OLD.id;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        EXECUTE format('INSERT INTO %I SELECT ($1).*, NOW(), NULL', history)
+            USING NEW;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER number_country_version BEFORE INSERT OR UPDATE ON country
+    FOR EACH ROW EXECUTE FUNCTION number_version();
+CREATE TRIGGER number_language_version BEFORE INSERT OR UPDATE ON language
+    FOR EACH ROW EXECUTE FUNCTION number_version();
+CREATE TRIGGER number_variant_version BEFORE INSERT OR UPDATE ON variant
+    FOR EACH ROW EXECUTE FUNCTION number_version();
+
+CREATE TRIGGER record_country_version AFTER INSERT OR UPDATE OR DELETE ON country
+    FOR EACH ROW EXECUTE FUNCTION record_version();
+CREATE TRIGGER record_language_version AFTER INSERT OR UPDATE OR DELETE ON language
+    FOR EACH ROW EXECUTE FUNCTION record_version();
+CREATE TRIGGER record_variant_version AFTER INSERT OR UPDATE OR DELETE ON variant
+    FOR EACH ROW EXECUTE FUNCTION record_version();
@@ -0,0 +1,18 @@
+-- Responses to create requests sent with an Idempotency-Key, so that retries
+-- get the first response instead of creating the resource again. Keys are
+-- scoped to the caller that sent them. status_code is null while the first
+-- request is still being handled, and expires_at is then a short lease after
+-- which the key can be claimed again if the request never finished.
+CREATE TABLE idempotency_key (
+    principal VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    fingerprint BYTEA NOT NULL,
+    status_code INT,
+    content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (principal, key)
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key(expires_at);
@@ -2,5 +2,14 @@ CREATE TABLE language (
                              id SERIAL PRIMARY KEY,
                              name VARCHAR(255) NOT NULL,
                              iso_639_1 CHAR(2) NOT NULL,
-                             iso_639_2 CHAR(3) NOT NULL
+                             iso_639_2 CHAR(3) NOT NULL,
+                             created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                             updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                             version INT NOT NULL DEFAULT 1,
+                             deleted_at TIMESTAMPTZ,
+                             status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'deprecated', 'retired')),
+                             deprecated_at TIMESTAMPTZ,
+                             replaced_by INT REFERENCES language(id) ON DELETE SET NULL
 );
+
+CREATE INDEX idx_language_deleted_at ON language(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -5,7 +5,13 @@ CREATE TABLE variant (
                           created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
                           updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
                           variant_tag VARCHAR(255) NOT NULL,
-                          description TEXT
+                          description TEXT,
+                          version INT NOT NULL DEFAULT 1,
+                          deleted_at TIMESTAMPTZ,
+                          status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'deprecated', 'retired')),
+                          deprecated_at TIMESTAMPTZ,
+                          replaced_by INT REFERENCES variant(id) ON DELETE SET NULL
 );
 
 CREATE INDEX idx_variants_language_id ON language_tag_variants(language_id);
+CREATE INDEX idx_variant_deleted_at ON variant(deleted_at) WHERE deleted_at IS NOT NULL;
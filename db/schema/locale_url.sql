@@ -0,0 +1,14 @@
+CREATE TABLE locale_url (
+    id SERIAL PRIMARY KEY,
+    site_id INT NOT NULL REFERENCES site(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    language_id INT REFERENCES language(id) ON DELETE SET NULL,
+    country_id INT REFERENCES country(id) ON DELETE SET NULL,
+    strategy VARCHAR(20) NOT NULL CHECK (strategy IN ('path_prefix', 'subdomain', 'cctld', 'query_param')),
+    value VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (site_id, locale)
+);
+
+CREATE INDEX idx_locale_url_site_id ON locale_url(site_id);
@@ -0,0 +1,96 @@
+-- Changes to the reference data, written by triggers in the transaction that
+-- made them so that an event exists if and only if its change was committed.
+-- The webhook worker queues each event for the webhooks subscribed to it and
+-- sets dispatched_at.
+--
+-- ids follow the order the changes were made in, not the order they were
+-- committed in, so the change feed numbers the committed events with seq,
+-- one sequencer at a time, for its clients to resume from safely.
+CREATE TABLE outbox_event (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    type VARCHAR(64) NOT NULL,
+    entity VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    data JSONB NOT NULL,
+    dispatched_at TIMESTAMPTZ,
+    seq BIGINT UNIQUE
+);
+
+CREATE SEQUENCE outbox_event_seq;
+
+CREATE INDEX idx_outbox_event_undispatched ON outbox_event(id) WHERE dispatched_at IS NULL;
+CREATE INDEX idx_outbox_event_unsequenced ON outbox_event(id) WHERE seq IS NULL;
+
+-- outbox_row records the change of a row as an event of type
+-- uurl.<entity>.<created|updated|deleted|restored>. Its arguments are the
+-- entity name and the one or two columns that identify the row, as for
+-- audit_row. Soft deletes and restores are deleted and restored events, and
+-- purging a row that was already deleted is not an event. data is the row
+-- after the change, or before it for deletions. Listeners on the
+-- outbox_event channel are notified once the transaction commits.
+CREATE OR REPLACE FUNCTION outbox_row() RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+DECLARE
+    before_row JSONB := 'null';
+    after_row JSONB := 'null';
+    row_data JSONB;
+    row_id TEXT;
+    operation TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        before_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        after_row := to_jsonb(NEW);
+    END IF;
+
+    IF TG_OP = 'INSERT' THEN
+        operation := 'created';
+    ELSIF TG_OP = 'DELETE' THEN
+        IF before_row ->> 'deleted_at' IS NOT NULL THEN
+            RETURN NULL;
+        END IF;
+        operation := 'deleted';
+    ELSIF before_row - 'updated_at' = after_row - 'updated_at' THEN
+        RETURN NULL;
+    ELSIF before_row ->> 'deleted_at' IS NULL AND after_row ->> 'deleted_at' IS NOT NULL THEN
+        operation := 'deleted';
+    ELSIF before_row ->> 'deleted_at' IS NOT NULL AND after_row ->> 'deleted_at' IS NULL THEN
+        operation := 'restored';
+    ELSE
+        operation := 'updated';
+    END IF;
+
+    row_data := CASE WHEN TG_OP = 'DELETE' THEN before_row ELSE after_row END;
+    row_id := row_data ->> TG_ARGV[1];
+    IF TG_NARGS > 2 THEN
+        row_id := row_id || ':' || (row_data ->> TG_ARGV[2]);
+    END IF;
+
+    INSERT INTO outbox_event (type, entity, entity_id, data)
+    VALUES ('uurl.' || TG_ARGV[0] || '.' || operation, TG_ARGV[0], row_id, row_data);
+    PERFORM pg_notify('outbox_event', '');
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER outbox_country AFTER INSERT OR UPDATE OR DELETE ON country
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('country', 'id');
+CREATE TRIGGER outbox_country_language AFTER INSERT OR UPDATE OR DELETE ON country_language
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('country_language', 'country_id', 'language_id');
+CREATE TRIGGER outbox_language AFTER INSERT OR UPDATE OR DELETE ON language
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('language', 'id');
+CREATE TRIGGER outbox_locale_url AFTER INSERT OR UPDATE OR DELETE ON locale_url
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('locale_url', 'id');
+CREATE TRIGGER outbox_localized_name AFTER INSERT OR UPDATE OR DELETE ON localized_name
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('localized_name', 'id');
+CREATE TRIGGER outbox_redirect AFTER INSERT OR UPDATE OR DELETE ON redirect
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('redirect', 'id');
+CREATE TRIGGER outbox_site AFTER INSERT OR UPDATE OR DELETE ON site
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('site', 'id');
+CREATE TRIGGER outbox_slug AFTER INSERT OR UPDATE OR DELETE ON slug
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('slug', 'id');
+CREATE TRIGGER outbox_variant AFTER INSERT OR UPDATE OR DELETE ON variant
+    FOR EACH ROW EXECUTE FUNCTION outbox_row('variant', 'id');
@@ -0,0 +1,13 @@
+CREATE TABLE redirect (
+    id SERIAL PRIMARY KEY,
+    locale VARCHAR(35) NOT NULL DEFAULT '',
+    match_type VARCHAR(10) NOT NULL DEFAULT 'exact' CHECK (match_type IN ('exact', 'prefix', 'regex')),
+    source_path VARCHAR(2048) NOT NULL,
+    target_path VARCHAR(2048) NOT NULL,
+    status_code INT NOT NULL DEFAULT 301 CHECK (status_code IN (301, 302, 307, 308)),
+    priority INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (locale, match_type, source_path)
+);
@@ -0,0 +1,50 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE because its dictionary can change, which keeps it
+-- out of index expressions. The dictionary is fixed here, so wrap it.
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+-- Accent-insensitive configuration used for tsvector matching and highlights.
+CREATE TEXT SEARCH CONFIGURATION uurl_search (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION uurl_search
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+
+-- Every searchable value, one row per entity and field. Deleted rows are
+-- left out.
+CREATE VIEW search_entry AS
+SELECT 'country' AS entity_type, id AS entity_id, name AS label, f.field, NULL::varchar AS locale, f.value
+FROM country,
+     LATERAL (VALUES ('name', name),
+                     ('official_state_name', official_state_name),
+                     ('iso3166_2_a1', iso3166_2_a1),
+                     ('iso3166_2_a3', iso3166_2_a3),
+                     ('tld', tld)) AS f(field, value)
+WHERE f.value IS NOT NULL AND deleted_at IS NULL
+UNION ALL
+SELECT 'language', id, name, f.field, NULL, f.value
+FROM language,
+     LATERAL (VALUES ('name', name::text),
+                     ('iso_639_1', iso_639_1::text),
+                     ('iso_639_2', iso_639_2::text)) AS f(field, value)
+WHERE deleted_at IS NULL
+UNION ALL
+SELECT ln.entity_type, ln.entity_id, COALESCE(c.name, l.name), 'localized_name', ln.locale, ln.name
+FROM localized_name ln
+         LEFT JOIN country c ON ln.entity_type = 'country' AND c.id = ln.entity_id
+         LEFT JOIN language l ON ln.entity_type = 'language' AND l.id = ln.entity_id
+WHERE c.deleted_at IS NULL AND l.deleted_at IS NULL
+UNION ALL
+SELECT 'variant', id, variant_tag, f.field, NULL, f.value
+FROM variant,
+     LATERAL (VALUES ('variant_tag', variant_tag::text),
+                     ('description', description)) AS f(field, value)
+WHERE f.value IS NOT NULL AND deleted_at IS NULL;
+
+CREATE INDEX idx_country_name_trgm ON country USING gin (immutable_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX idx_country_official_state_name_trgm ON country USING gin (immutable_unaccent(lower(official_state_name)) gin_trgm_ops);
+CREATE INDEX idx_language_name_trgm ON language USING gin (immutable_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX idx_localized_name_name_trgm ON localized_name USING gin (immutable_unaccent(lower(name)) gin_trgm_ops);
+CREATE INDEX idx_variant_description_trgm ON variant USING gin (immutable_unaccent(lower(description)) gin_trgm_ops);
@@ -0,0 +1,10 @@
+CREATE TABLE site (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    scheme VARCHAR(10) NOT NULL DEFAULT 'https',
+    host VARCHAR(255) NOT NULL UNIQUE,
+    query_param VARCHAR(50) NOT NULL DEFAULT 'lang',
+    default_locale VARCHAR(35),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
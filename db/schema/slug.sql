@@ -0,0 +1,19 @@
+CREATE TABLE slug (
+    id SERIAL PRIMARY KEY,
+    resource_key VARCHAR(255) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (resource_key, locale),
+    UNIQUE (locale, slug)
+);
+
+CREATE TABLE slug_history (
+    id SERIAL PRIMARY KEY,
+    slug_id INT NOT NULL REFERENCES slug(id) ON DELETE CASCADE,
+    slug VARCHAR(255) NOT NULL,
+    replaced_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_slug_history_slug_id ON slug_history(slug_id);
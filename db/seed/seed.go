@@ -0,0 +1,145 @@
+// Package seed bundles reference data for new environments: ISO 3166-1
+// countries, ISO 639-1 languages, common regional variants and the languages
+// spoken in each country. The datasets are CSV files in the columns GET
+// /export writes, so they can also be edited in a spreadsheet or sent to
+// POST /import.
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/tabular"
+)
+
+//go:embed data/*.csv
+var data embed.FS
+
+// Datasets are listed in the order they are loaded, so that variants and
+// country languages find the countries and languages they refer to.
+var Datasets = []string{"country", "language", "variant", "country_language"}
+
+type Result struct {
+	Dataset  string
+	Rows     int
+	Inserted int
+	Present  int
+	// Skipped counts rows that refer to a country or language that is not
+	// in the database, which happens when its dataset is left out.
+	Skipped int
+	// NumericFilled counts present countries whose missing numeric code was
+	// filled in.
+	NumericFilled int
+}
+
+// Load inserts the rows of the datasets in only, or of all datasets when only
+// is empty, that are not in the database yet. Rows are matched by their ISO
+// codes, so loading twice inserts nothing the second time. Everything is
+// loaded in a single transaction.
+func Load(ctx context.Context, db *sql.DB, only []string) ([]Result, error) {
+	for _, name := range only {
+		if !slices.Contains(Datasets, name) {
+			return nil, fmt.Errorf("seed: unknown dataset %q, expected one of %s", name, strings.Join(Datasets, ", "))
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	q := sqlc.New(tx)
+
+	if err := q.SetAuditContext(ctx, sqlc.SetAuditContextParams{Actor: "seed"}); err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	for _, name := range Datasets {
+		if len(only) > 0 && !slices.Contains(only, name) {
+			continue
+		}
+
+		t, err := readDataset(name)
+		if err != nil {
+			return nil, err
+		}
+
+		result := Result{Dataset: name, Rows: len(t.Rows)}
+		switch name {
+		case "country":
+			row, err := q.SeedCountries(ctx, sqlc.SeedCountriesParams{
+				Codes:              column(t, "iso3166_2_a1"),
+				Alpha3Codes:        column(t, "iso3166_2_a3"),
+				NumericCodes:       column(t, "iso3166_numeric"),
+				Names:              column(t, "name"),
+				OfficialStateNames: column(t, "official_state_name"),
+				Tlds:               column(t, "tld"),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("seed: loading countries: %w", err)
+			}
+			result.Inserted = int(row.Inserted)
+			result.NumericFilled = int(row.NumericFilled)
+		case "language":
+			inserted, err := q.SeedLanguages(ctx, sqlc.SeedLanguagesParams{
+				Codes:       column(t, "iso_639_1"),
+				Alpha3Codes: column(t, "iso_639_2"),
+				Names:       column(t, "name"),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("seed: loading languages: %w", err)
+			}
+			result.Inserted = int(inserted)
+		case "variant":
+			row, err := q.SeedVariants(ctx, sqlc.SeedVariantsParams{
+				Languages:    column(t, "language"),
+				Countries:    column(t, "country"),
+				VariantTags:  column(t, "variant_tag"),
+				Descriptions: column(t, "description"),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("seed: loading variants: %w", err)
+			}
+			result.Inserted, result.Skipped = int(row.Inserted), int(row.Skipped)
+		case "country_language":
+			row, err := q.SeedCountryLanguages(ctx, sqlc.SeedCountryLanguagesParams{
+				Countries: column(t, "country"),
+				Languages: column(t, "language"),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("seed: loading country languages: %w", err)
+			}
+			result.Inserted, result.Skipped = int(row.Inserted), int(row.Skipped)
+		}
+		result.Present = result.Rows - result.Inserted - result.Skipped
+		results = append(results, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func readDataset(name string) (tabular.Table, error) {
+	f, err := data.Open("data/" + name + ".csv")
+	if err != nil {
+		return tabular.Table{}, err
+	}
+	defer f.Close()
+	return tabular.ReadCSV(f, name)
+}
+
+func column(t tabular.Table, name string) []string {
+	values := make([]string, len(t.Rows))
+	for i := range t.Rows {
+		values[i] = t.Get(i, name)
+	}
+	return values
+}
@@ -13,8 +13,20 @@ import (
 	"github.com/lib/pq"
 )
 
+const deleteCountry = `-- name: DeleteCountry :execrows
+UPDATE country SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteCountry(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCountry, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getAllCountries = `-- name: GetAllCountries :many
-SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3 FROM country
+SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3, iso3166_numeric FROM country WHERE deleted_at IS NULL ORDER BY id
 `
 
 type GetAllCountriesRow struct {
@@ -24,6 +36,7 @@ type GetAllCountriesRow struct {
 	Tld               string         `json:"tld"`
 	Iso31662A1        string         `json:"iso3166_2_a1"`
 	Iso31662A3        string         `json:"iso3166_2_a3"`
+	Iso3166Numeric    sql.NullString `json:"iso3166_numeric"`
 }
 
 func (q *Queries) GetAllCountries(ctx context.Context) ([]GetAllCountriesRow, error) {
@@ -42,6 +55,7 @@ func (q *Queries) GetAllCountries(ctx context.Context) ([]GetAllCountriesRow, er
 			&i.Tld,
 			&i.Iso31662A1,
 			&i.Iso31662A3,
+			&i.Iso3166Numeric,
 		); err != nil {
 			return nil, err
 		}
@@ -57,7 +71,7 @@ func (q *Queries) GetAllCountries(ctx context.Context) ([]GetAllCountriesRow, er
 }
 
 const getCountryById = `-- name: GetCountryById :one
-SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3 FROM country where id = $1
+SELECT id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3, iso3166_numeric, version, deleted_at, status, deprecated_at, replaced_by FROM country where id = $1
 `
 
 type GetCountryByIdRow struct {
@@ -67,6 +81,12 @@ type GetCountryByIdRow struct {
 	Tld               string         `json:"tld"`
 	Iso31662A1        string         `json:"iso3166_2_a1"`
 	Iso31662A3        string         `json:"iso3166_2_a3"`
+	Iso3166Numeric    sql.NullString `json:"iso3166_numeric"`
+	Version           int32          `json:"version"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	Status            string         `json:"status"`
+	DeprecatedAt      sql.NullTime   `json:"deprecated_at"`
+	ReplacedBy        sql.NullInt32  `json:"replaced_by"`
 }
 
 func (q *Queries) GetCountryById(ctx context.Context, id int32) (GetCountryByIdRow, error) {
@@ -79,43 +99,40 @@ func (q *Queries) GetCountryById(ctx context.Context, id int32) (GetCountryByIdR
 		&i.Tld,
 		&i.Iso31662A1,
 		&i.Iso31662A3,
+		&i.Iso3166Numeric,
+		&i.Version,
+		&i.DeletedAt,
+		&i.Status,
+		&i.DeprecatedAt,
+		&i.ReplacedBy,
 	)
 	return i, err
 }
 
-const getFilteredCountry = `-- name: GetFilteredCountry :many
-SELECT DISTINCT ON (ctr.id) id, name, official_state_name, tld, iso3166_2_A1, iso3166_2_A3
-FROM country ctr
-         LEFT JOIN country_language cl ON ctr.id = cl.country_id
-WHERE ($1::int[] IS NULL OR language_id = ANY($1::int[]))
+const getCountryLanguagesForExport = `-- name: GetCountryLanguagesForExport :many
+SELECT c.iso3166_2_a1, l.iso_639_1
+FROM country_language cl
+         JOIN country c ON c.id = cl.country_id
+         JOIN language l ON l.id = cl.language_id
+WHERE c.deleted_at IS NULL AND l.deleted_at IS NULL
+ORDER BY c.iso3166_2_a1, l.iso_639_1
 `
 
-type GetFilteredCountryRow struct {
-	ID                int32          `json:"id"`
-	Name              string         `json:"name"`
-	OfficialStateName sql.NullString `json:"official_state_name"`
-	Tld               string         `json:"tld"`
-	Iso31662A1        string         `json:"iso3166_2_a1"`
-	Iso31662A3        string         `json:"iso3166_2_a3"`
+type GetCountryLanguagesForExportRow struct {
+	Iso31662A1 string `json:"iso3166_2_a1"`
+	Iso6391    string `json:"iso_639_1"`
 }
 
-func (q *Queries) GetFilteredCountry(ctx context.Context, dollar_1 []int32) ([]GetFilteredCountryRow, error) {
-	rows, err := q.db.QueryContext(ctx, getFilteredCountry, pq.Array(dollar_1))
+func (q *Queries) GetCountryLanguagesForExport(ctx context.Context) ([]GetCountryLanguagesForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCountryLanguagesForExport)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetFilteredCountryRow{}
+	items := []GetCountryLanguagesForExportRow{}
 	for rows.Next() {
-		var i GetFilteredCountryRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.OfficialStateName,
-			&i.Tld,
-			&i.Iso31662A1,
-			&i.Iso31662A3,
-		); err != nil {
+		var i GetCountryLanguagesForExportRow
+		if err := rows.Scan(&i.Iso31662A1, &i.Iso6391); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -129,6 +146,33 @@ func (q *Queries) GetFilteredCountry(ctx context.Context, dollar_1 []int32) ([]G
 	return items, nil
 }
 
+const getExistingCountryIDs = `-- name: GetExistingCountryIDs :many
+SELECT id FROM country WHERE id = ANY($1::int[]) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetExistingCountryIDs(ctx context.Context, ids []int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getExistingCountryIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertCountry = `-- name: InsertCountry :one
 INSERT INTO country(
     name,
@@ -136,10 +180,11 @@ INSERT INTO country(
     tld,
     iso3166_2_a1,
     iso3166_2_a3,
+    iso3166_numeric,
     created_at,
     updated_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
 `
 
 type InsertCountryParams struct {
@@ -148,6 +193,7 @@ type InsertCountryParams struct {
 	Tld               string         `json:"tld"`
 	Iso31662A1        string         `json:"iso3166_2_a1"`
 	Iso31662A3        string         `json:"iso3166_2_a3"`
+	Iso3166Numeric    sql.NullString `json:"iso3166_numeric"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 }
@@ -159,6 +205,7 @@ func (q *Queries) InsertCountry(ctx context.Context, arg InsertCountryParams) (i
 		arg.Tld,
 		arg.Iso31662A1,
 		arg.Iso31662A3,
+		arg.Iso3166Numeric,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -166,3 +213,104 @@ func (q *Queries) InsertCountry(ctx context.Context, arg InsertCountryParams) (i
 	err := row.Scan(&id)
 	return id, err
 }
+
+const insertCountryLanguage = `-- name: InsertCountryLanguage :exec
+INSERT INTO country_language (country_id, language_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`
+
+type InsertCountryLanguageParams struct {
+	CountryID  int32 `json:"country_id"`
+	LanguageID int32 `json:"language_id"`
+}
+
+func (q *Queries) InsertCountryLanguage(ctx context.Context, arg InsertCountryLanguageParams) error {
+	_, err := q.db.ExecContext(ctx, insertCountryLanguage, arg.CountryID, arg.LanguageID)
+	return err
+}
+
+const lockCountry = `-- name: LockCountry :one
+SELECT version FROM country WHERE id = $1 FOR UPDATE
+`
+
+// Locks the row for the rest of the transaction.
+func (q *Queries) LockCountry(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockCountry, id)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const purgeCountries = `-- name: PurgeCountries :execrows
+DELETE FROM country WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeCountries(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeCountries, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreCountry = `-- name: RestoreCountry :execrows
+UPDATE country SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreCountry(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreCountry, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setCountryStatus = `-- name: SetCountryStatus :execrows
+UPDATE country
+SET status = $1, deprecated_at = $2, replaced_by = $3, updated_at = NOW()
+WHERE id = $4 AND deleted_at IS NULL
+`
+
+type SetCountryStatusParams struct {
+	Status       string        `json:"status"`
+	DeprecatedAt sql.NullTime  `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32 `json:"replaced_by"`
+	ID           int32         `json:"id"`
+}
+
+func (q *Queries) SetCountryStatus(ctx context.Context, arg SetCountryStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setCountryStatus,
+		arg.Status,
+		arg.DeprecatedAt,
+		arg.ReplacedBy,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateCountry = `-- name: UpdateCountry :exec
+UPDATE country SET name = $2, official_state_name = $3, tld = $4, iso3166_2_a3 = $5, iso3166_numeric = $6, updated_at = NOW() WHERE id = $1
+`
+
+type UpdateCountryParams struct {
+	ID                int32          `json:"id"`
+	Name              string         `json:"name"`
+	OfficialStateName sql.NullString `json:"official_state_name"`
+	Tld               string         `json:"tld"`
+	Iso31662A3        string         `json:"iso3166_2_a3"`
+	Iso3166Numeric    sql.NullString `json:"iso3166_numeric"`
+}
+
+func (q *Queries) UpdateCountry(ctx context.Context, arg UpdateCountryParams) error {
+	_, err := q.db.ExecContext(ctx, updateCountry,
+		arg.ID,
+		arg.Name,
+		arg.OfficialStateName,
+		arg.Tld,
+		arg.Iso31662A3,
+		arg.Iso3166Numeric,
+	)
+	return err
+}
@@ -7,10 +7,26 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+const deleteLanguage = `-- name: DeleteLanguage :execrows
+UPDATE language SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteLanguage(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLanguage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getAllLanguageTags = `-- name: GetAllLanguageTags :many
-SELECT id, name, iso_639_1, iso_639_2 FROM language
+SELECT id, name, iso_639_1, iso_639_2, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by FROM language WHERE deleted_at IS NULL ORDER BY id
 `
 
 func (q *Queries) GetAllLanguageTags(ctx context.Context) ([]Language, error) {
@@ -27,6 +43,106 @@ func (q *Queries) GetAllLanguageTags(ctx context.Context) ([]Language, error) {
 			&i.Name,
 			&i.Iso6391,
 			&i.Iso6392,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+			&i.Status,
+			&i.DeprecatedAt,
+			&i.ReplacedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExistingLanguageIDs = `-- name: GetExistingLanguageIDs :many
+SELECT id FROM language WHERE id = ANY($1::int[]) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetExistingLanguageIDs(ctx context.Context, ids []int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getExistingLanguageIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLanguageCounts = `-- name: GetLanguageCounts :many
+SELECT l.id,
+       v.variant_count,
+       c.country_count,
+       lu.locale_count
+FROM unnest($1::int[]) AS l(id)
+         CROSS JOIN LATERAL (SELECT count(*) AS variant_count FROM (
+                                 SELECT id FROM variant
+                                 WHERE $2::timestamptz IS NULL AND language_id = l.id AND deleted_at IS NULL
+                                 UNION ALL
+                                 SELECT id FROM variant_history
+                                 WHERE language_id = l.id AND deleted_at IS NULL AND valid_from <= $2::timestamptz
+                                   AND (valid_to IS NULL OR valid_to > $2::timestamptz)) vs) v
+         CROSS JOIN LATERAL (SELECT count(*) AS country_count FROM country_language
+                             WHERE $3::boolean AND language_id = l.id) c
+         CROSS JOIN LATERAL (SELECT count(DISTINCT locale) AS locale_count FROM locale_url
+                             WHERE $4::boolean AND language_id = l.id) lu
+`
+
+type GetLanguageCountsParams struct {
+	Ids           []int32      `json:"ids"`
+	AsOf          sql.NullTime `json:"as_of"`
+	WithCountries bool         `json:"with_countries"`
+	WithLocales   bool         `json:"with_locales"`
+}
+
+type GetLanguageCountsRow struct {
+	ID           int32 `json:"id"`
+	VariantCount int64 `json:"variant_count"`
+	CountryCount int64 `json:"country_count"`
+	LocaleCount  int64 `json:"locale_count"`
+}
+
+func (q *Queries) GetLanguageCounts(ctx context.Context, arg GetLanguageCountsParams) ([]GetLanguageCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLanguageCounts,
+		pq.Array(arg.Ids),
+		arg.AsOf,
+		arg.WithCountries,
+		arg.WithLocales,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLanguageCountsRow{}
+	for rows.Next() {
+		var i GetLanguageCountsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.VariantCount,
+			&i.CountryCount,
+			&i.LocaleCount,
 		); err != nil {
 			return nil, err
 		}
@@ -42,7 +158,7 @@ func (q *Queries) GetAllLanguageTags(ctx context.Context) ([]Language, error) {
 }
 
 const getLanguageTagByID = `-- name: GetLanguageTagByID :one
-SELECT id, name, iso_639_1, iso_639_2 FROM language WHERE id = $1
+SELECT id, name, iso_639_1, iso_639_2, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by FROM language WHERE id = $1
 `
 
 func (q *Queries) GetLanguageTagByID(ctx context.Context, id int32) (Language, error) {
@@ -53,6 +169,13 @@ func (q *Queries) GetLanguageTagByID(ctx context.Context, id int32) (Language, e
 		&i.Name,
 		&i.Iso6391,
 		&i.Iso6392,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.Status,
+		&i.DeprecatedAt,
+		&i.ReplacedBy,
 	)
 	return i, err
 }
@@ -73,3 +196,81 @@ func (q *Queries) InsertLanguageTag(ctx context.Context, arg InsertLanguageTagPa
 	err := row.Scan(&id)
 	return id, err
 }
+
+const lockLanguage = `-- name: LockLanguage :one
+SELECT version FROM language WHERE id = $1 FOR UPDATE
+`
+
+// Locks the row for the rest of the transaction.
+func (q *Queries) LockLanguage(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockLanguage, id)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const purgeLanguages = `-- name: PurgeLanguages :execrows
+DELETE FROM language WHERE deleted_at < $1::timestamptz
+`
+
+// Also removes the variants of the languages, deleted or not.
+func (q *Queries) PurgeLanguages(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeLanguages, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreLanguage = `-- name: RestoreLanguage :execrows
+UPDATE language SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreLanguage(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreLanguage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setLanguageStatus = `-- name: SetLanguageStatus :execrows
+UPDATE language
+SET status = $1, deprecated_at = $2, replaced_by = $3, updated_at = NOW()
+WHERE id = $4 AND deleted_at IS NULL
+`
+
+type SetLanguageStatusParams struct {
+	Status       string        `json:"status"`
+	DeprecatedAt sql.NullTime  `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32 `json:"replaced_by"`
+	ID           int32         `json:"id"`
+}
+
+func (q *Queries) SetLanguageStatus(ctx context.Context, arg SetLanguageStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setLanguageStatus,
+		arg.Status,
+		arg.DeprecatedAt,
+		arg.ReplacedBy,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateLanguageTag = `-- name: UpdateLanguageTag :exec
+UPDATE language SET name = $2, iso_639_2 = $3, updated_at = NOW() WHERE id = $1
+`
+
+type UpdateLanguageTagParams struct {
+	ID      int32  `json:"id"`
+	Name    string `json:"name"`
+	Iso6392 string `json:"iso_639_2"`
+}
+
+func (q *Queries) UpdateLanguageTag(ctx context.Context, arg UpdateLanguageTagParams) error {
+	_, err := q.db.ExecContext(ctx, updateLanguageTag, arg.ID, arg.Name, arg.Iso6392)
+	return err
+}
@@ -9,23 +9,103 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
-const getPaginatedVariantsWithFilter = `-- name: GetPaginatedVariantsWithFilter :many
-SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description FROM variant
-WHERE language_id = $3::integer
+const countVariants = `-- name: CountVariants :one
+SELECT count(id) FROM variant
+WHERE ($1::integer IS NULL OR language_id = $1::integer)
+  AND ($2::boolean OR deleted_at IS NULL)
+  AND ($3::boolean OR status <> 'retired')
+`
+
+type CountVariantsParams struct {
+	LanguageID     sql.NullInt32 `json:"language_id"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	IncludeRetired bool          `json:"include_retired"`
+}
+
+func (q *Queries) CountVariants(ctx context.Context, arg CountVariantsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countVariants, arg.LanguageID, arg.IncludeDeleted, arg.IncludeRetired)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteVariant = `-- name: DeleteVariant :execrows
+UPDATE variant SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteVariant(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteVariant, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getVariantByID = `-- name: GetVariantByID :one
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant WHERE id = $1
+`
+
+func (q *Queries) GetVariantByID(ctx context.Context, id int32) (Variant, error) {
+	row := q.db.QueryRowContext(ctx, getVariantByID, id)
+	var i Variant
+	err := row.Scan(
+		&i.ID,
+		&i.LanguageID,
+		&i.CountryID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VariantTag,
+		&i.Description,
+		&i.Version,
+		&i.DeletedAt,
+		&i.Status,
+		&i.DeprecatedAt,
+		&i.ReplacedBy,
+	)
+	return i, err
+}
+
+const getVariantCount = `-- name: GetVariantCount :one
+SELECT count(id) FROM variant WHERE language_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetVariantCount(ctx context.Context, languageID sql.NullInt32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getVariantCount, languageID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getVariantsAfter = `-- name: GetVariantsAfter :many
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant
+WHERE ($1::integer IS NULL OR language_id = $1::integer)
+  AND ($2::boolean OR deleted_at IS NULL)
+  AND ($3::boolean OR status <> 'retired')
+  AND id > $4::integer
 ORDER BY id
-LIMIT $1 OFFSET $2
+LIMIT $5::integer
 `
 
-type GetPaginatedVariantsWithFilterParams struct {
-	Limit      int32 `json:"limit"`
-	Offset     int32 `json:"offset"`
-	LanguageID int32 `json:"language_id"`
+type GetVariantsAfterParams struct {
+	LanguageID     sql.NullInt32 `json:"language_id"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	IncludeRetired bool          `json:"include_retired"`
+	AfterID        int32         `json:"after_id"`
+	RowLimit       int32         `json:"row_limit"`
 }
 
-func (q *Queries) GetPaginatedVariantsWithFilter(ctx context.Context, arg GetPaginatedVariantsWithFilterParams) ([]Variant, error) {
-	rows, err := q.db.QueryContext(ctx, getPaginatedVariantsWithFilter, arg.Limit, arg.Offset, arg.LanguageID)
+func (q *Queries) GetVariantsAfter(ctx context.Context, arg GetVariantsAfterParams) ([]Variant, error) {
+	rows, err := q.db.QueryContext(ctx, getVariantsAfter,
+		arg.LanguageID,
+		arg.IncludeDeleted,
+		arg.IncludeRetired,
+		arg.AfterID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +121,11 @@ func (q *Queries) GetPaginatedVariantsWithFilter(ctx context.Context, arg GetPag
 			&i.UpdatedAt,
 			&i.VariantTag,
 			&i.Description,
+			&i.Version,
+			&i.DeletedAt,
+			&i.Status,
+			&i.DeprecatedAt,
+			&i.ReplacedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -55,19 +140,32 @@ func (q *Queries) GetPaginatedVariantsWithFilter(ctx context.Context, arg GetPag
 	return items, nil
 }
 
-const getPaginatedVariantsWithoutFilter = `-- name: GetPaginatedVariantsWithoutFilter :many
-SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description FROM variant
-ORDER BY id
-LIMIT $1 OFFSET $2
+const getVariantsBefore = `-- name: GetVariantsBefore :many
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant
+WHERE ($1::integer IS NULL OR language_id = $1::integer)
+  AND ($2::boolean OR deleted_at IS NULL)
+  AND ($3::boolean OR status <> 'retired')
+  AND id < $4::integer
+ORDER BY id DESC
+LIMIT $5::integer
 `
 
-type GetPaginatedVariantsWithoutFilterParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type GetVariantsBeforeParams struct {
+	LanguageID     sql.NullInt32 `json:"language_id"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	IncludeRetired bool          `json:"include_retired"`
+	BeforeID       int32         `json:"before_id"`
+	RowLimit       int32         `json:"row_limit"`
 }
 
-func (q *Queries) GetPaginatedVariantsWithoutFilter(ctx context.Context, arg GetPaginatedVariantsWithoutFilterParams) ([]Variant, error) {
-	rows, err := q.db.QueryContext(ctx, getPaginatedVariantsWithoutFilter, arg.Limit, arg.Offset)
+func (q *Queries) GetVariantsBefore(ctx context.Context, arg GetVariantsBeforeParams) ([]Variant, error) {
+	rows, err := q.db.QueryContext(ctx, getVariantsBefore,
+		arg.LanguageID,
+		arg.IncludeDeleted,
+		arg.IncludeRetired,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +181,11 @@ func (q *Queries) GetPaginatedVariantsWithoutFilter(ctx context.Context, arg Get
 			&i.UpdatedAt,
 			&i.VariantTag,
 			&i.Description,
+			&i.Version,
+			&i.DeletedAt,
+			&i.Status,
+			&i.DeprecatedAt,
+			&i.ReplacedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -97,43 +200,85 @@ func (q *Queries) GetPaginatedVariantsWithoutFilter(ctx context.Context, arg Get
 	return items, nil
 }
 
-const getVariantCount = `-- name: GetVariantCount :one
-SELECT count(id) FROM variant WHERE language_id = $1
+const getVariantsByLanguageTagID = `-- name: GetVariantsByLanguageTagID :many
+SELECT id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by FROM variant
+WHERE language_id = $1 AND ($2::boolean OR deleted_at IS NULL)
+  AND ($3::boolean OR status <> 'retired')
+ORDER BY id
 `
 
-func (q *Queries) GetVariantCount(ctx context.Context, languageID sql.NullInt32) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getVariantCount, languageID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+type GetVariantsByLanguageTagIDParams struct {
+	LanguageID     sql.NullInt32 `json:"language_id"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	IncludeRetired bool          `json:"include_retired"`
 }
 
-const getVariantsByLanguageTagID = `-- name: GetVariantsByLanguageTagID :many
-SELECT id, created_at, updated_at, variant_tag, description
-FROM variant WHERE language_id = $1
+func (q *Queries) GetVariantsByLanguageTagID(ctx context.Context, arg GetVariantsByLanguageTagIDParams) ([]Variant, error) {
+	rows, err := q.db.QueryContext(ctx, getVariantsByLanguageTagID, arg.LanguageID, arg.IncludeDeleted, arg.IncludeRetired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Variant{}
+	for rows.Next() {
+		var i Variant
+		if err := rows.Scan(
+			&i.ID,
+			&i.LanguageID,
+			&i.CountryID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VariantTag,
+			&i.Description,
+			&i.Version,
+			&i.DeletedAt,
+			&i.Status,
+			&i.DeprecatedAt,
+			&i.ReplacedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVariantsForExport = `-- name: GetVariantsForExport :many
+SELECT v.id, l.iso_639_1, c.iso3166_2_a1, v.variant_tag, v.description
+FROM variant v
+         LEFT JOIN language l ON l.id = v.language_id
+         LEFT JOIN country c ON c.id = v.country_id
+WHERE v.deleted_at IS NULL AND l.deleted_at IS NULL AND c.deleted_at IS NULL
+ORDER BY v.id
 `
 
-type GetVariantsByLanguageTagIDRow struct {
+type GetVariantsForExportRow struct {
 	ID          int32          `json:"id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	Iso6391     sql.NullString `json:"iso_639_1"`
+	Iso31662A1  sql.NullString `json:"iso3166_2_a1"`
 	VariantTag  string         `json:"variant_tag"`
 	Description sql.NullString `json:"description"`
 }
 
-func (q *Queries) GetVariantsByLanguageTagID(ctx context.Context, languageID sql.NullInt32) ([]GetVariantsByLanguageTagIDRow, error) {
-	rows, err := q.db.QueryContext(ctx, getVariantsByLanguageTagID, languageID)
+func (q *Queries) GetVariantsForExport(ctx context.Context) ([]GetVariantsForExportRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVariantsForExport)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetVariantsByLanguageTagIDRow{}
+	items := []GetVariantsForExportRow{}
 	for rows.Next() {
-		var i GetVariantsByLanguageTagIDRow
+		var i GetVariantsForExportRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Iso6391,
+			&i.Iso31662A1,
 			&i.VariantTag,
 			&i.Description,
 		); err != nil {
@@ -150,9 +295,9 @@ func (q *Queries) GetVariantsByLanguageTagID(ctx context.Context, languageID sql
 	return items, nil
 }
 
-const insertVariant = `-- name: InsertVariant :exec
+const insertVariant = `-- name: InsertVariant :one
 INSERT INTO variant (language_id, variant_tag, description, country_id, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id
 `
 
 type InsertVariantParams struct {
@@ -164,8 +309,8 @@ type InsertVariantParams struct {
 	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
-func (q *Queries) InsertVariant(ctx context.Context, arg InsertVariantParams) error {
-	_, err := q.db.ExecContext(ctx, insertVariant,
+func (q *Queries) InsertVariant(ctx context.Context, arg InsertVariantParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, insertVariant,
 		arg.LanguageID,
 		arg.VariantTag,
 		arg.Description,
@@ -173,11 +318,200 @@ func (q *Queries) InsertVariant(ctx context.Context, arg InsertVariantParams) er
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
-	return err
+	var id int32
+	err := row.Scan(&id)
+	return id, err
 }
 
-const updateVariant = `-- name: UpdateVariant :exec
-UPDATE variant set language_id = $2, variant_tag = $3, description = $4, updated_at = $5 where id = $1
+const insertVariants = `-- name: InsertVariants :many
+WITH input AS (
+    SELECT nextval(pg_get_serial_sequence('variant', 'id'))::int AS id, u.language_id, u.country_id, u.variant_tag, u.description, u.position
+    FROM unnest($1::int[], $2::int[],
+                $3::varchar[], $4::text[])
+             WITH ORDINALITY AS u(language_id, country_id, variant_tag, description, position)
+),
+inserted AS (
+    INSERT INTO variant (id, language_id, country_id, variant_tag, description)
+    SELECT id, NULLIF(language_id, 0), NULLIF(country_id, 0), variant_tag, description
+    FROM input
+    RETURNING id, language_id, country_id, created_at, updated_at, variant_tag, description, version, deleted_at, status, deprecated_at, replaced_by
+)
+SELECT input.position::int AS position, inserted.id, inserted.language_id, inserted.country_id, inserted.created_at, inserted.updated_at, inserted.variant_tag, inserted.description, inserted.version, inserted.deleted_at, inserted.status, inserted.deprecated_at, inserted.replaced_by
+FROM inserted
+         JOIN input ON input.id = inserted.id
+ORDER BY input.position
+`
+
+type InsertVariantsParams struct {
+	LanguageIds  []int32  `json:"language_ids"`
+	CountryIds   []int32  `json:"country_ids"`
+	VariantTags  []string `json:"variant_tags"`
+	Descriptions []string `json:"descriptions"`
+}
+
+type InsertVariantsRow struct {
+	Position     int32          `json:"position"`
+	ID           int32          `json:"id"`
+	LanguageID   sql.NullInt32  `json:"language_id"`
+	CountryID    sql.NullInt32  `json:"country_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	VariantTag   string         `json:"variant_tag"`
+	Description  sql.NullString `json:"description"`
+	Version      int32          `json:"version"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Status       string         `json:"status"`
+	DeprecatedAt sql.NullTime   `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32  `json:"replaced_by"`
+}
+
+// Returns each row with the 1-based position of its item in the arrays. The
+// IDs are drawn before the insert, since RETURNING does not keep the order of
+// the input.
+func (q *Queries) InsertVariants(ctx context.Context, arg InsertVariantsParams) ([]InsertVariantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, insertVariants,
+		pq.Array(arg.LanguageIds),
+		pq.Array(arg.CountryIds),
+		pq.Array(arg.VariantTags),
+		pq.Array(arg.Descriptions),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []InsertVariantsRow{}
+	for rows.Next() {
+		var i InsertVariantsRow
+		if err := rows.Scan(
+			&i.Position,
+			&i.ID,
+			&i.LanguageID,
+			&i.CountryID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VariantTag,
+			&i.Description,
+			&i.Version,
+			&i.DeletedAt,
+			&i.Status,
+			&i.DeprecatedAt,
+			&i.ReplacedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockVariant = `-- name: LockVariant :one
+SELECT version FROM variant WHERE id = $1 FOR UPDATE
+`
+
+// Locks the row for the rest of the transaction.
+func (q *Queries) LockVariant(ctx context.Context, id int32) (int32, error) {
+	row := q.db.QueryRowContext(ctx, lockVariant, id)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const patchVariant = `-- name: PatchVariant :execrows
+UPDATE variant SET
+    language_id = CASE WHEN $1::boolean THEN $2::integer ELSE language_id END,
+    country_id = CASE WHEN $3::boolean THEN $4::integer ELSE country_id END,
+    variant_tag = COALESCE($5::varchar, variant_tag),
+    description = CASE WHEN $6::boolean THEN $7::text ELSE description END,
+    updated_at = NOW()
+WHERE id = $8 AND deleted_at IS NULL
+`
+
+type PatchVariantParams struct {
+	SetLanguageID  bool           `json:"set_language_id"`
+	LanguageID     sql.NullInt32  `json:"language_id"`
+	SetCountryID   bool           `json:"set_country_id"`
+	CountryID      sql.NullInt32  `json:"country_id"`
+	VariantTag     sql.NullString `json:"variant_tag"`
+	SetDescription bool           `json:"set_description"`
+	Description    sql.NullString `json:"description"`
+	ID             int32          `json:"id"`
+}
+
+func (q *Queries) PatchVariant(ctx context.Context, arg PatchVariantParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, patchVariant,
+		arg.SetLanguageID,
+		arg.LanguageID,
+		arg.SetCountryID,
+		arg.CountryID,
+		arg.VariantTag,
+		arg.SetDescription,
+		arg.Description,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeVariants = `-- name: PurgeVariants :execrows
+DELETE FROM variant WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeVariants(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeVariants, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreVariant = `-- name: RestoreVariant :execrows
+UPDATE variant SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreVariant(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreVariant, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setVariantStatus = `-- name: SetVariantStatus :execrows
+UPDATE variant
+SET status = $1, deprecated_at = $2, replaced_by = $3, updated_at = NOW()
+WHERE id = $4 AND deleted_at IS NULL
+`
+
+type SetVariantStatusParams struct {
+	Status       string        `json:"status"`
+	DeprecatedAt sql.NullTime  `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32 `json:"replaced_by"`
+	ID           int32         `json:"id"`
+}
+
+func (q *Queries) SetVariantStatus(ctx context.Context, arg SetVariantStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setVariantStatus,
+		arg.Status,
+		arg.DeprecatedAt,
+		arg.ReplacedBy,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateVariant = `-- name: UpdateVariant :execrows
+UPDATE variant set language_id = $2, variant_tag = $3, description = $4, updated_at = $5, country_id = $6 where id = $1 AND deleted_at IS NULL
 `
 
 type UpdateVariantParams struct {
@@ -186,15 +520,20 @@ type UpdateVariantParams struct {
 	VariantTag  string         `json:"variant_tag"`
 	Description sql.NullString `json:"description"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+	CountryID   sql.NullInt32  `json:"country_id"`
 }
 
-func (q *Queries) UpdateVariant(ctx context.Context, arg UpdateVariantParams) error {
-	_, err := q.db.ExecContext(ctx, updateVariant,
+func (q *Queries) UpdateVariant(ctx context.Context, arg UpdateVariantParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateVariant,
 		arg.ID,
 		arg.LanguageID,
 		arg.VariantTag,
 		arg.Description,
 		arg.UpdatedAt,
+		arg.CountryID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
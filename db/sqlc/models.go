@@ -6,9 +6,34 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type ApiKey struct {
+	ID         int32        `json:"id"`
+	Name       string       `json:"name"`
+	Role       string       `json:"role"`
+	KeyID      string       `json:"key_id"`
+	KeyHash    []byte       `json:"key_hash"`
+	CreatedAt  time.Time    `json:"created_at"`
+	RotatedAt  sql.NullTime `json:"rotated_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+}
+
+type AuditLog struct {
+	ID         int64           `json:"id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id"`
+	Entity     string          `json:"entity"`
+	EntityID   string          `json:"entity_id"`
+	Operation  string          `json:"operation"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+}
+
 type Country struct {
 	ID                int32          `json:"id"`
 	Name              string         `json:"name"`
@@ -16,8 +41,33 @@ type Country struct {
 	Tld               string         `json:"tld"`
 	Iso31662A1        string         `json:"iso3166_2_a1"`
 	Iso31662A3        string         `json:"iso3166_2_a3"`
+	Iso3166Numeric    sql.NullString `json:"iso3166_numeric"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	Version           int32          `json:"version"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	Status            string         `json:"status"`
+	DeprecatedAt      sql.NullTime   `json:"deprecated_at"`
+	ReplacedBy        sql.NullInt32  `json:"replaced_by"`
+}
+
+type CountryHistory struct {
+	ID                int32          `json:"id"`
+	Name              string         `json:"name"`
+	OfficialStateName sql.NullString `json:"official_state_name"`
+	Tld               string         `json:"tld"`
+	Iso31662A1        string         `json:"iso3166_2_a1"`
+	Iso31662A3        string         `json:"iso3166_2_a3"`
+	Iso3166Numeric    sql.NullString `json:"iso3166_numeric"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
+	Version           int32          `json:"version"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	Status            string         `json:"status"`
+	DeprecatedAt      sql.NullTime   `json:"deprecated_at"`
+	ReplacedBy        sql.NullInt32  `json:"replaced_by"`
+	ValidFrom         time.Time      `json:"valid_from"`
+	ValidTo           sql.NullTime   `json:"valid_to"`
 }
 
 type CountryLanguage struct {
@@ -25,19 +75,178 @@ type CountryLanguage struct {
 	LanguageID int32 `json:"language_id"`
 }
 
+type IdempotencyKey struct {
+	Principal    string         `json:"principal"`
+	Key          string         `json:"key"`
+	Fingerprint  []byte         `json:"fingerprint"`
+	StatusCode   sql.NullInt32  `json:"status_code"`
+	ContentType  sql.NullString `json:"content_type"`
+	ResponseBody []byte         `json:"response_body"`
+	CreatedAt    time.Time      `json:"created_at"`
+	ExpiresAt    time.Time      `json:"expires_at"`
+}
+
 type Language struct {
-	ID      int32  `json:"id"`
-	Name    string `json:"name"`
-	Iso6391 string `json:"iso_639_1"`
-	Iso6392 string `json:"iso_639_2"`
+	ID           int32         `json:"id"`
+	Name         string        `json:"name"`
+	Iso6391      string        `json:"iso_639_1"`
+	Iso6392      string        `json:"iso_639_2"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	Version      int32         `json:"version"`
+	DeletedAt    sql.NullTime  `json:"deleted_at"`
+	Status       string        `json:"status"`
+	DeprecatedAt sql.NullTime  `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32 `json:"replaced_by"`
+}
+
+type LanguageHistory struct {
+	ID           int32         `json:"id"`
+	Name         string        `json:"name"`
+	Iso6391      string        `json:"iso_639_1"`
+	Iso6392      string        `json:"iso_639_2"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	Version      int32         `json:"version"`
+	DeletedAt    sql.NullTime  `json:"deleted_at"`
+	Status       string        `json:"status"`
+	DeprecatedAt sql.NullTime  `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32 `json:"replaced_by"`
+	ValidFrom    time.Time     `json:"valid_from"`
+	ValidTo      sql.NullTime  `json:"valid_to"`
+}
+
+type LocaleUrl struct {
+	ID         int32         `json:"id"`
+	SiteID     int32         `json:"site_id"`
+	Locale     string        `json:"locale"`
+	LanguageID sql.NullInt32 `json:"language_id"`
+	CountryID  sql.NullInt32 `json:"country_id"`
+	Strategy   string        `json:"strategy"`
+	Value      string        `json:"value"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type LocalizedName struct {
+	ID         int32     `json:"id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   int32     `json:"entity_id"`
+	Locale     string    `json:"locale"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type OutboxEvent struct {
+	ID           int64           `json:"id"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	Type         string          `json:"type"`
+	Entity       string          `json:"entity"`
+	EntityID     string          `json:"entity_id"`
+	Data         json.RawMessage `json:"data"`
+	DispatchedAt sql.NullTime    `json:"dispatched_at"`
+	Seq          sql.NullInt64   `json:"seq"`
+}
+
+type Redirect struct {
+	ID         int32        `json:"id"`
+	Locale     string       `json:"locale"`
+	MatchType  string       `json:"match_type"`
+	SourcePath string       `json:"source_path"`
+	TargetPath string       `json:"target_path"`
+	StatusCode int32        `json:"status_code"`
+	Priority   int32        `json:"priority"`
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+type SearchEntry struct {
+	EntityType string         `json:"entity_type"`
+	EntityID   int32          `json:"entity_id"`
+	Label      string         `json:"label"`
+	Field      string         `json:"field"`
+	Locale     sql.NullString `json:"locale"`
+	Value      string         `json:"value"`
+}
+
+type Site struct {
+	ID            int32          `json:"id"`
+	Name          string         `json:"name"`
+	Scheme        string         `json:"scheme"`
+	Host          string         `json:"host"`
+	QueryParam    string         `json:"query_param"`
+	DefaultLocale sql.NullString `json:"default_locale"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+type Slug struct {
+	ID          int32     `json:"id"`
+	ResourceKey string    `json:"resource_key"`
+	Locale      string    `json:"locale"`
+	Slug        string    `json:"slug"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SlugHistory struct {
+	ID         int32     `json:"id"`
+	SlugID     int32     `json:"slug_id"`
+	Slug       string    `json:"slug"`
+	ReplacedAt time.Time `json:"replaced_at"`
 }
 
 type Variant struct {
-	ID          int32          `json:"id"`
-	LanguageID  sql.NullInt32  `json:"language_id"`
-	CountryID   sql.NullInt32  `json:"country_id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	VariantTag  string         `json:"variant_tag"`
-	Description sql.NullString `json:"description"`
+	ID           int32          `json:"id"`
+	LanguageID   sql.NullInt32  `json:"language_id"`
+	CountryID    sql.NullInt32  `json:"country_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	VariantTag   string         `json:"variant_tag"`
+	Description  sql.NullString `json:"description"`
+	Version      int32          `json:"version"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Status       string         `json:"status"`
+	DeprecatedAt sql.NullTime   `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32  `json:"replaced_by"`
+}
+
+type VariantHistory struct {
+	ID           int32          `json:"id"`
+	LanguageID   sql.NullInt32  `json:"language_id"`
+	CountryID    sql.NullInt32  `json:"country_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	VariantTag   string         `json:"variant_tag"`
+	Description  sql.NullString `json:"description"`
+	Version      int32          `json:"version"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	Status       string         `json:"status"`
+	DeprecatedAt sql.NullTime   `json:"deprecated_at"`
+	ReplacedBy   sql.NullInt32  `json:"replaced_by"`
+	ValidFrom    time.Time      `json:"valid_from"`
+	ValidTo      sql.NullTime   `json:"valid_to"`
+}
+
+type Webhook struct {
+	ID         int32     `json:"id"`
+	Url        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64         `json:"id"`
+	WebhookID      int32         `json:"webhook_id"`
+	EventID        int64         `json:"event_id"`
+	Status         string        `json:"status"`
+	Attempts       int32         `json:"attempts"`
+	NextAttemptAt  time.Time     `json:"next_attempt_at"`
+	LastAttemptAt  sql.NullTime  `json:"last_attempt_at"`
+	ResponseStatus sql.NullInt32 `json:"response_status"`
+	LastError      string        `json:"last_error"`
+	CreatedAt      time.Time     `json:"created_at"`
 }
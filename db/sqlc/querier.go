@@ -7,22 +7,157 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	// Leases up to max_deliveries pending deliveries that are due until
+	// lease_until, so that other workers skip them while they are sent.
+	ClaimDueDeliveries(ctx context.Context, arg ClaimDueDeliveriesParams) ([]ClaimDueDeliveriesRow, error)
+	// Claims a key that is unused or expired; no rows are affected when the key
+	// is already in use.
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (int64, error)
+	CountVariants(ctx context.Context, arg CountVariantsParams) (int64, error)
+	CountVariantsAsOf(ctx context.Context, arg CountVariantsAsOfParams) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error)
+	DeleteCountry(ctx context.Context, id int32) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	DeleteLanguage(ctx context.Context, id int32) (int64, error)
+	DeleteLocaleURL(ctx context.Context, id int32) (int64, error)
+	DeleteLocalizedName(ctx context.Context, id int32) (int64, error)
+	DeleteRedirect(ctx context.Context, id int32) (int64, error)
+	DeleteRedirectBySource(ctx context.Context, arg DeleteRedirectBySourceParams) error
+	DeleteVariant(ctx context.Context, id int32) (int64, error)
+	DeleteWebhook(ctx context.Context, id int32) (int64, error)
+	// Queues up to max_events undispatched events for the webhooks subscribed to
+	// them and returns how many events there were.
+	DispatchOutboxEvents(ctx context.Context, maxEvents int32) (int64, error)
 	GetAllCountries(ctx context.Context) ([]GetAllCountriesRow, error)
 	GetAllLanguageTags(ctx context.Context) ([]Language, error)
+	GetAllSites(ctx context.Context) ([]GetAllSitesRow, error)
+	GetAPIKey(ctx context.Context, id int32) (ApiKey, error)
+	GetAPIKeyByKeyID(ctx context.Context, keyID string) (ApiKey, error)
+	GetAuditHistory(ctx context.Context, arg GetAuditHistoryParams) ([]AuditLog, error)
 	GetCountryById(ctx context.Context, id int32) (GetCountryByIdRow, error)
-	GetFilteredCountry(ctx context.Context, dollar_1 []int32) ([]GetFilteredCountryRow, error)
+	GetCountryByIdAsOf(ctx context.Context, arg GetCountryByIdAsOfParams) (GetCountryByIdAsOfRow, error)
+	GetCountryLanguagesForExport(ctx context.Context) ([]GetCountryLanguagesForExportRow, error)
+	GetExistingCountryIDs(ctx context.Context, ids []int32) ([]int32, error)
+	GetExistingLanguageIDs(ctx context.Context, ids []int32) ([]int32, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLanguageCounts(ctx context.Context, arg GetLanguageCountsParams) ([]GetLanguageCountsRow, error)
 	GetLanguageTagByID(ctx context.Context, id int32) (Language, error)
-	GetPaginatedVariantsWithFilter(ctx context.Context, arg GetPaginatedVariantsWithFilterParams) ([]Variant, error)
-	GetPaginatedVariantsWithoutFilter(ctx context.Context, arg GetPaginatedVariantsWithoutFilterParams) ([]Variant, error)
+	GetLanguageTagByIDAsOf(ctx context.Context, arg GetLanguageTagByIDAsOfParams) (GetLanguageTagByIDAsOfRow, error)
+	GetLastChangeSeq(ctx context.Context) (int64, error)
+	GetLocaleURLByID(ctx context.Context, id int32) (GetLocaleURLByIDRow, error)
+	GetLocaleURLLocales(ctx context.Context) ([]string, error)
+	// Returns the URL configuration of a locale on every site that has it.
+	GetLocaleURLsByLocale(ctx context.Context, locale string) ([]GetLocaleURLsByLocaleRow, error)
+	GetLocaleURLsBySiteID(ctx context.Context, siteID int32) ([]GetLocaleURLsBySiteIDRow, error)
+	GetLocalizedNameByID(ctx context.Context, id int32) (LocalizedName, error)
+	GetLocalizedNames(ctx context.Context, arg GetLocalizedNamesParams) ([]LocalizedName, error)
+	GetRedirectByID(ctx context.Context, id int32) (Redirect, error)
+	GetRedirects(ctx context.Context, locale sql.NullString) ([]Redirect, error)
+	GetSiteByID(ctx context.Context, id int32) (GetSiteByIDRow, error)
+	GetSlugByID(ctx context.Context, id int32) (GetSlugByIDRow, error)
+	GetSlugHistory(ctx context.Context, slugID int32) ([]GetSlugHistoryRow, error)
+	GetSlugs(ctx context.Context, arg GetSlugsParams) ([]GetSlugsRow, error)
+	GetVariantByID(ctx context.Context, id int32) (Variant, error)
+	GetVariantByIDAsOf(ctx context.Context, arg GetVariantByIDAsOfParams) (GetVariantByIDAsOfRow, error)
 	GetVariantCount(ctx context.Context, languageID sql.NullInt32) (int64, error)
-	GetVariantsByLanguageTagID(ctx context.Context, languageID sql.NullInt32) ([]GetVariantsByLanguageTagIDRow, error)
+	GetVariantsAfter(ctx context.Context, arg GetVariantsAfterParams) ([]Variant, error)
+	GetVariantsAfterAsOf(ctx context.Context, arg GetVariantsAfterAsOfParams) ([]GetVariantsAfterAsOfRow, error)
+	GetVariantsBefore(ctx context.Context, arg GetVariantsBeforeParams) ([]Variant, error)
+	GetVariantsBeforeAsOf(ctx context.Context, arg GetVariantsBeforeAsOfParams) ([]GetVariantsBeforeAsOfRow, error)
+	GetVariantsByLanguageTagID(ctx context.Context, arg GetVariantsByLanguageTagIDParams) ([]Variant, error)
+	GetVariantsByLanguageTagIDAsOf(ctx context.Context, arg GetVariantsByLanguageTagIDAsOfParams) ([]GetVariantsByLanguageTagIDAsOfRow, error)
+	GetVariantsForExport(ctx context.Context) ([]GetVariantsForExportRow, error)
+	GetWebhook(ctx context.Context, id int32) (Webhook, error)
 	InsertCountry(ctx context.Context, arg InsertCountryParams) (int32, error)
+	InsertCountryLanguage(ctx context.Context, arg InsertCountryLanguageParams) error
 	InsertLanguageTag(ctx context.Context, arg InsertLanguageTagParams) (int32, error)
-	InsertVariant(ctx context.Context, arg InsertVariantParams) error
-	UpdateVariant(ctx context.Context, arg UpdateVariantParams) error
+	InsertLocaleURL(ctx context.Context, arg InsertLocaleURLParams) (int32, error)
+	InsertLocalizedName(ctx context.Context, arg InsertLocalizedNameParams) (int32, error)
+	InsertRedirect(ctx context.Context, arg InsertRedirectParams) (int32, error)
+	InsertSite(ctx context.Context, arg InsertSiteParams) (int32, error)
+	InsertSlug(ctx context.Context, arg InsertSlugParams) (int32, error)
+	InsertSlugHistory(ctx context.Context, arg InsertSlugHistoryParams) error
+	InsertVariant(ctx context.Context, arg InsertVariantParams) (int32, error)
+	// Returns each row with the 1-based position of its item in the arrays. The
+	// IDs are drawn before the insert, since RETURNING does not keep the order of
+	// the input.
+	InsertVariants(ctx context.Context, arg InsertVariantsParams) ([]InsertVariantsRow, error)
+	ListAPIKeys(ctx context.Context) ([]ApiKey, error)
+	ListChanges(ctx context.Context, arg ListChangesParams) ([]ListChangesRow, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	// Makes sequencers take turns, until the end of the transaction.
+	LockChangeSequencer(ctx context.Context) error
+	// Locks the row for the rest of the transaction.
+	LockCountry(ctx context.Context, id int32) (int32, error)
+	// Locks the row for the rest of the transaction.
+	LockLanguage(ctx context.Context, id int32) (int32, error)
+	// Locks the row for the rest of the transaction.
+	LockLocaleURL(ctx context.Context, id int32) (time.Time, error)
+	// Locks the row for the rest of the transaction.
+	LockLocalizedName(ctx context.Context, id int32) (time.Time, error)
+	// Locks the row for the rest of the transaction.
+	LockRedirect(ctx context.Context, id int32) (time.Time, error)
+	// Makes writers of redirect rules take turns, until the end of the
+	// transaction, so that each loop check sees the rules it is checked against.
+	LockRedirectRules(ctx context.Context) error
+	// Locks the row for the rest of the transaction.
+	LockSlug(ctx context.Context, id int32) (time.Time, error)
+	// Locks the row for the rest of the transaction.
+	LockVariant(ctx context.Context, id int32) (int32, error)
+	// Tells the listeners on the changes channel, once the transaction commits,
+	// that events up to seq can be read.
+	NotifyChanges(ctx context.Context, seq int64) error
+	PatchVariant(ctx context.Context, arg PatchVariantParams) (int64, error)
+	PurgeCountries(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// Also removes the variants of the languages, deleted or not.
+	PurgeLanguages(ctx context.Context, deletedBefore time.Time) (int64, error)
+	PurgeVariants(ctx context.Context, deletedBefore time.Time) (int64, error)
+	RecordDeliveryAttempt(ctx context.Context, arg RecordDeliveryAttemptParams) error
+	// Sends a delivery again as soon as possible, with a fresh set of attempts.
+	RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (int64, error)
+	ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error
+	RestoreCountry(ctx context.Context, id int32) (int64, error)
+	RestoreLanguage(ctx context.Context, id int32) (int64, error)
+	RestoreVariant(ctx context.Context, id int32) (int64, error)
+	RetargetRedirects(ctx context.Context, arg RetargetRedirectsParams) error
+	// Copies a version back into the row, which records it as a new version.
+	RevertCountry(ctx context.Context, arg RevertCountryParams) (int64, error)
+	// Copies a version back into the row, which records it as a new version.
+	RevertLanguage(ctx context.Context, arg RevertLanguageParams) (int64, error)
+	// Copies a version back into the row, which records it as a new version.
+	RevertVariant(ctx context.Context, arg RevertVariantParams) (int64, error)
+	RevokeAPIKey(ctx context.Context, id int32) (int64, error)
+	RotateAPIKey(ctx context.Context, arg RotateAPIKeyParams) (ApiKey, error)
+	SaveIdempotentResponse(ctx context.Context, arg SaveIdempotentResponseParams) error
+	Search(ctx context.Context, arg SearchParams) ([]SearchRow, error)
+	SeedCountries(ctx context.Context, arg SeedCountriesParams) (SeedCountriesRow, error)
+	SeedCountryLanguages(ctx context.Context, arg SeedCountryLanguagesParams) (SeedCountryLanguagesRow, error)
+	SeedLanguages(ctx context.Context, arg SeedLanguagesParams) (int64, error)
+	SeedVariants(ctx context.Context, arg SeedVariantsParams) (SeedVariantsRow, error)
+	// Numbers the committed events that have no seq yet, in the order of their
+	// ids, and returns the last seq given, or 0 when there were none.
+	SequenceOutboxEvents(ctx context.Context) (int64, error)
+	SetAPIKeyRole(ctx context.Context, arg SetAPIKeyRoleParams) (ApiKey, error)
+	// Identifies the caller in the audit entries of the current transaction.
+	SetAuditContext(ctx context.Context, arg SetAuditContextParams) error
+	SetCountryStatus(ctx context.Context, arg SetCountryStatusParams) (int64, error)
+	SetLanguageStatus(ctx context.Context, arg SetLanguageStatusParams) (int64, error)
+	SetVariantStatus(ctx context.Context, arg SetVariantStatusParams) (int64, error)
+	SlugExists(ctx context.Context, arg SlugExistsParams) (bool, error)
+	TouchAPIKey(ctx context.Context, id int32) error
+	UpdateCountry(ctx context.Context, arg UpdateCountryParams) error
+	UpdateLanguageTag(ctx context.Context, arg UpdateLanguageTagParams) error
+	UpdateLocaleURL(ctx context.Context, arg UpdateLocaleURLParams) (int64, error)
+	UpdateLocalizedName(ctx context.Context, arg UpdateLocalizedNameParams) (int64, error)
+	UpdateRedirect(ctx context.Context, arg UpdateRedirectParams) (int64, error)
+	UpdateSlug(ctx context.Context, arg UpdateSlugParams) error
+	UpdateVariant(ctx context.Context, arg UpdateVariantParams) (int64, error)
+	UpsertRedirect(ctx context.Context, arg UpsertRedirectParams) error
 }
 
 var _ Querier = (*Queries)(nil)
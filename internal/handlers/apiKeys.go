@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/auth"
+)
+
+type APIKeyRequest struct {
+	Name string `json:"name"`
+	// Role is viewer, editor or admin; viewer by default.
+	Role string `json:"role"`
+}
+
+type APIKeyRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type APIKeyResponse struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	KeyID      string     `json:"key_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeySecretResponse is returned when a key is created or rotated, the only
+// times the key itself can be read.
+type APIKeySecretResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyHandler handles requests related to API keys
+//
+//	@Summary		Handles API keys
+//	@Description	List, get, create, rotate, revoke or change the role of the API keys that authenticate requests
+//	@tags			API keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	false	"API key ID"
+//	@Success		200	{object}	APIKeyResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func APIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	if path == "/api-keys" || path == "/api-keys/" {
+		switch r.Method {
+		case http.MethodGet:
+			getAPIKeys(w, r)
+		case http.MethodPost:
+			postAPIKey(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	idStr, rotate := strings.CutSuffix(strings.TrimPrefix(path, "/api-keys/"), "/rotate")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	if rotate {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rotateAPIKey(w, r, int32(id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		getAPIKeyByID(w, r, int32(id))
+	case http.MethodPatch:
+		patchAPIKey(w, r, int32(id))
+	case http.MethodDelete:
+		revokeAPIKey(w, r, int32(id))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getAPIKeys lists API keys
+//
+//	@Summary		List API keys
+//	@Description	List every API key, including revoked ones. Keys themselves are never returned.
+//	@tags			API keys
+//	@Produce		json
+//	@Success		200	{array}		APIKeyResponse
+//	@Failure		500	{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/api-keys [get]
+func getAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := queries.ListAPIKeys(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]APIKeyResponse, len(keys))
+	for i, k := range keys {
+		response[i] = toAPIKeyResponse(k)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getAPIKeyByID returns an API key
+//
+//	@Summary		Get an API key
+//	@Description	Get an API key by ID, with the time it was last used
+//	@tags			API keys
+//	@Produce		json
+//	@Param			id	path		int	true	"API key ID"
+//	@Success		200	{object}	APIKeyResponse
+//	@Failure		404	{string}	string	"API key not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/api-keys/{id} [get]
+func getAPIKeyByID(w http.ResponseWriter, r *http.Request, id int32) {
+	key, err := queries.GetAPIKey(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "API key not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toAPIKeyResponse(key)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// postAPIKey creates an API key
+//
+//	@Summary		Create an API key
+//	@Description	Create an API key. The key is returned only in this response; store it, as only its hash is kept.
+//	@tags			API keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			key	body		APIKeyRequest	true	"API key"
+//	@Success		201	{object}	APIKeySecretResponse
+//	@Failure		400	{string}	string	"Invalid input"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/api-keys [post]
+func postAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || utf8.RuneCountInString(req.Name) > 100 {
+		http.Error(w, "Name must be between 1 and 100 characters", http.StatusBadRequest)
+		return
+	}
+	if req.Role == "" {
+		req.Role = string(auth.RoleViewer)
+	}
+	role, ok := auth.ParseRole(req.Role)
+	if !ok {
+		http.Error(w, "Role must be viewer, editor or admin", http.StatusBadRequest)
+		return
+	}
+
+	secret, key, err := CreateAPIKey(r.Context(), req.Name, role)
+	if err != nil {
+		http.Error(w, "Failed to create API key", http.StatusInternalServerError)
+		return
+	}
+
+	writeAPIKeySecret(w, http.StatusCreated, key, secret)
+}
+
+// rotateAPIKey replaces the key of an API key
+//
+//	@Summary		Rotate an API key
+//	@Description	Replace the key of an API key with a new one, keeping its ID and name. The old key stops working at once.
+//	@tags			API keys
+//	@Produce		json
+//	@Param			id	path		int	true	"API key ID"
+//	@Success		200	{object}	APIKeySecretResponse
+//	@Failure		404	{string}	string	"API key not found or revoked"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/api-keys/{id}/rotate [post]
+func rotateAPIKey(w http.ResponseWriter, r *http.Request, id int32) {
+	secret, keyID, err := auth.NewAPIKey()
+	if err != nil {
+		http.Error(w, "Failed to generate API key", http.StatusInternalServerError)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to rotate API key", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	key, err := qtx.RotateAPIKey(r.Context(), sqlc.RotateAPIKeyParams{
+		ID:      id,
+		KeyID:   keyID,
+		KeyHash: auth.HashAPIKey(secret),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "API key not found or revoked", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to rotate API key", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to rotate API key", http.StatusInternalServerError)
+		return
+	}
+
+	writeAPIKeySecret(w, http.StatusOK, key, secret)
+}
+
+// patchAPIKey changes the role of an API key
+//
+//	@Summary		Change the role of an API key
+//	@Description	Change the role of an API key. It applies from the next request made with the key.
+//	@tags			API keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"API key ID"
+//	@Param			role	body		APIKeyRoleRequest	true	"Role"
+//	@Success		200		{object}	APIKeyResponse
+//	@Failure		400		{string}	string	"Invalid input"
+//	@Failure		404		{string}	string	"API key not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/api-keys/{id} [patch]
+func patchAPIKey(w http.ResponseWriter, r *http.Request, id int32) {
+	var req APIKeyRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+	role, ok := auth.ParseRole(req.Role)
+	if !ok {
+		http.Error(w, "Role must be viewer, editor or admin", http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to update API key", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	key, err := qtx.SetAPIKeyRole(r.Context(), sqlc.SetAPIKeyRoleParams{ID: id, Role: string(role)})
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "API key not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to update API key", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to update API key", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toAPIKeyResponse(key)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// revokeAPIKey revokes an API key
+//
+//	@Summary		Revoke an API key
+//	@Description	Revoke an API key. It stays listed, with the time it was revoked.
+//	@tags			API keys
+//	@Param			id	path		int	true	"API key ID"
+//	@Success		204
+//	@Failure		404	{string}	string	"API key not found or already revoked"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/api-keys/{id} [delete]
+func revokeAPIKey(w http.ResponseWriter, r *http.Request, id int32) {
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	revoked, err := qtx.RevokeAPIKey(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+	if revoked == 0 {
+		http.Error(w, "API key not found or already revoked", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateAPIKey creates an API key named name with role and returns the key,
+// which is not stored, along with its record.
+func CreateAPIKey(ctx context.Context, name string, role auth.Role) (string, sqlc.ApiKey, error) {
+	secret, keyID, err := auth.NewAPIKey()
+	if err != nil {
+		return "", sqlc.ApiKey{}, err
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		return "", sqlc.ApiKey{}, err
+	}
+	defer tx.Rollback()
+
+	key, err := qtx.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
+		Name:    name,
+		Role:    string(role),
+		KeyID:   keyID,
+		KeyHash: auth.HashAPIKey(secret),
+	})
+	if err != nil {
+		return "", sqlc.ApiKey{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", sqlc.ApiKey{}, err
+	}
+	return secret, key, nil
+}
+
+func writeAPIKeySecret(w http.ResponseWriter, status int, key sqlc.ApiKey, secret string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(APIKeySecretResponse{APIKeyResponse: toAPIKeyResponse(key), Key: secret}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func toAPIKeyResponse(k sqlc.ApiKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Role:       k.Role,
+		KeyID:      k.KeyID,
+		CreatedAt:  k.CreatedAt,
+		RotatedAt:  timePtr(k.RotatedAt),
+		LastUsedAt: timePtr(k.LastUsedAt),
+		RevokedAt:  timePtr(k.RevokedAt),
+	}
+}
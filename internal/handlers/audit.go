@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+)
+
+// The audit log is written by the audit_row trigger, in the transaction of
+// each change. Handlers only have to run their changes in a transaction
+// started by beginAudited so that the entries name the caller and request.
+
+// auditEntities are the entity names the audit log records.
+var auditEntities = []string{
+	"api_key", "country", "country_language", "language", "locale_url",
+	"localized_name", "redirect", "site", "slug", "variant", "webhook",
+}
+
+// beginAudited starts a transaction whose changes the audit log attributes
+// to the caller and request of ctx.
+func beginAudited(ctx context.Context) (*sql.Tx, *sqlc.Queries, error) {
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	qtx := queries.WithTx(tx)
+	if err := qtx.SetAuditContext(ctx, sqlc.SetAuditContextParams{
+		Actor:     auditActor(ctx),
+		RequestID: RequestIDFromContext(ctx),
+	}); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	return tx, qtx, nil
+}
+
+// auditActor names the caller of ctx in the audit log: api_key:<id> or
+// jwt:<subject>. Outside of a request it is empty, and the database user is
+// recorded instead.
+func auditActor(ctx context.Context) string {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return p.Kind + ":" + p.Subject
+}
+
+type AuditEntryResponse struct {
+	Seq        int64     `json:"seq"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Actor      string    `json:"actor"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Entity     string    `json:"entity"`
+	// ID identifies the row, as country_id:language_id for country_language.
+	ID        string          `json:"id"`
+	Operation string          `json:"operation"`
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+type PaginatedAuditResponse struct {
+	Entries       []AuditEntryResponse `json:"entries"`
+	NextPageToken string               `json:"next_page_token,omitempty"`
+	PrevPageToken string               `json:"prev_page_token,omitempty"`
+	TotalCount    *int64               `json:"total_count,omitempty"`
+}
+
+// AuditVersionResponse is an entry of the history of a row, with the names
+// of the fields it changed.
+type AuditVersionResponse struct {
+	AuditEntryResponse
+	Changed []string `json:"changed"`
+}
+
+type AuditHistoryResponse struct {
+	Entity  string                 `json:"entity"`
+	ID      string                 `json:"id"`
+	History []AuditVersionResponse `json:"history"`
+}
+
+var auditResource = listquery.Resource{
+	From: "audit_log",
+	Key:  "id",
+	Columns: []listquery.Column{
+		{Name: "seq", SQL: "id", Type: listquery.Int},
+		{Name: "occurred_at", SQL: "occurred_at", Type: listquery.Time},
+		{Name: "actor", SQL: "actor", Type: listquery.String},
+		{Name: "request_id", SQL: "request_id", Type: listquery.String},
+		{Name: "entity", SQL: "entity", Type: listquery.String},
+		{Name: "id", SQL: "entity_id", Type: listquery.String},
+		{Name: "operation", SQL: "operation", Type: listquery.String},
+		{Name: "before", SQL: "before::text", Type: listquery.String},
+		{Name: "after", SQL: "after::text", Type: listquery.String},
+	},
+}
+
+// auditRow holds the columns of auditResource.
+type auditRow struct {
+	AuditEntryResponse
+	before, after string
+}
+
+func auditFields(e *auditRow) []any {
+	return []any{&e.Seq, &e.OccurredAt, &e.Actor, &e.RequestID, &e.Entity, &e.ID, &e.Operation, &e.before, &e.after}
+}
+
+// AuditHandler handles requests for the audit log
+//
+//	@Summary		Audit log
+//	@Description	Search the audit log, or get the history of a single row
+//	@tags			Audit
+//	@Produce		json
+//	@Success		200	{object}	PaginatedAuditResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func AuditHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/audit")
+	if path == "" || path == "/" {
+		getAuditEntries(w, r)
+		return
+	}
+
+	entity, id, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	getAuditHistory(w, r, entity, id)
+}
+
+// getAuditEntries searches the audit log
+//
+//	@Summary		Search the audit log
+//	@Description	List audit entries, newest first by default. Every column can be filtered and sorted on, e.g. entity=country&id=12, actor=api_key:3, request_id=..., occurred_at>=2026-01-01T00:00:00Z or after~=Deutschland. id is the id of the changed row; seq orders the entries.
+//	@tags			Audit
+//	@Produce		json
+//	@Param			entity				query		string	false	"Entity of the changed row"	Enums(api_key, country, country_language, language, locale_url, localized_name, redirect, site, slug, variant, webhook)
+//	@Param			id					query		string	false	"ID of the changed row"
+//	@Param			sort				query		string	false	"Comma-separated columns, prefixed with - for descending order"
+//	@Param			filter				query		string	false	"Filter expression, e.g. operation in (update, delete)"
+//	@Param			page_size			query		int		false	"Number of entries per page (max 100)"
+//	@Param			page_token			query		string	false	"Cursor of the page to fetch"
+//	@Param			include_total_count	query		bool	false	"Include the total number of matching entries"
+//	@Success		200					{object}	PaginatedAuditResponse
+//	@Failure		400					{string}	string	"Invalid sort, filter or page_token parameter"
+//	@Failure		500					{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/audit [get]
+func getAuditEntries(w http.ResponseWriter, r *http.Request) {
+	q, err := auditResource.Parse(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(q.Sort) == 0 {
+		q.Sort = []listquery.Sort{{Column: "seq", Desc: true}}
+	}
+
+	rows, page, ok := fetchPage(w, r, auditResource, q, auditFields)
+	if !ok {
+		return
+	}
+
+	response := PaginatedAuditResponse{
+		Entries:       make([]AuditEntryResponse, len(rows)),
+		NextPageToken: page.NextPageToken,
+		PrevPageToken: page.PrevPageToken,
+		TotalCount:    page.TotalCount,
+	}
+	for i, row := range rows {
+		row.Before = json.RawMessage(row.before)
+		row.After = json.RawMessage(row.after)
+		response.Entries[i] = row.AuditEntryResponse
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getAuditHistory returns the history of a row
+//
+//	@Summary		Get the history of a row
+//	@Description	Get every audit entry of a row, oldest first, with the fields each one changed
+//	@tags			Audit
+//	@Produce		json
+//	@Param			entity	path		string	true	"Entity"	Enums(api_key, country, country_language, language, locale_url, localized_name, redirect, site, slug, variant, webhook)
+//	@Param			id		path		string	true	"ID of the row"
+//	@Success		200		{object}	AuditHistoryResponse
+//	@Failure		404		{string}	string	"Unknown entity or no history"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/audit/{entity}/{id} [get]
+func getAuditHistory(w http.ResponseWriter, r *http.Request, entity, id string) {
+	if !slices.Contains(auditEntities, entity) {
+		http.Error(w, "Unknown entity", http.StatusNotFound)
+		return
+	}
+
+	entries, err := queries.GetAuditHistory(r.Context(), sqlc.GetAuditHistoryParams{Entity: entity, EntityID: id})
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if len(entries) == 0 {
+		http.Error(w, "No history for this row", http.StatusNotFound)
+		return
+	}
+
+	response := AuditHistoryResponse{Entity: entity, ID: id, History: make([]AuditVersionResponse, len(entries))}
+	for i, e := range entries {
+		response.History[i] = AuditVersionResponse{
+			AuditEntryResponse: toAuditEntryResponse(e),
+			Changed:            changedFields(e.Before, e.After),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func toAuditEntryResponse(e sqlc.AuditLog) AuditEntryResponse {
+	return AuditEntryResponse{
+		Seq:        e.ID,
+		OccurredAt: e.OccurredAt,
+		Actor:      e.Actor,
+		RequestID:  e.RequestID,
+		Entity:     e.Entity,
+		ID:         e.EntityID,
+		Operation:  e.Operation,
+		Before:     e.Before,
+		After:      e.After,
+	}
+}
+
+// changedFields returns the sorted names of the fields that differ between
+// two row snapshots, either of which may be null.
+func changedFields(before, after json.RawMessage) []string {
+	var b, a map[string]json.RawMessage
+	json.Unmarshal(before, &b)
+	json.Unmarshal(after, &a)
+
+	changed := []string{}
+	for name, value := range a {
+		if old, ok := b[name]; !ok || !bytes.Equal(old, value) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/auth"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Kind is "api_key" or "jwt".
+	Kind string
+	// Subject is the ID of the API key or the sub claim of the token.
+	Subject string
+	// Name is the name of the API key.
+	Name   string
+	Role   auth.Role
+	Claims auth.Claims
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller authenticated by Authenticate, if
+// any; reads let through by AUTH_PUBLIC_READS have none.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+var (
+	authSettings = config.GetAuth()
+	jwks         = loadJWKS()
+)
+
+func loadJWKS() *auth.KeySet {
+	if authSettings.JWKSFile == "" {
+		return nil
+	}
+	ks, err := auth.ReadKeySetFile(authSettings.JWKSFile)
+	if err != nil {
+		log.Fatal("Failed to load AUTH_JWKS_FILE: ", err)
+	}
+	return ks
+}
+
+// publicPaths are served without credentials.
+var publicPaths = []string{"/swagger-ui/"}
+
+var (
+	errNoCredentials      = errors.New("no credentials")
+	errInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticate requires every request to carry an API key, in the X-API-Key
+// header or as a bearer token, or a JWT bearer token signed by a key of
+// AUTH_JWKS_FILE. With AUTH_PUBLIC_READS=true, GET and HEAD requests without
+// credentials are let through.
+func Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, prefix := range publicPaths {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		principal, err := authenticate(r)
+		switch {
+		case errors.Is(err, errNoCredentials):
+			if authSettings.PublicReads && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			unauthorized(w, "Authentication required")
+			return
+		case errors.Is(err, errInvalidCredentials):
+			unauthorized(w, "Invalid credentials")
+			return
+		case err != nil:
+			http.Error(w, "Authentication failed", http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="uurl-admin"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
+
+func authenticate(r *http.Request) (Principal, error) {
+	credential := r.Header.Get("X-API-Key")
+	if credential == "" {
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			credential = strings.TrimSpace(token)
+		}
+	}
+	if credential == "" {
+		return Principal{}, errNoCredentials
+	}
+
+	if keyID, ok := auth.ParseAPIKey(credential); ok {
+		return authenticateAPIKey(r.Context(), credential, keyID)
+	}
+
+	if jwks == nil {
+		return Principal{}, errInvalidCredentials
+	}
+	claims, err := jwks.Verify(credential, auth.VerifyOptions{
+		Issuer:   authSettings.JWTIssuer,
+		Audience: authSettings.JWTAudience,
+	})
+	if err != nil || claims.Subject() == "" {
+		return Principal{}, errInvalidCredentials
+	}
+	role, ok := auth.HighestRole(claims.Strings(authSettings.JWTRolesClaim))
+	if !ok {
+		role = auth.RoleViewer
+	}
+	return Principal{Kind: "jwt", Subject: claims.Subject(), Role: role, Claims: claims}, nil
+}
+
+func authenticateAPIKey(ctx context.Context, key, keyID string) (Principal, error) {
+	apiKey, err := queries.GetAPIKeyByKeyID(ctx, keyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Principal{}, errInvalidCredentials
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	if apiKey.RevokedAt.Valid || !auth.CheckAPIKey(key, apiKey.KeyHash) {
+		return Principal{}, errInvalidCredentials
+	}
+
+	// last_used_at is a hint for cleaning up keys, so failing to record it
+	// does not fail the request.
+	if err := queries.TouchAPIKey(ctx, apiKey.ID); err != nil {
+		log.Printf("auth: recording use of API key %d: %v", apiKey.ID, err)
+	}
+
+	// The column is constrained to valid roles.
+	role, _ := auth.ParseRole(apiKey.Role)
+	return Principal{Kind: "api_key", Subject: strconv.Itoa(int(apiKey.ID)), Name: apiKey.Name, Role: role}, nil
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+	"github.com/lib/pq"
+)
+
+// changeEntities are the entities whose changes are streamed.
+var changeEntities = []string{"country", "language", "variant"}
+
+const (
+	changeBatchSize         = 100
+	changeHeartbeatInterval = 15 * time.Second
+	// changeSequencerInterval is how often events are numbered and the
+	// listener checked without being notified, in case a notification was
+	// lost while it reconnected.
+	changeSequencerInterval = 30 * time.Second
+)
+
+// ChangeEventResponse is the data of an event of the change stream.
+type ChangeEventResponse struct {
+	Seq    int64  `json:"seq"`
+	Entity string `json:"entity"`
+	// Operation is created, updated, deleted or restored.
+	Operation  string    `json:"operation"`
+	ID         string    `json:"id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Data is the row after the change, or before it for deletions.
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// changeSubscribers are woken when more changes can be read.
+var changeSubscribers = struct {
+	sync.Mutex
+	wake map[chan struct{}]bool
+}{wake: map[chan struct{}]bool{}}
+
+func subscribeChanges() chan struct{} {
+	ch := make(chan struct{}, 1)
+	changeSubscribers.Lock()
+	changeSubscribers.wake[ch] = true
+	changeSubscribers.Unlock()
+	return ch
+}
+
+func unsubscribeChanges(ch chan struct{}) {
+	changeSubscribers.Lock()
+	delete(changeSubscribers.wake, ch)
+	changeSubscribers.Unlock()
+}
+
+func wakeChangeSubscribers() {
+	changeSubscribers.Lock()
+	defer changeSubscribers.Unlock()
+	for ch := range changeSubscribers.wake {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ListenForChanges numbers the outbox events as they are committed and wakes
+// the change streams of this replica when there are new ones, until ctx is
+// done. Every replica runs it: the numbering is done by one at a time and
+// notified to all of them through Postgres, and the streams read the events
+// from the database, so that they all emit the same events with the same IDs.
+func ListenForChanges(ctx context.Context) {
+	listener := pq.NewListener(config.GetDatabaseURL(), time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("changes: listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	for _, channel := range []string{"outbox_event", "changes"} {
+		if err := listener.Listen(channel); err != nil {
+			log.Printf("changes: listening on %s: %v", channel, err)
+			return
+		}
+	}
+
+	sequence := func() {
+		if err := sequenceChanges(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("changes: numbering events: %v", err)
+		}
+	}
+	sequence()
+
+	ticker := time.NewTicker(changeSequencerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-listener.Notify:
+			switch {
+			case n == nil:
+				// The connection was lost and notifications with it.
+				sequence()
+				wakeChangeSubscribers()
+			case n.Channel == "outbox_event":
+				sequence()
+			case n.Channel == "changes":
+				wakeChangeSubscribers()
+			}
+		case <-ticker.C:
+			sequence()
+			listener.Ping()
+		}
+	}
+}
+
+// sequenceChanges numbers the committed outbox events that have no seq yet
+// and notifies the replicas. Sequencers take turns so that a seq is only
+// visible once every smaller one is.
+func sequenceChanges(ctx context.Context) error {
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := queries.WithTx(tx)
+
+	if err := qtx.LockChangeSequencer(ctx); err != nil {
+		return err
+	}
+	last, err := qtx.SequenceOutboxEvents(ctx)
+	if err != nil {
+		return err
+	}
+	if last > 0 {
+		if err := qtx.NotifyChanges(ctx, last); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ChangeStreamHandler streams changes as server-sent events
+//
+//	@Summary		Stream changes
+//	@Description	Stream the creations, updates, deletions and restorations of countries, languages and variants as server-sent events, named <entity>.<operation>, e.g. country.updated.
+//	@Description	Event IDs are a sequence increasing in the order changes become visible, the same on every replica. Reconnecting with Last-Event-ID resumes after that event; without it the stream starts with the next change. A heartbeat comment is sent every 15 seconds.
+//	@tags			Changes
+//	@Produce		text/event-stream
+//	@Param			entity			query		string				false	"Comma-separated entities to stream, all by default"	Enums(country, language, variant)
+//	@Param			Last-Event-ID	header		int					false	"ID of the last event received, to resume after it"
+//	@Success		200				{object}	ChangeEventResponse	"Stream of events whose data is a ChangeEventResponse"
+//	@Failure		400				{string}	string				"Invalid entity or Last-Event-ID"
+//	@Failure		405				{string}	string				"Method not allowed"
+//	@Failure		500				{string}	string				"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/changes/stream [get]
+func ChangeStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+
+	entities := changeEntities
+	if value := r.URL.Query().Get("entity"); value != "" {
+		entities = strings.Split(value, ",")
+		for _, e := range entities {
+			if !slices.Contains(changeEntities, e) {
+				http.Error(w, "Unknown entity "+strconv.Quote(e), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	// Subscribing before the first read ensures no change is missed between
+	// it and the wait for the next one.
+	wake := subscribeChanges()
+	defer unsubscribeChanges(wake)
+
+	var last int64
+	var err error
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		if last, err = strconv.ParseInt(id, 10, 64); err != nil || last < 0 {
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	} else if last, err = queries.GetLastChangeSeq(ctx); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(changeHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		changes, err := queries.ListChanges(ctx, sqlc.ListChangesParams{
+			AfterSeq:   last,
+			Entities:   entities,
+			MaxChanges: changeBatchSize,
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("changes: reading changes after %d: %v", last, err)
+			}
+			return
+		}
+		for _, c := range changes {
+			if err := writeChangeEvent(w, c); err != nil {
+				return
+			}
+			last = c.Seq
+		}
+		if len(changes) > 0 {
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+		if len(changes) == changeBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeChangeEvent(w http.ResponseWriter, c sqlc.ListChangesRow) error {
+	name := strings.TrimPrefix(c.Type, "uurl.")
+	data, err := json.Marshal(ChangeEventResponse{
+		Seq:        c.Seq,
+		Entity:     c.Entity,
+		Operation:  strings.TrimPrefix(name, c.Entity+"."),
+		ID:         c.EntityID,
+		OccurredAt: c.OccurredAt,
+		Data:       c.Data,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", c.Seq, name, data)
+	return err
+}
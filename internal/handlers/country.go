@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+	"github.com/lib/pq"
 )
 
 type GetAllCountriesResponse struct {
@@ -17,6 +20,16 @@ type GetAllCountriesResponse struct {
 	Tld               string `json:"tld"`
 	Iso31662A1        string `json:"iso3166_2_a1"`
 	Iso31662A3        string `json:"iso3166_2_a3"`
+	Iso3166Numeric    string `json:"iso3166_numeric"`
+	Version           int32  `json:"version"`
+	// DeletedAt is set on deleted countries, which lists only return with
+	// include_deleted=true.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Status is active, deprecated or retired. Lists only return retired
+	// countries with include_retired=true.
+	Status       string     `json:"status"`
+	DeprecatedAt *time.Time `json:"deprecated_at,omitempty"`
+	ReplacedBy   *int32     `json:"replaced_by,omitempty"`
 }
 
 type InsertCountryRequest struct {
@@ -25,10 +38,48 @@ type InsertCountryRequest struct {
 	Tld               string `json:"tld"`
 	Iso31662A1        string `json:"iso3166_2_a1"`
 	Iso31662A3        string `json:"iso3166_2_a3"`
+	Iso3166Numeric    string `json:"iso3166_numeric"`
 }
 
-type CountryFilter struct {
-	LanguageIds []int32 `json:"language_ids"`
+type PaginatedCountriesResponse struct {
+	Countries     []GetAllCountriesResponse `json:"countries"`
+	NextPageToken string                    `json:"next_page_token,omitempty"`
+	PrevPageToken string                    `json:"prev_page_token,omitempty"`
+	TotalCount    *int64                    `json:"total_count,omitempty"`
+}
+
+var countryResource = listquery.Resource{
+	From: "country",
+	Key:  "id",
+	Columns: append([]listquery.Column{
+		{Name: "id", SQL: "id", Type: listquery.Int},
+		{Name: "name", SQL: "name", Type: listquery.String},
+		{Name: "official_state_name", SQL: "COALESCE(official_state_name, '')", Type: listquery.String},
+		{Name: "tld", SQL: "tld", Type: listquery.String},
+		{Name: "iso3166_2_a1", SQL: "iso3166_2_a1", Type: listquery.String},
+		{Name: "iso3166_2_a3", SQL: "iso3166_2_a3", Type: listquery.String},
+		{Name: "iso3166_numeric", SQL: "COALESCE(iso3166_numeric, '')", Type: listquery.String},
+		{Name: "created_at", SQL: "created_at", Type: listquery.Time},
+		{Name: "updated_at", SQL: "updated_at", Type: listquery.Time},
+		{Name: "version", SQL: "version", Type: listquery.Int},
+		deletedAtColumn,
+	}, lifecycleColumns...),
+	Params: []string{"language_ids", "as_of", "include_deleted", "include_retired"},
+}
+
+// countryRow holds the columns of countryResource.
+type countryRow struct {
+	GetAllCountriesResponse
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    time.Time
+	DeprecatedAt time.Time
+	ReplacedBy   int32
+}
+
+func countryFields(c *countryRow) []any {
+	return []any{&c.ID, &c.Name, &c.OfficialStateName, &c.Tld, &c.Iso31662A1, &c.Iso31662A3, &c.Iso3166Numeric, &c.CreatedAt, &c.UpdatedAt, &c.Version, &c.DeletedAt,
+		&c.Status, &c.DeprecatedAt, &c.ReplacedBy}
 }
 
 // CountryHandler handles requests for country-related operations
@@ -41,13 +92,40 @@ type CountryFilter struct {
 // @Success 200  {object}  GetAllCountriesResponse
 // @Failure 400  {string}  string  "Invalid item ID"
 // @Failure 405  {string}  string  "Method not allowed"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /country [get]
 // @Router /country [post]
 func CountryHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	if rest, ok := strings.CutPrefix(path, "/country/"); ok {
+		if idStr, action, ok := strings.Cut(rest, "/"); ok {
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				http.Error(w, "Invalid item ID", http.StatusBadRequest)
+				return
+			}
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			switch action {
+			case "revert":
+				revertCountry(w, r, int32(id))
+			case "restore":
+				restoreCountry(w, r, int32(id))
+			case "status":
+				setCountryStatus(w, r, int32(id))
+			default:
+				http.Error(w, "Not found", http.StatusNotFound)
+			}
+			return
+		}
+	}
+
 	switch r.Method {
 	case http.MethodGet:
-		path := r.URL.Path
-
 		if path == "/country" || path == "/country/" {
 			getFilteredCountries(w, r)
 			return
@@ -62,99 +140,169 @@ func CountryHandler(w http.ResponseWriter, r *http.Request) {
 		getCountryByID(w, r, int32(id))
 	case http.MethodPost:
 		createCountry(w, r)
+	case http.MethodDelete:
+		id, err := strconv.Atoi(strings.TrimPrefix(path, "/country/"))
+		if err != nil {
+			http.Error(w, "Invalid item ID", http.StatusBadRequest)
+			return
+		}
+		deleteCountry(w, r, int32(id))
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
-// getFilteredCountries retrieves a list of countries filtered by language IDs
+// getFilteredCountries retrieves a page of countries
 // @Summary Get filtered countries
-// @Description Retrieves a list of countries filtered by language IDs
+// @Description Retrieves a page of countries, leaving out deleted and retired ones unless include_deleted or include_retired is true. Any column can be filtered with col=v (repeat for any of several values), col~=v (case-insensitive substring), col!=v, col>=v and col<=v, or with filter expressions such as "iso3166_2_a1 in (PT, BR)". Looking a code up with iso3166_2_a1=v, iso3166_2_a3=v or iso3166_numeric=v returns retired countries too, and when a single one matches and it is deprecated or retired, the response has a Deprecation header and a successor-version Link to its replacement. Columns: id, name, official_state_name, tld, iso3166_2_a1, iso3166_2_a3, iso3166_numeric, created_at, updated_at, version, deleted_at, status, deprecated_at, replaced_by.
 // @Tags Country
 // @Accept json
 // @Produce json
 // @Param language_ids query []int false "Filter by language IDs"
-// @Success 200 {array} GetAllCountriesResponse
-// @Failure 400 {string} string "Invalid language_ids parameter"
+// @Param as_of query string false "List the countries as they were at this RFC 3339 time or date" example(2026-01-01)
+// @Param include_deleted query bool false "Include deleted countries"
+// @Param include_retired query bool false "Include retired countries"
+// @Param sort query string false "Comma-separated columns, prefixed with - for descending order" example(name,-created_at)
+// @Param filter query []string false "Filter expressions" collectionFormat(multi)
+// @Param page_size query int false "Limit of items per page" default(10) maximum(100)
+// @Param page_token query string false "Cursor of the page to fetch"
+// @Param include_total_count query bool false "Include the total number of matching countries"
+// @Success 200 {object} PaginatedCountriesResponse
+// @Header 200 {string} Deprecation "On a code lookup matching a deprecated or retired country, when it was deprecated, as @ and a Unix time"
+// @Header 200 {string} Link "On a code lookup, successor-version link to the country replacing the one found"
+// @Failure 400 {string} string "Invalid sort, filter, language_ids, as_of, include_deleted, include_retired or page_token parameter"
 // @Failure 500 {string} string "Failed to get countries"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /country [get]
 func getFilteredCountries(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	query := r.URL.Query()
 
-	languageIdsStr := query["language_ids"]
+	q, err := countryResource.Parse(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	var languageIds []int32
-	for _, idStr := range languageIdsStr {
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		http.Error(w, "Invalid include_deleted parameter", http.StatusBadRequest)
+		return
+	}
+	if !includeDeleted {
+		q.Where = append(q.Where, notDeleted)
+	}
+
+	includeRetired, err := parseIncludeRetired(r)
+	if err != nil {
+		http.Error(w, "Invalid include_retired parameter", http.StatusBadRequest)
+		return
+	}
+	lookup := isCodeLookup(q, "iso3166_2_a1", "iso3166_2_a3", "iso3166_numeric")
+	if !includeRetired && !lookup {
+		q.Where = append(q.Where, notRetired)
+	}
+
+	res := countryResource
+	if asOf, ok, err := parseAsOf(r); err != nil {
+		http.Error(w, "Invalid as_of parameter", http.StatusBadRequest)
+		return
+	} else if ok {
+		res = asOfResource(res, asOf)
+	}
+
+	var languageIds []int64
+	for _, idStr := range query["language_ids"] {
 		id, err := strconv.Atoi(idStr)
 		if err != nil {
 			http.Error(w, "Invalid language_ids parameter", http.StatusBadRequest)
 			return
 		}
-		languageIds = append(languageIds, int32(id))
+		languageIds = append(languageIds, int64(id))
 	}
-
-	filter := CountryFilter{
-		LanguageIds: languageIds,
+	if len(languageIds) > 0 {
+		q.Where = append(q.Where, listquery.Condition{
+			SQL:  "id IN (SELECT country_id FROM country_language WHERE language_id = ANY(?))",
+			Args: []any{pq.Array(languageIds)},
+		})
 	}
 
-	countries, err := queries.GetFilteredCountry(ctx, filter.LanguageIds)
-	if err != nil {
-		println(err.Error())
-		http.Error(w, "Failed to get countries", http.StatusInternalServerError)
+	countries, page, ok := fetchPage(w, r, res, q, countryFields)
+	if !ok {
 		return
 	}
 
-	var result []GetAllCountriesResponse
+	response := PaginatedCountriesResponse{
+		Countries:     []GetAllCountriesResponse{},
+		NextPageToken: page.NextPageToken,
+		PrevPageToken: page.PrevPageToken,
+		TotalCount:    page.TotalCount,
+	}
 	for _, country := range countries {
+		country.GetAllCountriesResponse.DeletedAt = coalescedTime(country.DeletedAt)
+		country.GetAllCountriesResponse.DeprecatedAt = coalescedTime(country.DeprecatedAt)
+		country.GetAllCountriesResponse.ReplacedBy = coalescedID(country.ReplacedBy)
+		response.Countries = append(response.Countries, country.GetAllCountriesResponse)
+	}
 
-		if err != nil {
-			http.Error(w, "Failed to get variants for country", http.StatusInternalServerError)
-			return
-		}
-
-		result = append(result, GetAllCountriesResponse{
-			ID:                country.ID,
-			Name:              country.Name,
-			Tld:               country.Tld,
-			Iso31662A1:        country.Iso31662A1,
-			Iso31662A3:        country.Iso31662A3,
-			OfficialStateName: country.OfficialStateName.String,
-		})
+	if lookup && len(countries) == 1 {
+		c := countries[0]
+		deprecatedAt := sql.NullTime{Time: c.DeprecatedAt, Valid: coalescedTime(c.DeprecatedAt) != nil}
+		replacedBy := sql.NullInt32{Int32: c.ReplacedBy, Valid: c.ReplacedBy != 0}
+		setDeprecationHeaders(w, c.Status, deprecatedAt, replacedBy, "/country/")
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
 
 // getCountryByID retrieves a country by its ID
 // @Summary Get country by ID
-// @Description Retrieves a country by the provided ID
+// @Description Retrieves a country by the provided ID. Deleted countries are returned too, with their deleted_at. Deprecated and retired countries come with a Deprecation header and a successor-version Link to their replacement.
 // @tags Country
 // @Accept  json
 // @Produce  json
 // @Param   id   path   int   true  "Country ID"
+// @Param   as_of  query  string  false  "Get the country as it was at this RFC 3339 time or date"
+// @Param   If-None-Match  header  string  false  "ETag of a copy of the country, to get 304 if it has not changed"
 // @Success 200  {object}  GetAllCountriesResponse
-// @Failure 400  {string}  string  "Invalid item ID"
+// @Success 304  {string}  string  "Not modified"
+// @Header  200  {string}  ETag  "Version of the country, for If-Match and If-None-Match"
+// @Header  200  {string}  Deprecation  "When a deprecated or retired country was deprecated, as @ and a Unix time"
+// @Header  200  {string}  Link  "successor-version link to the country replacing it"
+// @Failure 400  {string}  string  "Invalid item ID or as_of"
+// @Failure 404  {string}  string  "Country not found"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /country/{id} [get]
 func getCountryByID(w http.ResponseWriter, r *http.Request, id int32) {
 	ctx := r.Context()
 
-	country, err := queries.GetCountryById(ctx, id)
+	asOf, historic, err := parseAsOf(r)
+	if err != nil {
+		http.Error(w, "Invalid as_of parameter", http.StatusBadRequest)
+		return
+	}
+
+	var country sqlc.GetCountryByIdRow
+	if historic {
+		var row sqlc.GetCountryByIdAsOfRow
+		row, err = queries.GetCountryByIdAsOf(ctx, sqlc.GetCountryByIdAsOfParams{ID: id, AsOf: asOf})
+		country = sqlc.GetCountryByIdRow(row)
+	} else {
+		country, err = queries.GetCountryById(ctx, id)
+	}
 	if err != nil {
 		http.Error(w, "Country not found", http.StatusNotFound)
 		return
 	}
 
-	result := GetAllCountriesResponse{
-		ID:                country.ID,
-		Name:              country.Name,
-		OfficialStateName: country.OfficialStateName.String,
-		Iso31662A1:        country.Iso31662A1,
-		Iso31662A3:        country.Iso31662A3,
-		Tld:               country.Tld,
+	result := toCountryResponse(country)
+	setDeprecationHeaders(w, country.Status, country.DeprecatedAt, country.ReplacedBy, "/country/")
+	if notModified(w, r, versionETag(country.Version)) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -170,8 +318,13 @@ func getCountryByID(w http.ResponseWriter, r *http.Request, id int32) {
 // @Accept  json
 // @Produce  json
 // @Param   country  body  InsertCountryRequest  true  "Country Data"
+// @Param   Idempotency-Key  header  string  false  "Makes retries safe: a retry with the same key and body gets the first response again"
 // @Success 201  {object}  GetAllCountriesResponse
 // @Failure 400  {string}  string  "Invalid input"
+// @Failure 409  {string}  string  "A request with this Idempotency-Key is still being processed"
+// @Failure 422  {string}  string  "Idempotency-Key already used with a different request"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /country [post]
 func createCountry(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -188,32 +341,155 @@ func createCountry(w http.ResponseWriter, r *http.Request) {
 		Tld:               input.Tld,
 		Iso31662A1:        input.Iso31662A1,
 		Iso31662A3:        input.Iso31662A3,
+		Iso3166Numeric:    sql.NullString{String: input.Iso3166Numeric, Valid: input.Iso3166Numeric != ""},
 	}
 
-	countryID, err := queries.InsertCountry(ctx, countryParams)
+	tx, qtx, err := beginAudited(ctx)
 	if err != nil {
 		http.Error(w, "Failed to insert country", http.StatusInternalServerError)
 		return
 	}
+	defer tx.Rollback()
 
-	country, err := queries.GetCountryById(ctx, countryID)
+	countryID, err := qtx.InsertCountry(ctx, countryParams)
+	if err != nil {
+		http.Error(w, "Failed to insert country", http.StatusInternalServerError)
+		return
+	}
+
+	country, err := qtx.GetCountryById(ctx, countryID)
 	if err != nil {
 		http.Error(w, "Failed to retrieve inserted country", http.StatusInternalServerError)
 		return
 	}
 
-	result := GetAllCountriesResponse{
-		ID:                country.ID,
-		Name:              country.Name,
-		Tld:               country.Tld,
-		OfficialStateName: country.OfficialStateName.String,
-		Iso31662A1:        country.Iso31662A1,
-		Iso31662A3:        country.Iso31662A3,
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to insert country", http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	if err := json.NewEncoder(w).Encode(toCountryResponse(country)); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
+
+// revertCountry restores a previous version of a country
+// @Summary Revert a country
+// @Description Copies a previous version of a country back into it, which records it as a new version. Versions are numbered from 1 and listed by GET /audit/country/{id}, or read with as_of.
+// @tags Country
+// @Produce  json
+// @Param   id       path   int  true  "Country ID"
+// @Param   version  query  int  true  "Version to restore"
+// @Success 200  {object}  GetAllCountriesResponse
+// @Failure 400  {string}  string  "Invalid item ID or version"
+// @Failure 404  {string}  string  "Country or version not found"
+// @Failure 409  {string}  string  "The version conflicts with another country"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /country/{id}/revert [post]
+func revertCountry(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := revertRow(w, r, "Country", func(qtx *sqlc.Queries, version int32) (int64, error) {
+		return qtx.RevertCountry(r.Context(), sqlc.RevertCountryParams{ID: id, Version: version})
+	})
+	if ok {
+		getCountryByID(w, r, id)
+	}
+}
+
+// deleteCountry deletes a country
+// @Summary Delete a country
+// @Description Marks a country as deleted. It is left out of lists but can still be read by ID, and restored until it is purged after the retention period.
+// @tags Country
+// @Param   id   path   int  true  "Country ID"
+// @Param   If-Match  header  string  true  "ETag of the country"
+// @Success 204  {string}  string  "Deleted"
+// @Failure 400  {string}  string  "Invalid item ID"
+// @Failure 404  {string}  string  "Country not found or already deleted"
+// @Failure 412  {string}  string  "The country has changed since its ETag"
+// @Failure 428  {string}  string  "If-Match header required"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /country/{id} [delete]
+func deleteCountry(w http.ResponseWriter, r *http.Request, id int32) {
+	lock := func(qtx *sqlc.Queries) (string, error) {
+		version, err := qtx.LockCountry(r.Context(), id)
+		return versionETag(version), err
+	}
+	ok := changeDeletion(w, r, "Country not found or already deleted", lock, func(qtx *sqlc.Queries) (int64, error) {
+		return qtx.DeleteCountry(r.Context(), id)
+	})
+	if ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// restoreCountry restores a deleted country
+// @Summary Restore a country
+// @Description Restores a deleted country that has not been purged yet
+// @tags Country
+// @Produce  json
+// @Param   id   path   int  true  "Country ID"
+// @Success 200  {object}  GetAllCountriesResponse
+// @Failure 400  {string}  string  "Invalid item ID"
+// @Failure 404  {string}  string  "Deleted country not found"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /country/{id}/restore [post]
+func restoreCountry(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeDeletion(w, r, "Deleted country not found", nil, func(qtx *sqlc.Queries) (int64, error) {
+		return qtx.RestoreCountry(r.Context(), id)
+	})
+	if ok {
+		getCountryByID(w, r, id)
+	}
+}
+
+// setCountryStatus changes the lifecycle status of a country
+// @Summary Deprecate, retire or reactivate a country
+// @Description Sets the status of a country, e.g. when its code is withdrawn. deprecated_at defaults to now, and replaced_by names the country to use instead.
+// @tags Country
+// @Accept  json
+// @Produce  json
+// @Param   id      path  int            true  "Country ID"
+// @Param   status  body  StatusRequest  true  "New status"
+// @Success 200  {object}  GetAllCountriesResponse
+// @Failure 400  {string}  string  "Invalid item ID, status or replaced_by"
+// @Failure 404  {string}  string  "Country not found"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /country/{id}/status [post]
+func setCountryStatus(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeStatus(w, r, id, "Country not found", func(qtx *sqlc.Queries, l lifecycle) (int64, error) {
+		return qtx.SetCountryStatus(r.Context(), sqlc.SetCountryStatusParams{
+			Status:       l.Status,
+			DeprecatedAt: l.DeprecatedAt,
+			ReplacedBy:   l.ReplacedBy,
+			ID:           id,
+		})
+	})
+	if ok {
+		getCountryByID(w, r, id)
+	}
+}
+
+func toCountryResponse(c sqlc.GetCountryByIdRow) GetAllCountriesResponse {
+	response := GetAllCountriesResponse{
+		ID:                c.ID,
+		Name:              c.Name,
+		OfficialStateName: c.OfficialStateName.String,
+		Iso31662A1:        c.Iso31662A1,
+		Iso31662A3:        c.Iso31662A3,
+		Iso3166Numeric:    c.Iso3166Numeric.String,
+		Tld:               c.Tld,
+		Version:           c.Version,
+		DeletedAt:         timePtr(c.DeletedAt),
+		Status:            c.Status,
+		DeprecatedAt:      timePtr(c.DeprecatedAt),
+	}
+	if c.ReplacedBy.Valid {
+		response.ReplacedBy = &c.ReplacedBy.Int32
+	}
+	return response
+}
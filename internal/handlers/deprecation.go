@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+)
+
+// Countries, languages and variants are active until their code is
+// withdrawn, e.g. YU or iw, when they become deprecated, and eventually
+// retired. Clients keep sending old codes, so lookups by ID or code still
+// return them, with a Deprecation header and a successor-version link to the
+// record replacing them. Lists leave retired records out unless
+// include_retired=true.
+
+const (
+	statusActive     = "active"
+	statusDeprecated = "deprecated"
+	statusRetired    = "retired"
+)
+
+// lifecycleColumns are the status columns of list resources. Missing
+// deprecation dates and replacements read as the epoch and 0.
+var lifecycleColumns = []listquery.Column{
+	{Name: "status", SQL: "status", Type: listquery.String},
+	{Name: "deprecated_at", SQL: "COALESCE(deprecated_at, 'epoch')", Type: listquery.Time},
+	{Name: "replaced_by", SQL: "COALESCE(replaced_by, 0)", Type: listquery.Int},
+}
+
+// notRetired is the list condition leaving out retired rows.
+var notRetired = listquery.Condition{SQL: "status <> 'retired'"}
+
+// isCodeLookup reports whether the list query q looks records up by one of
+// their codes, with an equality filter on one of codeColumns such as
+// ?iso_639_1=iw. Lookups return retired records, and set deprecation headers
+// when a single record matches.
+func isCodeLookup(q listquery.Query, codeColumns ...string) bool {
+	for _, f := range q.Filters {
+		if f.Op == listquery.Eq && slices.Contains(codeColumns, f.Column) {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusRequest is the body of the status endpoints.
+type StatusRequest struct {
+	// Status is active, deprecated or retired.
+	Status string `json:"status"`
+	// DeprecatedAt defaults to now for deprecated and retired records, and
+	// is cleared along with ReplacedBy for active ones.
+	DeprecatedAt *time.Time `json:"deprecated_at"`
+	// ReplacedBy is the ID of the record to use instead.
+	ReplacedBy *int32 `json:"replaced_by"`
+}
+
+// lifecycle is a validated StatusRequest.
+type lifecycle struct {
+	Status       string
+	DeprecatedAt sql.NullTime
+	ReplacedBy   sql.NullInt32
+}
+
+// parseIncludeRetired reads the include_retired parameter.
+func parseIncludeRetired(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("include_retired")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// coalescedID returns an ID read from a column COALESCEd to 0, or nil for 0.
+func coalescedID(id int32) *int32 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// changeStatus sets the status of row id from the StatusRequest body of r
+// with set, in an audited transaction. It writes the error response itself
+// and reports false on failure; notFound is the 404 message when set affects
+// no row.
+func changeStatus(w http.ResponseWriter, r *http.Request, id int32, notFound string, set func(*sqlc.Queries, lifecycle) (int64, error)) bool {
+	var req StatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return false
+	}
+
+	l := lifecycle{Status: req.Status}
+	switch req.Status {
+	case statusActive:
+		if req.DeprecatedAt != nil || req.ReplacedBy != nil {
+			http.Error(w, "deprecated_at and replaced_by are only allowed when deprecating or retiring", http.StatusBadRequest)
+			return false
+		}
+	case statusDeprecated, statusRetired:
+		l.DeprecatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		if req.DeprecatedAt != nil {
+			l.DeprecatedAt.Time = *req.DeprecatedAt
+		}
+		if req.ReplacedBy != nil {
+			if *req.ReplacedBy == id {
+				http.Error(w, "A record cannot replace itself", http.StatusBadRequest)
+				return false
+			}
+			l.ReplacedBy = sql.NullInt32{Int32: *req.ReplacedBy, Valid: true}
+		}
+	default:
+		http.Error(w, "Invalid status, expected active, deprecated or retired", http.StatusBadRequest)
+		return false
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	defer tx.Rollback()
+
+	affected, err := set(qtx, l)
+	if isForeignKeyViolation(err) {
+		http.Error(w, "Unknown replaced_by", http.StatusBadRequest)
+		return false
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	if affected == 0 {
+		http.Error(w, notFound, http.StatusNotFound)
+		return false
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// setDeprecationHeaders announces in the response to a lookup that a record
+// is deprecated or retired, as an RFC 9745 Deprecation date and a link to
+// its replacement under path, e.g. "/country/".
+func setDeprecationHeaders(w http.ResponseWriter, status string, deprecatedAt sql.NullTime, replacedBy sql.NullInt32, path string) {
+	if status == statusActive {
+		return
+	}
+	if deprecatedAt.Valid {
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Time.Unix(), 10))
+	}
+	if replacedBy.Valid {
+		w.Header().Add("Link", "<"+path+strconv.Itoa(int(replacedBy.Int32))+`>; rel="successor-version"`)
+	}
+}
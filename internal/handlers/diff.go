@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/datadiff"
+)
+
+// DiffHandler godoc
+//
+//	@Summary		Compare a data file with this instance
+//	@Description	Compares an export of another instance, or any file POST /import accepts, with the data of this instance by ISO codes, and returns what importing it would add and update.
+//	@Description	Only the entities in the file are compared. Rows only this instance has are listed as removals, which POST /import never applies.
+//	@Tags			Import and export
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Produce		plain
+//	@Param			file	formData	file	true	"CSV, zipped CSV, JSON Lines or XLSX file"
+//	@Param			format	formData	string	false	"File format, inferred from the file name by default"	Enums(csv, jsonl, xlsx)
+//	@Param			entity	formData	string	false	"Entity of a single CSV file or of JSON Lines records without an entity field"	Enums(country, language, variant, country_language)
+//	@Param			mapping	formData	string	false	"JSON column mapping, as for POST /import"
+//	@Param			output	formData	string	false	"Response format"	Enums(json, text)	default(json)
+//	@Success		200		{object}	datadiff.Changeset
+//	@Failure		400		{string}	string	"Invalid file, format, entity or mapping"
+//	@Failure		405		{string}	string	"Method not allowed"
+//	@Failure		413		{string}	string	"File too large"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/diff [post]
+func DiffHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "File too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+
+	output := r.FormValue("output")
+	if output != "" && output != "json" && output != "text" {
+		http.Error(w, "Invalid output", http.StatusBadRequest)
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	mapping, err := parseImportMapping(r.FormValue("mapping"))
+	if err != nil {
+		http.Error(w, "Invalid mapping: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	source, err := readImportTables(data, fileHeader.Filename, r.FormValue("format"), r.FormValue("entity"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	source, err = mapImportTables(source, mapping)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var entities []string
+	for _, t := range source {
+		entities = append(entities, t.Name)
+	}
+
+	target, err := exportTables(r.Context(), entities)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	changes, err := datadiff.Diff(source, target, ExchangeKeys())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if output == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		changes.WriteText(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(changes); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+)
+
+// Single resources carry strong ETags, taken from their version where they
+// are versioned and from updated_at otherwise. Reads answer a matching
+// If-None-Match with 304, and PUT, PATCH and DELETE need an If-Match with
+// the current ETag, so that concurrent edits fail instead of overwriting each
+// other. The ETag is compared with the row locked in the transaction of the
+// change, so it cannot change in between.
+
+// versionETag is the ETag of a versioned resource.
+func versionETag(version int32) string {
+	return `"v` + strconv.Itoa(int(version)) + `"`
+}
+
+// timeETag is the ETag of a resource last updated at t.
+func timeETag(t time.Time) string {
+	return `"` + strconv.FormatInt(t.UnixMicro(), 36) + `"`
+}
+
+// etagMatches reports whether etag is in header, a list of ETags or "*".
+// Weak ETags only match when weak is true, as for If-None-Match.
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets the ETag of a single resource on the response, and
+// answers 304 Not Modified if r is a read whose If-None-Match already has
+// it. It reports whether it did.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	header := r.Header.Get("If-None-Match")
+	if header == "" || !etagMatches(header, etag, true) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// requireIfMatch writes 428 Precondition Required and reports false when r
+// has no If-Match header.
+func requireIfMatch(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("If-Match") == "" {
+		http.Error(w, "If-Match header required, with the ETag of the resource", http.StatusPreconditionRequired)
+		return false
+	}
+	return true
+}
+
+// matchLocked reads the current ETag of a resource with lock, which locks its
+// row for the rest of the transaction of qtx, and compares it with the
+// If-Match header of r. It writes the error response itself and reports false when
+// the change cannot go ahead; notFound is the 404 message when there is no
+// row.
+func matchLocked(w http.ResponseWriter, r *http.Request, notFound string, qtx *sqlc.Queries, lock func(*sqlc.Queries) (string, error)) bool {
+	etag, err := lock(qtx)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, notFound, http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	if !etagMatches(r.Header.Get("If-Match"), etag, false) {
+		http.Error(w, "The resource has changed, fetch it again and retry with its ETag", http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
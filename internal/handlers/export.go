@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/tabular"
+)
+
+// exchangeEntity describes how an entity travels in exports and imports.
+// Rows are identified by natural keys rather than database IDs, so that data
+// can move between environments whose IDs differ: countries by ISO 3166-1
+// alpha-2 code, languages by ISO 639-1 code, variants by language, country
+// and tag, and the languages of a country by both codes.
+type exchangeEntity struct {
+	name     string
+	columns  []string
+	required []string
+	key      []string
+}
+
+// exchangeEntities are listed in dependency order: variants and country
+// languages refer to countries and languages.
+var exchangeEntities = []exchangeEntity{
+	{
+		name:     "country",
+		columns:  []string{"iso3166_2_a1", "iso3166_2_a3", "iso3166_numeric", "name", "official_state_name", "tld"},
+		required: []string{"iso3166_2_a1", "iso3166_2_a3", "name", "tld"},
+		key:      []string{"iso3166_2_a1"},
+	},
+	{
+		name:     "language",
+		columns:  []string{"iso_639_1", "iso_639_2", "name"},
+		required: []string{"iso_639_1", "iso_639_2", "name"},
+		key:      []string{"iso_639_1"},
+	},
+	{
+		name:     "variant",
+		columns:  []string{"language", "country", "variant_tag", "description"},
+		required: []string{"language", "variant_tag"},
+		key:      []string{"language", "country", "variant_tag"},
+	},
+	{
+		name:     "country_language",
+		columns:  []string{"country", "language"},
+		required: []string{"country", "language"},
+		key:      []string{"country", "language"},
+	},
+}
+
+func findExchangeEntity(name string) (exchangeEntity, bool) {
+	for _, e := range exchangeEntities {
+		if e.name == name {
+			return e, true
+		}
+	}
+	return exchangeEntity{}, false
+}
+
+// ExchangeKeys returns the natural key columns of each entity that GET
+// /export writes.
+func ExchangeKeys() map[string][]string {
+	keys := map[string][]string{}
+	for _, e := range exchangeEntities {
+		keys[e.name] = e.key
+	}
+	return keys
+}
+
+var exportFormats = []string{"csv", "jsonl", "xlsx"}
+
+// jsonlEntityKey is the field that names the entity of each JSON Lines
+// record.
+const jsonlEntityKey = "entity"
+
+// ExportHandler godoc
+//
+//	@Summary		Export reference data
+//	@Description	Streams countries, languages, variants and the languages of each country keyed by ISO codes rather than IDs, in the columns POST /import accepts.
+//	@Description	CSV exports of a single entity are a plain CSV file; several entities are zipped as one <entity>.csv file each. JSON Lines records name their entity in an "entity" field. XLSX workbooks have one sheet per entity.
+//	@Tags			Import and export
+//	@Produce		text/csv
+//	@Produce		application/zip
+//	@Produce		application/x-ndjson
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			entities	query		[]string	false	"Entities to export, all by default"	Enums(country, language, variant, country_language)
+//	@Param			format		query		string		false	"File format"							Enums(csv, jsonl, xlsx)	default(csv)
+//	@Success		200			{file}		file
+//	@Failure		400			{string}	string	"Invalid entities or format parameter"
+//	@Failure		405			{string}	string	"Method not allowed"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/export [get]
+func ExportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if !slices.Contains(exportFormats, format) {
+		http.Error(w, "Invalid format parameter", http.StatusBadRequest)
+		return
+	}
+
+	var entities []string
+	for _, v := range query["entities"] {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if _, ok := findExchangeEntity(name); !ok {
+				http.Error(w, "Invalid entities parameter", http.StatusBadRequest)
+				return
+			}
+			if !slices.Contains(entities, name) {
+				entities = append(entities, name)
+			}
+		}
+	}
+	if len(entities) == 0 {
+		for _, e := range exchangeEntities {
+			entities = append(entities, e.name)
+		}
+	}
+
+	tables, err := exportTables(r.Context(), entities)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	var filename, contentType string
+	var write func() error
+	switch {
+	case format == "csv" && len(tables) == 1:
+		filename, contentType = tables[0].Name+".csv", "text/csv; charset=utf-8"
+		write = func() error { return tabular.WriteCSV(w, tables[0]) }
+	case format == "csv":
+		filename, contentType = "export.zip", "application/zip"
+		write = func() error { return tabular.WriteCSVZip(w, tables...) }
+	case format == "jsonl":
+		filename, contentType = "export.jsonl", "application/x-ndjson"
+		write = func() error { return tabular.WriteJSONL(w, jsonlEntityKey, tables...) }
+	case format == "xlsx":
+		filename, contentType = "export.xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		write = func() error { return tabular.WriteXLSX(w, tables...) }
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if err := write(); err != nil {
+		// The response has started, so the client sees a truncated file.
+		log.Printf("export: %v", err)
+	}
+}
+
+// exportTables reads the entities from a single snapshot of the database,
+// so that every variant refers to an exported country and language.
+func exportTables(ctx context.Context, entities []string) ([]tabular.Table, error) {
+	tx, err := database.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := queries.WithTx(tx)
+
+	var tables []tabular.Table
+	for _, e := range exchangeEntities {
+		if !slices.Contains(entities, e.name) {
+			continue
+		}
+
+		t := tabular.Table{Name: e.name, Header: e.columns}
+		switch e.name {
+		case "country":
+			countries, err := qtx.GetAllCountries(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range countries {
+				t.Rows = append(t.Rows, []string{c.Iso31662A1, c.Iso31662A3, c.Iso3166Numeric.String, c.Name, c.OfficialStateName.String, c.Tld})
+			}
+		case "language":
+			languages, err := qtx.GetAllLanguageTags(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range languages {
+				t.Rows = append(t.Rows, []string{l.Iso6391, l.Iso6392, l.Name})
+			}
+		case "variant":
+			variants, err := qtx.GetVariantsForExport(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range variants {
+				t.Rows = append(t.Rows, []string{v.Iso6391.String, v.Iso31662A1.String, v.VariantTag, v.Description.String})
+			}
+		case "country_language":
+			links, err := qtx.GetCountryLanguagesForExport(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range links {
+				t.Rows = append(t.Rows, []string{l.Iso31662A1, l.Iso6391})
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables, tx.Commit()
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/hreflang"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+type HreflangRequest struct {
+	SiteID int32  `json:"site_id"`
+	Path   string `json:"path"`
+	Format string `json:"format" enums:"json,html,header" default:"json"`
+}
+
+type HreflangResponse struct {
+	Path       string          `json:"path"`
+	Alternates []hreflang.Link `json:"alternates"`
+}
+
+// HreflangHandler godoc
+//
+//	@Summary		Generate hreflang alternates
+//	@Description	Return the rel="alternate" hreflang set of a canonical path, including x-default, as JSON, HTML link tags or an HTTP Link header value.
+//	@Description	Every locale must be a valid language(-region) code whose language and country exist.
+//	@Tags			URLs
+//	@Accept			json
+//	@Produce		json
+//	@Produce		html
+//	@Produce		plain
+//	@Param			request	body		HreflangRequest		true	"Canonical path and output format"
+//	@Success		200		{object}	HreflangResponse	"Alternate links"
+//	@Failure		400		{string}	string				"Invalid input"
+//	@Failure		404		{string}	string				"Site not found"
+//	@Failure		405		{string}	string				"Method not allowed"
+//	@Failure		422		{string}	string				"Invalid hreflang values"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/hreflang [post]
+func HreflangHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input HreflangRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+	if input.Format == "" {
+		input.Format = "json"
+	}
+	if input.Format != "json" && input.Format != "html" && input.Format != "header" {
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	site, ok := siteForRequest(w, r, input.SiteID)
+	if !ok {
+		return
+	}
+
+	problems, err := validateHreflangLocales(r.Context(), site)
+	if err != nil {
+		http.Error(w, "Failed to load languages and countries", http.StatusInternalServerError)
+		return
+	}
+	if len(problems) > 0 {
+		http.Error(w, strings.Join(problems, "\n"), http.StatusUnprocessableEntity)
+		return
+	}
+
+	links, err := hreflang.Alternates(site, input.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch input.Format {
+	case "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, hreflang.HTML(links))
+	case "header":
+		header := hreflang.LinkHeader(links)
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Link", header)
+		fmt.Fprintln(w, header)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(HreflangResponse{Path: input.Path, Alternates: links}); err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+	}
+}
+
+// validateHreflangLocales checks every locale of site against the hreflang
+// syntax and the stored languages and countries, returning one message per
+// invalid locale.
+func validateHreflangLocales(ctx context.Context, site urlpattern.Site) ([]string, error) {
+	languages, err := queries.GetAllLanguageTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	countries, err := queries.GetAllCountries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	knownLanguages := map[string]bool{}
+	for _, l := range languages {
+		knownLanguages[strings.ToLower(strings.TrimSpace(l.Iso6391))] = true
+		knownLanguages[strings.ToLower(strings.TrimSpace(l.Iso6392))] = true
+	}
+	knownRegions := map[string]bool{}
+	for _, c := range countries {
+		knownRegions[strings.ToUpper(strings.TrimSpace(c.Iso31662A1))] = true
+	}
+
+	var problems []string
+	for _, l := range site.Locales {
+		tag, err := hreflang.Parse(l.Tag)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if !knownLanguages[tag.Language] {
+			problems = append(problems, fmt.Sprintf("invalid hreflang %q: unknown language %q", l.Tag, tag.Language))
+		}
+		if tag.Region != "" && !knownRegions[tag.Region] {
+			problems = append(problems, fmt.Sprintf("invalid hreflang %q: unknown region %q", l.Tag, tag.Region))
+		}
+	}
+
+	return problems, nil
+}
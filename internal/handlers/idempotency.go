@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+)
+
+const (
+	// maxIdempotencyKeyLength bounds the Idempotency-Key values accepted from
+	// clients.
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize bounds the create requests read into memory to be
+	// fingerprinted, which the largest bulk variant request fits in.
+	maxIdempotentBodySize = 8 << 20
+	// idempotencyClaimLease is how long a key stays claimed by a request that
+	// has not answered yet. It outlasts any request, and frees keys whose
+	// request never finished, e.g. because the server stopped.
+	idempotencyClaimLease = 5 * time.Minute
+)
+
+// idempotentCreatePaths are the collections whose POST creates a resource.
+// POST /api-keys is left out so that key secrets are never stored.
+var idempotentCreatePaths = map[string]bool{
+	"/country":          true,
+	"/language":         true,
+	"/language-variant": true,
+	"/locale-url":       true,
+	"/localized-name":   true,
+	"/redirects":        true,
+	"/site":             true,
+	"/slug":             true,
+}
+
+var idempotencyKeyTTL = config.GetIdempotencyKeyTTL()
+
+// Idempotency makes create requests sent with an Idempotency-Key header safe
+// to retry. The first response other than a server error is kept for
+// IDEMPOTENCY_KEY_TTL and replayed to retries with the same key and body,
+// marked by an Idempotent-Replayed header. Reusing the key with a different
+// request is refused with 422, and retrying while the first request is still
+// being handled with 409. Keys are scoped to the caller, so it has to run
+// after Authenticate. A key is released, to be retried, when the request
+// fails with a server error or a panic.
+func Idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" || r.Method != http.MethodPost || !idempotentCreatePaths[trimSlash(r.URL.Path)] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		principal := auditActor(ctx)
+		fingerprint := requestFingerprint(r, body)
+
+		claimed, err := queries.ClaimIdempotencyKey(ctx, sqlc.ClaimIdempotencyKeyParams{
+			Principal:   principal,
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   time.Now().Add(idempotencyClaimLease),
+		})
+		if err != nil {
+			http.Error(w, "Database query error", http.StatusInternalServerError)
+			return
+		}
+		if claimed == 0 {
+			replayIdempotent(ctx, w, principal, key, fingerprint)
+			return
+		}
+
+		defer func() {
+			if p := recover(); p != nil {
+				err := queries.ReleaseIdempotencyKey(context.WithoutCancel(ctx), sqlc.ReleaseIdempotencyKeyParams{Principal: principal, Key: key})
+				if err != nil {
+					log.Printf("idempotency: releasing key %q: %v", key, err)
+				}
+				panic(p)
+			}
+		}()
+
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		// The response is kept even when the client went away, since that is
+		// when it is most likely to retry.
+		ctx = context.WithoutCancel(ctx)
+		if rec.status >= http.StatusInternalServerError {
+			err = queries.ReleaseIdempotencyKey(ctx, sqlc.ReleaseIdempotencyKeyParams{Principal: principal, Key: key})
+		} else {
+			err = queries.SaveIdempotentResponse(ctx, sqlc.SaveIdempotentResponseParams{
+				Principal:    principal,
+				Key:          key,
+				StatusCode:   sql.NullInt32{Int32: int32(rec.status), Valid: true},
+				ContentType:  sql.NullString{String: rec.Header().Get("Content-Type"), Valid: true},
+				ResponseBody: rec.body.Bytes(),
+				ExpiresAt:    time.Now().Add(idempotencyKeyTTL),
+			})
+		}
+		if err != nil {
+			log.Printf("idempotency: recording response for key %q: %v", key, err)
+		}
+	})
+}
+
+// replayIdempotent answers a request whose key was already claimed.
+func replayIdempotent(ctx context.Context, w http.ResponseWriter, principal, key string, fingerprint []byte) {
+	stored, err := queries.GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{Principal: principal, Key: key})
+	if errors.Is(err, sql.ErrNoRows) {
+		// The first request failed and released the key in the meantime.
+		http.Error(w, "A request with this Idempotency-Key failed, retry it", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	if !bytes.Equal(stored.Fingerprint, fingerprint) {
+		http.Error(w, "Idempotency-Key was already used with a different request", http.StatusUnprocessableEntity)
+		return
+	}
+	if !stored.StatusCode.Valid {
+		http.Error(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+		return
+	}
+
+	if stored.ContentType.String != "" {
+		w.Header().Set("Content-Type", stored.ContentType.String)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(int(stored.StatusCode.Int32))
+	w.Write(stored.ResponseBody)
+}
+
+// requestFingerprint identifies what a request asks for, so that a key reused
+// for another request is told apart from a retry.
+func requestFingerprint(r *http.Request, body []byte) []byte {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+func trimSlash(path string) string {
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		return path[:len(path)-1]
+	}
+	return path
+}
+
+// responseRecorder passes a response through while keeping a copy of its
+// status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// PurgeExpiredIdempotencyKeys removes the responses that are no longer
+// replayed and returns how many there were.
+func PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return queries.DeleteExpiredIdempotencyKeys(ctx)
+}
@@ -0,0 +1,736 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/tabular"
+)
+
+const (
+	maxImportSize         = 32 << 20
+	maxImportErrorsPerRun = 1000
+)
+
+type ImportRowError struct {
+	// Row is the 1-based position of the row among the entity's data rows,
+	// not counting the header or blank rows.
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportEntityReport struct {
+	Entity    string           `json:"entity"`
+	Rows      int              `json:"rows"`
+	Inserted  int              `json:"inserted"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+type ImportReport struct {
+	DryRun  bool `json:"dry_run"`
+	Applied bool `json:"applied"`
+	// ErrorCount counts every error found; at most 1000 are listed.
+	ErrorCount int                  `json:"error_count"`
+	Entities   []ImportEntityReport `json:"entities"`
+}
+
+// ImportHandler godoc
+//
+//	@Summary		Import reference data
+//	@Description	Creates or updates countries, languages, variants and the languages of each country from a file in any format GET /export produces. Rows are matched to existing data by ISO codes: countries by iso3166_2_a1, languages by iso_639_1, variants by language, country and variant_tag, and country languages by country and language. Nothing is deleted.
+//	@Description	Every row is validated before anything is written, and the import is applied in a single transaction only if all rows are valid. With dry_run=true the report of what would change is returned without writing.
+//	@Description	mapping renames file columns to import columns, either for all entities, e.g. {"Code": "iso3166_2_a1"}, or per entity, e.g. {"country": {"Code": "iso3166_2_a1"}}. Mapping a column to "" ignores it. Columns that are neither known nor ignored are rejected.
+//	@Description	Optional columns absent from the file are left unchanged on existing rows.
+//	@Tags			Import and export
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"CSV, zipped CSV, JSON Lines or XLSX file"
+//	@Param			format	formData	string	false	"File format, inferred from the file name by default"	Enums(csv, jsonl, xlsx)
+//	@Param			entity	formData	string	false	"Entity of a single CSV file or of JSON Lines records without an entity field, inferred from the file name by default"	Enums(country, language, variant, country_language)
+//	@Param			mapping	formData	string	false	"JSON column mapping"
+//	@Param			dry_run	formData	bool	false	"Validate and report without writing"
+//	@Success		200		{object}	ImportReport
+//	@Failure		400		{string}	string	"Invalid file, format, entity or mapping"
+//	@Failure		405		{string}	string	"Method not allowed"
+//	@Failure		413		{string}	string	"File too large"
+//	@Failure		422		{object}	ImportReport
+//	@Failure		500		{string}	string	"Database error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/import [post]
+func ImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "File too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusBadRequest)
+		return
+	}
+
+	mapping, err := parseImportMapping(r.FormValue("mapping"))
+	if err != nil {
+		http.Error(w, "Invalid mapping: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tables, err := readImportTables(data, fileHeader.Filename, r.FormValue("format"), r.FormValue("entity"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tables, err = mapImportTables(tables, mapping)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.FormValue("dry_run") == "true"
+
+	report, err := importTables(r.Context(), tables, dryRun)
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if report.ErrorCount > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// readImportTables decodes an uploaded file. Tables that do not name their
+// entity themselves, as a lone CSV file does, are named after entity or else
+// after the file.
+func readImportTables(data []byte, filename, format, entity string) ([]tabular.Table, error) {
+	ext := strings.ToLower(path.Ext(filename))
+	if format == "" {
+		switch ext {
+		case ".csv", ".zip":
+			format = "csv"
+		case ".jsonl", ".ndjson":
+			format = "jsonl"
+		case ".xlsx":
+			format = "xlsx"
+		default:
+			return nil, errors.New("Cannot infer the format from the file name, set format")
+		}
+	}
+
+	name := entity
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	}
+
+	var tables []tabular.Table
+	var err error
+	switch format {
+	case "csv":
+		if tabular.IsZip(data) {
+			tables, err = tabular.ReadCSVZip(bytes.NewReader(data), int64(len(data)))
+		} else {
+			var t tabular.Table
+			t, err = tabular.ReadCSV(bytes.NewReader(data), name)
+			tables = []tabular.Table{t}
+		}
+	case "jsonl":
+		tables, err = tabular.ReadJSONL(bytes.NewReader(data), jsonlEntityKey, name)
+	case "xlsx":
+		tables, err = tabular.ReadXLSX(bytes.NewReader(data), int64(len(data)))
+	default:
+		return nil, errors.New("Invalid format")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s file: %v", format, err)
+	}
+	if len(tables) == 0 {
+		return nil, errors.New("The file contains no data")
+	}
+	return tables, nil
+}
+
+// importMapping maps file columns to import columns per entity; the entry
+// for "" applies to every entity.
+type importMapping map[string]map[string]string
+
+func parseImportMapping(raw string) (importMapping, error) {
+	mapping := importMapping{}
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return nil, errors.New("expected a JSON object")
+	}
+	for key, value := range fields {
+		var column string
+		if err := json.Unmarshal(value, &column); err == nil {
+			if mapping[""] == nil {
+				mapping[""] = map[string]string{}
+			}
+			mapping[""][key] = column
+			continue
+		}
+
+		if _, ok := findExchangeEntity(key); !ok {
+			return nil, fmt.Errorf("%q is neither a column mapped to a string nor an entity", key)
+		}
+		var columns map[string]string
+		if err := json.Unmarshal(value, &columns); err != nil {
+			return nil, fmt.Errorf("the mapping of %s must map columns to strings", key)
+		}
+		mapping[key] = columns
+	}
+	return mapping, nil
+}
+
+// mapImportTables renames the columns of each table according to mapping,
+// drops ignored columns, and checks that the result has the columns its
+// entity requires and no others.
+func mapImportTables(tables []tabular.Table, mapping importMapping) ([]tabular.Table, error) {
+	var mapped []tabular.Table
+	seen := map[string]bool{}
+
+	for _, t := range tables {
+		name := strings.ToLower(strings.TrimSpace(t.Name))
+		entity, ok := findExchangeEntity(name)
+		if !ok {
+			return nil, fmt.Errorf("Unknown entity %q, set entity or name the file, sheet or records after country, language, variant or country_language", t.Name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("Entity %s appears more than once", name)
+		}
+		seen[name] = true
+
+		var header []string
+		var keep []int
+		for i, column := range t.Header {
+			target, ok := mapping[name][column]
+			if !ok {
+				target, ok = mapping[""][column]
+			}
+			if !ok {
+				target = column
+			}
+			if target == "" {
+				continue
+			}
+			if !slices.Contains(entity.columns, target) {
+				return nil, fmt.Errorf("Unknown %s column %q, expected %s", name, column, strings.Join(entity.columns, ", "))
+			}
+			if slices.Contains(header, target) {
+				return nil, fmt.Errorf("More than one %s column maps to %s", name, target)
+			}
+			header = append(header, target)
+			keep = append(keep, i)
+		}
+		for _, column := range entity.required {
+			if !slices.Contains(header, column) {
+				return nil, fmt.Errorf("Missing %s column %s", name, column)
+			}
+		}
+
+		m := tabular.Table{Name: name, Header: header}
+		for _, row := range t.Rows {
+			values := make([]string, len(keep))
+			for j, i := range keep {
+				if i < len(row) {
+					values[j] = strings.TrimSpace(row[i])
+				}
+			}
+			m.Rows = append(m.Rows, values)
+		}
+		mapped = append(mapped, m)
+	}
+
+	slices.SortFunc(mapped, func(a, b tabular.Table) int {
+		return exchangeEntityIndex(a.Name) - exchangeEntityIndex(b.Name)
+	})
+	return mapped, nil
+}
+
+func exchangeEntityIndex(name string) int {
+	return slices.IndexFunc(exchangeEntities, func(e exchangeEntity) bool { return e.name == name })
+}
+
+// importRun validates tables against the database and collects the writes
+// that apply them. Writes run in dependency order, and the IDs of inserted
+// countries and languages are recorded for the variants and country
+// languages that follow.
+type importRun struct {
+	report ImportReport
+	writes []func(ctx context.Context, q *sqlc.Queries) error
+
+	countries map[string]sqlc.GetAllCountriesRow
+	languages map[string]sqlc.Language
+	variants  map[variantKey]sqlc.GetVariantsForExportRow
+	links     map[linkKey]bool
+
+	countryIDs  map[string]int32
+	languageIDs map[string]int32
+
+	// newCountries and newLanguages are the codes the run inserts, which
+	// variants and country languages may refer to before they have IDs.
+	newCountries map[string]bool
+	newLanguages map[string]bool
+}
+
+type variantKey struct {
+	language, country, tag string
+}
+
+type linkKey struct {
+	country, language string
+}
+
+// importTables validates every row and, unless dryRun is set or a row is
+// invalid, applies the tables in a single transaction.
+func importTables(ctx context.Context, tables []tabular.Table, dryRun bool) (ImportReport, error) {
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	defer tx.Rollback()
+
+	run, err := newImportRun(ctx, qtx)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	run.report.DryRun = dryRun
+
+	for _, t := range tables {
+		entity := ImportEntityReport{Entity: t.Name, Rows: len(t.Rows), Errors: []ImportRowError{}}
+		switch t.Name {
+		case "country":
+			run.planCountries(t, &entity)
+		case "language":
+			run.planLanguages(t, &entity)
+		case "variant":
+			run.planVariants(t, &entity)
+		case "country_language":
+			run.planCountryLanguages(t, &entity)
+		}
+		run.report.Entities = append(run.report.Entities, entity)
+	}
+
+	if dryRun || run.report.ErrorCount > 0 {
+		return run.report, nil
+	}
+
+	for _, write := range run.writes {
+		if err := write(ctx, qtx); err != nil {
+			return ImportReport{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return ImportReport{}, err
+	}
+	run.report.Applied = true
+	return run.report, nil
+}
+
+func newImportRun(ctx context.Context, q *sqlc.Queries) (*importRun, error) {
+	run := &importRun{
+		countries:    map[string]sqlc.GetAllCountriesRow{},
+		languages:    map[string]sqlc.Language{},
+		variants:     map[variantKey]sqlc.GetVariantsForExportRow{},
+		links:        map[linkKey]bool{},
+		countryIDs:   map[string]int32{},
+		languageIDs:  map[string]int32{},
+		newCountries: map[string]bool{},
+		newLanguages: map[string]bool{},
+	}
+
+	countries, err := q.GetAllCountries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range countries {
+		code := strings.ToUpper(c.Iso31662A1)
+		if _, ok := run.countries[code]; !ok {
+			run.countries[code] = c
+			run.countryIDs[code] = c.ID
+		}
+	}
+
+	languages, err := q.GetAllLanguageTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range languages {
+		code := strings.ToLower(l.Iso6391)
+		if _, ok := run.languages[code]; !ok {
+			run.languages[code] = l
+			run.languageIDs[code] = l.ID
+		}
+	}
+
+	variants, err := q.GetVariantsForExport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range variants {
+		key := variantKey{strings.ToLower(v.Iso6391.String), strings.ToUpper(v.Iso31662A1.String), v.VariantTag}
+		if _, ok := run.variants[key]; !ok {
+			run.variants[key] = v
+		}
+	}
+
+	links, err := q.GetCountryLanguagesForExport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range links {
+		run.links[linkKey{strings.ToUpper(l.Iso31662A1), strings.ToLower(l.Iso6391)}] = true
+	}
+	return run, nil
+}
+
+func (run *importRun) fail(entity *ImportEntityReport, row int, column, message string) {
+	run.report.ErrorCount++
+	if run.report.ErrorCount <= maxImportErrorsPerRun {
+		entity.Errors = append(entity.Errors, ImportRowError{Row: row + 1, Column: column, Message: message})
+	}
+}
+
+// checkCode records an error unless value is exactly n ASCII letters.
+func (run *importRun) checkCode(entity *ImportEntityReport, row int, column, value string, n int) bool {
+	valid := len(value) == n
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			valid = false
+		}
+	}
+	if !valid {
+		run.fail(entity, row, column, fmt.Sprintf("must be %d letters", n))
+	}
+	return valid
+}
+
+func isNumericCode(value string) bool {
+	if len(value) != 3 {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkText records an error if value is empty but required, or longer than
+// max characters.
+func (run *importRun) checkText(entity *ImportEntityReport, row int, column, value string, required bool, max int) bool {
+	switch {
+	case required && value == "":
+		run.fail(entity, row, column, "is required")
+		return false
+	case utf8.RuneCountInString(value) > max:
+		run.fail(entity, row, column, fmt.Sprintf("must be at most %d characters", max))
+		return false
+	}
+	return true
+}
+
+func (run *importRun) planCountries(t tabular.Table, entity *ImportEntityReport) {
+	hasNumeric := t.Index("iso3166_numeric") >= 0
+	hasOfficialName := t.Index("official_state_name") >= 0
+	seen := map[string]int{}
+
+	for i := range t.Rows {
+		code := strings.ToUpper(t.Get(i, "iso3166_2_a1"))
+		alpha3 := strings.ToUpper(t.Get(i, "iso3166_2_a3"))
+		numeric := t.Get(i, "iso3166_numeric")
+		name := t.Get(i, "name")
+		officialName := t.Get(i, "official_state_name")
+		tld := t.Get(i, "tld")
+
+		valid := run.checkCode(entity, i, "iso3166_2_a1", code, 2)
+		valid = run.checkCode(entity, i, "iso3166_2_a3", alpha3, 3) && valid
+		if numeric != "" && !isNumericCode(numeric) {
+			run.fail(entity, i, "iso3166_numeric", "must be 3 digits")
+			valid = false
+		}
+		valid = run.checkText(entity, i, "name", name, true, 100) && valid
+		valid = run.checkText(entity, i, "official_state_name", officialName, false, 100) && valid
+		valid = run.checkText(entity, i, "tld", tld, true, 3) && valid
+		if first, ok := seen[code]; ok && code != "" {
+			run.fail(entity, i, "iso3166_2_a1", fmt.Sprintf("duplicates row %d", first+1))
+			valid = false
+		}
+		if _, ok := seen[code]; !ok {
+			seen[code] = i
+		}
+		if !valid {
+			continue
+		}
+
+		existing, ok := run.countries[code]
+		if !ok {
+			entity.Inserted++
+			run.newCountries[code] = true
+			params := sqlc.InsertCountryParams{
+				Name:              name,
+				OfficialStateName: sql.NullString{String: officialName, Valid: officialName != ""},
+				Tld:               tld,
+				Iso31662A1:        code,
+				Iso31662A3:        alpha3,
+				Iso3166Numeric:    sql.NullString{String: numeric, Valid: numeric != ""},
+			}
+			run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+				now := time.Now()
+				params.CreatedAt, params.UpdatedAt = now, now
+				id, err := q.InsertCountry(ctx, params)
+				run.countryIDs[code] = id
+				return err
+			})
+			continue
+		}
+
+		official := existing.OfficialStateName
+		if hasOfficialName {
+			official = sql.NullString{String: officialName, Valid: officialName != ""}
+		}
+		numericCode := existing.Iso3166Numeric
+		if hasNumeric {
+			numericCode = sql.NullString{String: numeric, Valid: numeric != ""}
+		}
+		if existing.Name == name && existing.Tld == tld && existing.Iso31662A3 == alpha3 &&
+			official.String == existing.OfficialStateName.String && numericCode.String == existing.Iso3166Numeric.String {
+			entity.Unchanged++
+			continue
+		}
+
+		entity.Updated++
+		params := sqlc.UpdateCountryParams{
+			ID:                existing.ID,
+			Name:              name,
+			OfficialStateName: official,
+			Tld:               tld,
+			Iso31662A3:        alpha3,
+			Iso3166Numeric:    numericCode,
+		}
+		run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+			return q.UpdateCountry(ctx, params)
+		})
+	}
+}
+
+func (run *importRun) planLanguages(t tabular.Table, entity *ImportEntityReport) {
+	seen := map[string]int{}
+
+	for i := range t.Rows {
+		code := strings.ToLower(t.Get(i, "iso_639_1"))
+		alpha3 := strings.ToLower(t.Get(i, "iso_639_2"))
+		name := t.Get(i, "name")
+
+		valid := run.checkCode(entity, i, "iso_639_1", code, 2)
+		valid = run.checkCode(entity, i, "iso_639_2", alpha3, 3) && valid
+		valid = run.checkText(entity, i, "name", name, true, 255) && valid
+		if first, ok := seen[code]; ok && code != "" {
+			run.fail(entity, i, "iso_639_1", fmt.Sprintf("duplicates row %d", first+1))
+			valid = false
+		}
+		if _, ok := seen[code]; !ok {
+			seen[code] = i
+		}
+		if !valid {
+			continue
+		}
+
+		existing, ok := run.languages[code]
+		if !ok {
+			entity.Inserted++
+			run.newLanguages[code] = true
+			params := sqlc.InsertLanguageTagParams{Name: name, Iso6391: code, Iso6392: alpha3}
+			run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+				id, err := q.InsertLanguageTag(ctx, params)
+				run.languageIDs[code] = id
+				return err
+			})
+			continue
+		}
+
+		if existing.Name == name && existing.Iso6392 == alpha3 {
+			entity.Unchanged++
+			continue
+		}
+
+		entity.Updated++
+		params := sqlc.UpdateLanguageTagParams{ID: existing.ID, Name: name, Iso6392: alpha3}
+		run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+			return q.UpdateLanguageTag(ctx, params)
+		})
+	}
+}
+
+// planVariants runs after planCountries and planLanguages, so a variant may
+// refer to a country or language created by the same import.
+func (run *importRun) planVariants(t tabular.Table, entity *ImportEntityReport) {
+	hasDescription := t.Index("description") >= 0
+	seen := map[variantKey]int{}
+
+	for i := range t.Rows {
+		key := variantKey{
+			language: strings.ToLower(t.Get(i, "language")),
+			country:  strings.ToUpper(t.Get(i, "country")),
+			tag:      t.Get(i, "variant_tag"),
+		}
+		description := t.Get(i, "description")
+
+		valid := true
+		if _, ok := run.languages[key.language]; !ok && !run.newLanguages[key.language] {
+			run.fail(entity, i, "language", fmt.Sprintf("unknown language %q", key.language))
+			valid = false
+		}
+		if _, ok := run.countries[key.country]; !ok && key.country != "" && !run.newCountries[key.country] {
+			run.fail(entity, i, "country", fmt.Sprintf("unknown country %q", key.country))
+			valid = false
+		}
+		valid = run.checkText(entity, i, "variant_tag", key.tag, true, 255) && valid
+		if first, ok := seen[key]; ok {
+			run.fail(entity, i, "variant_tag", fmt.Sprintf("duplicates row %d", first+1))
+			valid = false
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = i
+		}
+		if !valid {
+			continue
+		}
+
+		existing, ok := run.variants[key]
+		if !ok {
+			entity.Inserted++
+			run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+				now := time.Now()
+				_, err := q.InsertVariant(ctx, sqlc.InsertVariantParams{
+					LanguageID:  sql.NullInt32{Int32: run.languageIDs[key.language], Valid: true},
+					CountryID:   run.countryID(key.country),
+					VariantTag:  key.tag,
+					Description: sql.NullString{String: description, Valid: description != ""},
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				})
+				return err
+			})
+			continue
+		}
+
+		if !hasDescription || existing.Description.String == description {
+			entity.Unchanged++
+			continue
+		}
+
+		entity.Updated++
+		run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+			_, err := q.UpdateVariant(ctx, sqlc.UpdateVariantParams{
+				ID:          existing.ID,
+				LanguageID:  sql.NullInt32{Int32: run.languageIDs[key.language], Valid: true},
+				CountryID:   run.countryID(key.country),
+				VariantTag:  key.tag,
+				Description: sql.NullString{String: description, Valid: description != ""},
+				UpdatedAt:   time.Now(),
+			})
+			return err
+		})
+	}
+}
+
+// planCountryLanguages runs after planCountries and planLanguages, so a
+// country may be linked to a language created by the same import.
+func (run *importRun) planCountryLanguages(t tabular.Table, entity *ImportEntityReport) {
+	seen := map[linkKey]int{}
+
+	for i := range t.Rows {
+		key := linkKey{
+			country:  strings.ToUpper(t.Get(i, "country")),
+			language: strings.ToLower(t.Get(i, "language")),
+		}
+
+		valid := true
+		if _, ok := run.countries[key.country]; !ok && !run.newCountries[key.country] {
+			run.fail(entity, i, "country", fmt.Sprintf("unknown country %q", key.country))
+			valid = false
+		}
+		if _, ok := run.languages[key.language]; !ok && !run.newLanguages[key.language] {
+			run.fail(entity, i, "language", fmt.Sprintf("unknown language %q", key.language))
+			valid = false
+		}
+		if first, ok := seen[key]; ok {
+			run.fail(entity, i, "language", fmt.Sprintf("duplicates row %d", first+1))
+			valid = false
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = i
+		}
+		if !valid {
+			continue
+		}
+
+		if run.links[key] {
+			entity.Unchanged++
+			continue
+		}
+
+		entity.Inserted++
+		run.writes = append(run.writes, func(ctx context.Context, q *sqlc.Queries) error {
+			return q.InsertCountryLanguage(ctx, sqlc.InsertCountryLanguageParams{
+				CountryID:  run.countryIDs[key.country],
+				LanguageID: run.languageIDs[key.language],
+			})
+		})
+	}
+}
+
+// countryID returns the ID of the country with code, null for "".
+func (run *importRun) countryID(code string) sql.NullInt32 {
+	if code == "" {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: run.countryIDs[code], Valid: true}
+}
@@ -3,12 +3,15 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
 	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
 )
 
 var database = config.GetDB()
@@ -19,7 +22,48 @@ type LanguageTagGetAllResponse struct {
 	Name          string `json:"name"`
 	ISO639_1      string `json:"iso_639_1"`
 	ISO639_2      string `json:"iso_639_2"`
+	Version       int32  `json:"version"`
 	VariantsCount int32  `json:"variants_count"`
+	// CountriesCount and LocalesCount are only set when requested with
+	// include_counts.
+	CountriesCount *int32 `json:"countries_count,omitempty"`
+	LocalesCount   *int32 `json:"locales_count,omitempty"`
+	// DeletedAt is set on deleted language tags, which lists only return
+	// with include_deleted=true.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Status is active, deprecated or retired. Lists only return retired
+	// language tags with include_retired=true.
+	Status       string     `json:"status"`
+	DeprecatedAt *time.Time `json:"deprecated_at,omitempty"`
+	ReplacedBy   *int32     `json:"replaced_by,omitempty"`
+}
+
+type PaginatedLanguageTagsResponse struct {
+	Languages     []LanguageTagGetAllResponse `json:"languages"`
+	NextPageToken string                      `json:"next_page_token,omitempty"`
+	PrevPageToken string                      `json:"prev_page_token,omitempty"`
+	TotalCount    *int64                      `json:"total_count,omitempty"`
+}
+
+var languageResource = listquery.Resource{
+	From: "language",
+	Key:  "id",
+	Columns: append([]listquery.Column{
+		{Name: "id", SQL: "id", Type: listquery.Int},
+		{Name: "name", SQL: "name", Type: listquery.String},
+		{Name: "iso_639_1", SQL: "iso_639_1", Type: listquery.String},
+		{Name: "iso_639_2", SQL: "iso_639_2", Type: listquery.String},
+		{Name: "created_at", SQL: "created_at", Type: listquery.Time},
+		{Name: "updated_at", SQL: "updated_at", Type: listquery.Time},
+		{Name: "version", SQL: "version", Type: listquery.Int},
+		deletedAtColumn,
+	}, lifecycleColumns...),
+	Params: []string{"include_counts", "as_of", "include_deleted", "include_retired"},
+}
+
+func languageFields(l *sqlc.Language) []any {
+	return []any{&l.ID, &l.Name, &l.Iso6391, &l.Iso6392, &l.CreatedAt, &l.UpdatedAt, &l.Version, &l.DeletedAt,
+		&l.Status, &l.DeprecatedAt, &l.ReplacedBy}
 }
 
 type LanguageTagResponse struct {
@@ -27,6 +71,13 @@ type LanguageTagResponse struct {
 	Name     string `json:"name"`
 	ISO639_1 string `json:"iso_639_1"`
 	ISO639_2 string `json:"iso_639_2"`
+	Version  int32  `json:"version"`
+	// DeletedAt is set on deleted language tags.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Status is active, deprecated or retired.
+	Status       string     `json:"status"`
+	DeprecatedAt *time.Time `json:"deprecated_at,omitempty"`
+	ReplacedBy   *int32     `json:"replaced_by,omitempty"`
 }
 
 type LanguageTagBody struct {
@@ -46,9 +97,42 @@ type LanguageTagBody struct {
 //	@Success		200	{object}	LanguageTagResponse	"Language Tag with variants"
 //	@Failure		400	{string}	string				"Invalid item ID"
 //	@Failure		405	{string}	string				"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language/{id} [get]
 //	@Router			/language [post]
 func LanguageTagHandler(w http.ResponseWriter, r *http.Request) {
+	if idStr, action, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/language/"), "/"); ok {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid item ID", http.StatusBadRequest)
+			return
+		}
+		if action == "variants" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			getLanguageVariants(w, r, int32(id))
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		switch action {
+		case "revert":
+			revertLanguageTag(w, r, int32(id))
+		case "restore":
+			restoreLanguageTag(w, r, int32(id))
+		case "status":
+			setLanguageTagStatus(w, r, int32(id))
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		path := r.URL.Path
@@ -69,6 +153,13 @@ func LanguageTagHandler(w http.ResponseWriter, r *http.Request) {
 		getLanguageTagByID(w, r, int32(id))
 	case http.MethodPost:
 		postLanguageTag(w, r)
+	case http.MethodDelete:
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/language/"))
+		if err != nil {
+			http.Error(w, "Invalid item ID", http.StatusBadRequest)
+			return
+		}
+		deleteLanguageTag(w, r, int32(id))
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
@@ -77,45 +168,146 @@ func LanguageTagHandler(w http.ResponseWriter, r *http.Request) {
 // getAllLanguageTags godoc
 //
 //	@Summary		Get all language tags
-//	@Description	Retrieve all language tags with their associated variants
+//	@Description	Retrieve a page of language tags with their variant counts. Any column can be filtered with col=v (repeat for any of several values), col~=v (case-insensitive substring), col!=v, col>=v and col<=v, or with filter expressions such as "iso_639_1 in (en, fr)". Columns: id, name, iso_639_1, iso_639_2, created_at, updated_at. Looking a code up with iso_639_1=v or iso_639_2=v returns retired language tags too, and when a single one matches and it is deprecated or retired, the response has a Deprecation header and a successor-version Link to its replacement.
 //	@Tags			Language tags
 //	@Produce		json
-//	@Success		200	{array}		LanguageTagGetAllResponse	"List of Language Tags with variants"
-//	@Failure		500	{string}	string						"Failed to get language tags"
+//	@Param			sort				query		string		false	"Comma-separated columns, prefixed with - for descending order"	example(name,-created_at)
+//	@Param			filter				query		[]string	false	"Filter expressions"											collectionFormat(multi)
+//	@Param			page_size			query		int			false	"Limit of items per page"										default(10)	maximum(100)
+//	@Param			page_token			query		string		false	"Cursor of the page to fetch"
+//	@Param			include_total_count	query		bool		false	"Include the total number of matching language tags"
+//	@Param			include_counts		query		[]string	false	"Extra per-language counts to include"	Enums(countries, locales)
+//	@Param			as_of				query		string		false	"List the language tags and their variant counts as they were at this RFC 3339 time or date"	example(2026-01-01)
+//	@Param			include_deleted		query		bool		false	"Include deleted language tags"
+//	@Param			include_retired		query		bool		false	"Include retired language tags"
+//	@Success		200					{object}	PaginatedLanguageTagsResponse	"Page of Language Tags with variant counts"
+//	@Header			200					{string}	Deprecation						"On a code lookup matching a deprecated or retired language tag, when it was deprecated, as @ and a Unix time"
+//	@Header			200					{string}	Link							"On a code lookup, successor-version link to the language tag replacing the one found"
+//	@Failure		400					{string}	string							"Invalid sort, filter, include_counts, as_of, include_deleted, include_retired or page_token parameter"
+//	@Failure		500					{string}	string							"Failed to get language tags"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language [get]
 func getAllLanguageTags(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	languageTags, err := queries.GetAllLanguageTags(ctx)
+	q, err := languageResource.Parse(r.URL.Query())
 	if err != nil {
-		http.Error(w, "Failed to get language tags", http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	var result []LanguageTagGetAllResponse
-	for _, tag := range languageTags {
-		tagIDNull := sql.NullInt32{
-			Int32: int32(tag.ID),
-			Valid: tag.ID != 0,
-		}
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		http.Error(w, "Invalid include_deleted parameter", http.StatusBadRequest)
+		return
+	}
+	if !includeDeleted {
+		q.Where = append(q.Where, notDeleted)
+	}
 
-		variantCount, err := queries.GetVariantCount(ctx, tagIDNull)
-		if err != nil {
-			http.Error(w, "Failed to get variants for language tag", http.StatusInternalServerError)
-			return
+	includeRetired, err := parseIncludeRetired(r)
+	if err != nil {
+		http.Error(w, "Invalid include_retired parameter", http.StatusBadRequest)
+		return
+	}
+	lookup := isCodeLookup(q, "iso_639_1", "iso_639_2")
+	if !includeRetired && !lookup {
+		q.Where = append(q.Where, notRetired)
+	}
+
+	// Only variants are versioned with languages; country and locale counts
+	// are always current.
+	res := languageResource
+	var countsAsOf sql.NullTime
+	if asOf, ok, err := parseAsOf(r); err != nil {
+		http.Error(w, "Invalid as_of parameter", http.StatusBadRequest)
+		return
+	} else if ok {
+		res = asOfResource(res, asOf)
+		countsAsOf = sql.NullTime{Time: asOf, Valid: true}
+	}
+
+	var withCountries, withLocales bool
+	for _, v := range r.URL.Query()["include_counts"] {
+		for _, count := range strings.Split(v, ",") {
+			switch strings.TrimSpace(count) {
+			case "countries":
+				withCountries = true
+			case "locales":
+				withLocales = true
+			default:
+				http.Error(w, "Invalid include_counts parameter", http.StatusBadRequest)
+				return
+			}
 		}
+	}
+
+	languageTags, page, ok := fetchPage(w, r, res, q, languageFields)
+	if !ok {
+		return
+	}
+
+	ids := make([]int32, len(languageTags))
+	for i, tag := range languageTags {
+		ids[i] = tag.ID
+	}
+
+	counts, err := queries.GetLanguageCounts(ctx, sqlc.GetLanguageCountsParams{
+		Ids:           ids,
+		AsOf:          countsAsOf,
+		WithCountries: withCountries,
+		WithLocales:   withLocales,
+	})
+	if err != nil {
+		http.Error(w, "Failed to get variants for language tag", http.StatusInternalServerError)
+		return
+	}
+
+	countsByID := make(map[int32]sqlc.GetLanguageCountsRow, len(counts))
+	for _, c := range counts {
+		countsByID[c.ID] = c
+	}
+
+	response := PaginatedLanguageTagsResponse{
+		Languages:     []LanguageTagGetAllResponse{},
+		NextPageToken: page.NextPageToken,
+		PrevPageToken: page.PrevPageToken,
+		TotalCount:    page.TotalCount,
+	}
+	for _, tag := range languageTags {
+		c := countsByID[tag.ID]
 
-		result = append(result, LanguageTagGetAllResponse{
+		item := LanguageTagGetAllResponse{
 			ID:            tag.ID,
 			ISO639_1:      tag.Iso6391,
 			Name:          tag.Name,
 			ISO639_2:      tag.Iso6392,
-			VariantsCount: int32(variantCount),
-		})
+			Version:       tag.Version,
+			VariantsCount: int32(c.VariantCount),
+			DeletedAt:     coalescedTime(tag.DeletedAt.Time),
+			Status:        tag.Status,
+			DeprecatedAt:  coalescedTime(tag.DeprecatedAt.Time),
+			ReplacedBy:    coalescedID(tag.ReplacedBy.Int32),
+		}
+		if withCountries {
+			countriesCount := int32(c.CountryCount)
+			item.CountriesCount = &countriesCount
+		}
+		if withLocales {
+			localesCount := int32(c.LocaleCount)
+			item.LocalesCount = &localesCount
+		}
+		response.Languages = append(response.Languages, item)
+	}
+
+	if lookup && len(languageTags) == 1 {
+		tag := languageTags[0]
+		setDeprecationHeaders(w, tag.Status, tag.DeprecatedAt, tag.ReplacedBy, "/language/")
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
@@ -123,28 +315,38 @@ func getAllLanguageTags(w http.ResponseWriter, r *http.Request) {
 // getLanguageTagByID godoc
 //
 //	@Summary		Get language tag by ID
-//	@Description	Retrieve a specific language tag and its variants by ID
+//	@Description	Retrieve a specific language tag and its variants by ID. Deleted language tags are returned too, with their deleted_at. Deprecated and retired language tags come with a Deprecation header and a successor-version Link to their replacement.
 //	@Tags			Language tags
 //	@Produce		json
-//	@Param			id	path		int					true	"Language Tag ID"
-//	@Success		200	{object}	LanguageTagResponse	"Language Tag with variants"
-//	@Failure		404	{string}	string				"Language tag not found"
-//	@Failure		500	{string}	string				"Failed to get variants"
+//	@Param			id				path		int					true	"Language Tag ID"
+//	@Param			as_of			query		string				false	"Get the language tag as it was at this RFC 3339 time or date"
+//	@Param			If-None-Match	header		string				false	"ETag of a copy of the language tag, to get 304 if it has not changed"
+//	@Success		200				{object}	LanguageTagResponse	"Language Tag with variants"
+//	@Success		304				{string}	string				"Not modified"
+//	@Header			200				{string}	ETag				"Version of the language tag, for If-Match and If-None-Match"
+//	@Header			200				{string}	Deprecation			"When a deprecated or retired language tag was deprecated, as @ and a Unix time"
+//	@Header			200				{string}	Link				"successor-version link to the language tag replacing it"
+//	@Failure		400				{string}	string				"Invalid item ID or as_of"
+//	@Failure		404				{string}	string				"Language tag not found"
+//	@Failure		500				{string}	string				"Failed to get variants"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language/{id} [get]
 func getLanguageTagByID(w http.ResponseWriter, r *http.Request, id int32) {
-	ctx := r.Context()
-
-	tag, err := queries.GetLanguageTagByID(ctx, id)
+	tag, err := getLanguageTag(r, id)
+	if errors.Is(err, errInvalidAsOf) {
+		http.Error(w, "Invalid as_of parameter", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Language tag not found", http.StatusNotFound)
 		return
 	}
 
-	result := LanguageTagResponse{
-		ID:       tag.ID,
-		Name:     tag.Name,
-		ISO639_1: tag.Iso6392,
-		ISO639_2: tag.Iso6391,
+	result := toLanguageTagResponse(tag)
+	setDeprecationHeaders(w, tag.Status, tag.DeprecatedAt, tag.ReplacedBy, "/language/")
+	if notModified(w, r, versionETag(tag.Version)) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -160,10 +362,15 @@ func getLanguageTagByID(w http.ResponseWriter, r *http.Request, id int32) {
 //	@Tags			Language tags
 //	@Accept			json
 //	@Produce		json
-//	@Param			languageTag	body		LanguageTagBody		true	"Language Tag with Variants"
-//	@Success		201			{object}	LanguageTagResponse	"Created Language Tag with variants"
-//	@Failure		400			{string}	string				"Invalid input"
-//	@Failure		500			{string}	string				"Failed to insert language tag or variants"
+//	@Param			languageTag		body		LanguageTagBody		true	"Language Tag with Variants"
+//	@Param			Idempotency-Key	header		string				false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{object}	LanguageTagResponse	"Created Language Tag with variants"
+//	@Failure		400				{string}	string				"Invalid input"
+//	@Failure		409				{string}	string				"A request with this Idempotency-Key is still being processed"
+//	@Failure		422				{string}	string				"Idempotency-Key already used with a different request"
+//	@Failure		500				{string}	string				"Failed to insert language tag or variants"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language [post]
 func postLanguageTag(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -180,28 +387,171 @@ func postLanguageTag(w http.ResponseWriter, r *http.Request) {
 		Iso6392: input.ISO639_2,
 	}
 
-	tagID, err := queries.InsertLanguageTag(ctx, tagParams)
+	tx, qtx, err := beginAudited(ctx)
 	if err != nil {
 		http.Error(w, "Failed to insert language tag", http.StatusInternalServerError)
 		return
 	}
+	defer tx.Rollback()
 
-	tag, err := queries.GetLanguageTagByID(ctx, tagID)
+	tagID, err := qtx.InsertLanguageTag(ctx, tagParams)
+	if err != nil {
+		http.Error(w, "Failed to insert language tag", http.StatusInternalServerError)
+		return
+	}
+
+	tag, err := qtx.GetLanguageTagByID(ctx, tagID)
 	if err != nil {
 		http.Error(w, "Failed to retrieve inserted language tag", http.StatusInternalServerError)
 		return
 	}
 
-	result := LanguageTagResponse{
-		ID:       tag.ID,
-		Name:     tag.Name,
-		ISO639_2: tag.Iso6391,
-		ISO639_1: tag.Iso6392,
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to insert language tag", http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(result); err != nil {
+	if err := json.NewEncoder(w).Encode(toLanguageTagResponse(tag)); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
+
+// getLanguageTag reads language tag id, as it was at the as_of parameter of
+// r if there is one.
+func getLanguageTag(r *http.Request, id int32) (sqlc.Language, error) {
+	asOf, historic, err := parseAsOf(r)
+	if err != nil {
+		return sqlc.Language{}, errInvalidAsOf
+	}
+	if !historic {
+		return queries.GetLanguageTagByID(r.Context(), id)
+	}
+	tag, err := queries.GetLanguageTagByIDAsOf(r.Context(), sqlc.GetLanguageTagByIDAsOfParams{ID: id, AsOf: asOf})
+	return sqlc.Language(tag), err
+}
+
+// revertLanguageTag godoc
+//
+//	@Summary		Revert a language tag
+//	@Description	Copy a previous version of a language tag back into it, which records it as a new version. Versions are numbered from 1 and listed by GET /audit/language/{id}, or read with as_of.
+//	@Tags			Language tags
+//	@Produce		json
+//	@Param			id		path		int					true	"Language Tag ID"
+//	@Param			version	query		int					true	"Version to restore"
+//	@Success		200		{object}	LanguageTagResponse	"Reverted Language Tag"
+//	@Failure		400		{string}	string				"Invalid item ID or version"
+//	@Failure		404		{string}	string				"Language tag or version not found"
+//	@Failure		409		{string}	string				"The version conflicts with another language tag"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language/{id}/revert [post]
+func revertLanguageTag(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := revertRow(w, r, "Language tag", func(qtx *sqlc.Queries, version int32) (int64, error) {
+		return qtx.RevertLanguage(r.Context(), sqlc.RevertLanguageParams{ID: id, Version: version})
+	})
+	if ok {
+		getLanguageTagByID(w, r, id)
+	}
+}
+
+// deleteLanguageTag godoc
+//
+//	@Summary		Delete a language tag
+//	@Description	Mark a language tag as deleted. It is left out of lists but can still be read by ID, and restored until it is purged after the retention period, along with its variants.
+//	@Tags			Language tags
+//	@Param			id			path		int		true	"Language Tag ID"
+//	@Param			If-Match	header		string	true	"ETag of the language tag"
+//	@Success		204			{string}	string	"Deleted"
+//	@Failure		400			{string}	string	"Invalid item ID"
+//	@Failure		404			{string}	string	"Language tag not found or already deleted"
+//	@Failure		412			{string}	string	"The language tag has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language/{id} [delete]
+func deleteLanguageTag(w http.ResponseWriter, r *http.Request, id int32) {
+	lock := func(qtx *sqlc.Queries) (string, error) {
+		version, err := qtx.LockLanguage(r.Context(), id)
+		return versionETag(version), err
+	}
+	ok := changeDeletion(w, r, "Language tag not found or already deleted", lock, func(qtx *sqlc.Queries) (int64, error) {
+		return qtx.DeleteLanguage(r.Context(), id)
+	})
+	if ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// restoreLanguageTag godoc
+//
+//	@Summary		Restore a language tag
+//	@Description	Restore a deleted language tag that has not been purged yet
+//	@Tags			Language tags
+//	@Produce		json
+//	@Param			id	path		int					true	"Language Tag ID"
+//	@Success		200	{object}	LanguageTagResponse	"Restored Language Tag"
+//	@Failure		400	{string}	string				"Invalid item ID"
+//	@Failure		404	{string}	string				"Deleted language tag not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language/{id}/restore [post]
+func restoreLanguageTag(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeDeletion(w, r, "Deleted language tag not found", nil, func(qtx *sqlc.Queries) (int64, error) {
+		return qtx.RestoreLanguage(r.Context(), id)
+	})
+	if ok {
+		getLanguageTagByID(w, r, id)
+	}
+}
+
+// setLanguageTagStatus godoc
+//
+//	@Summary		Deprecate, retire or reactivate a language tag
+//	@Description	Set the status of a language tag, e.g. when its code is withdrawn as iw was for he. deprecated_at defaults to now, and replaced_by names the language tag to use instead.
+//	@Tags			Language tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Language Tag ID"
+//	@Param			status	body		StatusRequest		true	"New status"
+//	@Success		200		{object}	LanguageTagResponse	"Language Tag with its new status"
+//	@Failure		400		{string}	string				"Invalid item ID, status or replaced_by"
+//	@Failure		404		{string}	string				"Language tag not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language/{id}/status [post]
+func setLanguageTagStatus(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeStatus(w, r, id, "Language tag not found", func(qtx *sqlc.Queries, l lifecycle) (int64, error) {
+		return qtx.SetLanguageStatus(r.Context(), sqlc.SetLanguageStatusParams{
+			Status:       l.Status,
+			DeprecatedAt: l.DeprecatedAt,
+			ReplacedBy:   l.ReplacedBy,
+			ID:           id,
+		})
+	})
+	if ok {
+		getLanguageTagByID(w, r, id)
+	}
+}
+
+func toLanguageTagResponse(tag sqlc.Language) LanguageTagResponse {
+	response := LanguageTagResponse{
+		ID:       tag.ID,
+		Name:     tag.Name,
+		ISO639_1: tag.Iso6392,
+		ISO639_2: tag.Iso6391,
+		Version:  tag.Version,
+		Status:   tag.Status,
+	}
+	if tag.DeletedAt.Valid {
+		response.DeletedAt = &tag.DeletedAt.Time
+	}
+	if tag.DeprecatedAt.Valid {
+		response.DeprecatedAt = &tag.DeprecatedAt.Time
+	}
+	if tag.ReplacedBy.Valid {
+		response.ReplacedBy = &tag.ReplacedBy.Int32
+	}
+	return response
+}
@@ -1,14 +1,20 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/lib/pq"
 )
 
 type LanguageTagVariantsRequest struct {
@@ -21,43 +27,116 @@ type LanguageTagVariantsRequest struct {
 type LanguageTagVariantsResponse struct {
 	ID            int32  `json:"id"`
 	LanguageTagID int32  `json:"language_tag_id"`
+	CountryID     *int32 `json:"country_id"`
 	VariantTag    string `json:"variant_tag"`
 	Description   string `json:"description"`
+	Version       int32  `json:"version"`
+	// DeletedAt is set on deleted variants, which lists only return with
+	// include_deleted=true.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Status is active, deprecated or retired. Lists only return retired
+	// variants with include_retired=true.
+	Status       string     `json:"status"`
+	DeprecatedAt *time.Time `json:"deprecated_at,omitempty"`
+	ReplacedBy   *int32     `json:"replaced_by,omitempty"`
+}
+
+// LanguageTagVariantPatchRequest documents the PATCH body. Only the fields
+// present are changed; null clears language_id, country_id or description.
+type LanguageTagVariantPatchRequest struct {
+	LanguageTagID *int32  `json:"language_id"`
+	CountryID     *int32  `json:"country_id"`
+	VariantTag    *string `json:"variant_tag"`
+	Description   *string `json:"description"`
+}
+
+const (
+	maxBulkVariants  = 10000
+	variantBatchSize = 1000
+)
+
+type VariantBulkItemResult struct {
+	// Index is the position of the item in the request.
+	Index  int    `json:"index"`
+	Status int    `json:"status"`
+	ID     int32  `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type VariantBulkResponse struct {
+	Mode    string                  `json:"mode"`
+	Created int                     `json:"created"`
+	Failed  int                     `json:"failed"`
+	Results []VariantBulkItemResult `json:"results"`
 }
 
 type PaginatedVariantsResponse struct {
 	Variants      []LanguageTagVariantsResponse `json:"variants"`
 	NextPageToken string                        `json:"next_page_token,omitempty"`
+	PrevPageToken string                        `json:"prev_page_token,omitempty"`
+	TotalCount    *int64                        `json:"total_count,omitempty"`
 }
 
 // LanguageTagVariantHandler handles requests related to language tag variants
 //
 //	@Summary		Handles language tag variants
-//	@Description	Get, create, or update language tag variants
+//	@Description	List, get, create, update or delete language tag variants
 //	@tags			Language variants
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int	false	"Variant ID"	for	PUT	request
+//	@Param			id	path		int	false	"Variant ID"
 //	@Success		200	{object}	LanguageTagVariantsResponse
 //	@Failure		400	{string}	string	"Invalid request"
 //	@Failure		405	{string}	string	"Method not allowed"
 func LanguageTagVariantHandler(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		getPaginatedVariants(w, r)
-	case http.MethodPost:
-		postLanguageTagVariant(w, r)
-	case http.MethodPut:
-		path := r.URL.Path
-		idStr := strings.TrimPrefix(path, "/language-variant/")
+	path := r.URL.Path
 
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			http.Error(w, "Invalid item ID", http.StatusBadRequest)
+	if path == "/language-variant" || path == "/language-variant/" {
+		switch r.Method {
+		case http.MethodGet:
+			getPaginatedVariants(w, r)
+		case http.MethodPost:
+			postLanguageTagVariant(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	idStr, action, hasAction := strings.Cut(strings.TrimPrefix(path, "/language-variant/"), "/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	if hasAction {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
+		switch action {
+		case "revert":
+			revertLanguageTagVariant(w, r, int32(id))
+		case "restore":
+			restoreLanguageTagVariant(w, r, int32(id))
+		case "status":
+			setLanguageTagVariantStatus(w, r, int32(id))
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+		return
+	}
 
+	switch r.Method {
+	case http.MethodGet:
+		getLanguageTagVariantByID(w, r, int32(id))
+	case http.MethodPut:
 		updateLanguageTagVariant(w, r, id)
+	case http.MethodPatch:
+		patchLanguageTagVariant(w, r, int32(id))
+	case http.MethodDelete:
+		deleteLanguageTagVariant(w, r, int32(id))
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
@@ -66,16 +145,22 @@ func LanguageTagVariantHandler(w http.ResponseWriter, r *http.Request) {
 // getPaginatedVariants returns paginated language tag variants
 //
 //	@Summary		Get paginated language tag variants
-//	@Description	Get a list of language tag variants with pagination
+//	@Description	Get a list of language tag variants ordered by ID. Page tokens are opaque cursors taken from next_page_token or prev_page_token, and are only valid with the same languageTagId, as_of, include_deleted and include_retired they were issued for. Retired variants are left out unless include_retired is true.
 //	@tags			Language variants
 //	@Accept			json
 //	@Produce		json
-//	@Param			languageTagId	query		int	false	"Language Tag ID"
-//	@Param			page_size		query		int	false	"Limit of items per page"	default(10)
-//	@Param			page_token		query		int	false	"Offset for pagination"		default(0)
-//	@Success		200				{object}	PaginatedVariantsResponse
-//	@Failure		400				{string}	string	"Invalid languageTagId or page_token"
-//	@Failure		500				{string}	string	"Database query error"
+//	@Param			languageTagId		query		int		false	"Language Tag ID"
+//	@Param			page_size			query		int		false	"Limit of items per page"	default(10)	maximum(100)
+//	@Param			page_token			query		string	false	"Cursor of the page to fetch"
+//	@Param			include_total_count	query		bool	false	"Include the total number of matching variants"
+//	@Param			as_of				query		string	false	"List the variants as they were at this RFC 3339 time or date"	example(2026-01-01)
+//	@Param			include_deleted		query		bool	false	"Include deleted variants"
+//	@Param			include_retired		query		bool	false	"Include retired variants"
+//	@Success		200					{object}	PaginatedVariantsResponse
+//	@Failure		400					{string}	string	"Invalid languageTagId, as_of, include_deleted, include_retired or page_token"
+//	@Failure		500					{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language-variant [get]
 func getPaginatedVariants(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -85,44 +170,110 @@ func getPaginatedVariants(w http.ResponseWriter, r *http.Request) {
 	pageSizeStr := query.Get("page_size")
 	pageTokenStr := query.Get("page_token")
 
-	pageSize, err := strconv.Atoi(pageSizeStr)
-	if err != nil || pageSize <= 0 {
-		pageSize = 10
-	}
+	pageSize := parsePageSize(pageSizeStr)
 
-	var languageTagId *int32
+	var languageTagId sql.NullInt32
 	if languageTagIdStr != "" {
 		id, err := strconv.Atoi(languageTagIdStr)
 		if err != nil {
 			http.Error(w, "Invalid languageTagId", http.StatusBadRequest)
 			return
 		}
-		id32 := int32(id)
-		languageTagId = &id32
-	} else {
-		languageTagId = nil
+		languageTagId = sql.NullInt32{Int32: int32(id), Valid: true}
 	}
 
-	offset := 0
+	includeTotalCount, err := parseIncludeTotalCount(query.Get("include_total_count"))
+	if err != nil {
+		http.Error(w, "Invalid include_total_count", http.StatusBadRequest)
+		return
+	}
+
+	asOf, historic, err := parseAsOf(r)
+	if err != nil {
+		http.Error(w, "Invalid as_of", http.StatusBadRequest)
+		return
+	}
+
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		http.Error(w, "Invalid include_deleted", http.StatusBadRequest)
+		return
+	}
+
+	includeRetired, err := parseIncludeRetired(r)
+	if err != nil {
+		http.Error(w, "Invalid include_retired", http.StatusBadRequest)
+		return
+	}
+
+	scope := ""
+	if languageTagId.Valid {
+		scope = strconv.Itoa(int(languageTagId.Int32))
+	}
+	if historic {
+		scope += "@" + asOf.UTC().Format(time.RFC3339Nano)
+	}
+	if includeDeleted {
+		scope += "+deleted"
+	}
+	if includeRetired {
+		scope += "+retired"
+	}
+
+	cursor := pageCursor{Direction: pageNext}
 	if pageTokenStr != "" {
-		offset, err = strconv.Atoi(pageTokenStr)
-		if err != nil || offset < 0 {
+		cursor, err = decodePageToken(pageTokenStr, scope)
+		if err != nil {
 			http.Error(w, "Invalid page_token", http.StatusBadRequest)
 			return
 		}
 	}
 
+	// One extra row tells whether there is another page in the direction
+	// being read without a separate count.
 	var variants []sqlc.Variant
-	if languageTagId == nil {
-		variants, err = queries.GetPaginatedVariantsWithoutFilter(ctx, sqlc.GetPaginatedVariantsWithoutFilterParams{
-			Limit:  int32(pageSize),
-			Offset: int32(offset),
+	switch {
+	case historic && cursor.Direction == pagePrev:
+		var rows []sqlc.GetVariantsBeforeAsOfRow
+		rows, err = queries.GetVariantsBeforeAsOf(ctx, sqlc.GetVariantsBeforeAsOfParams{
+			LanguageID:     languageTagId,
+			AsOf:           asOf,
+			IncludeDeleted: includeDeleted,
+			IncludeRetired: includeRetired,
+			BeforeID:       cursor.ID,
+			RowLimit:       int32(pageSize + 1),
 		})
-	} else {
-		variants, err = queries.GetPaginatedVariantsWithFilter(ctx, sqlc.GetPaginatedVariantsWithFilterParams{
-			LanguageID: *languageTagId,
-			Limit:      int32(pageSize),
-			Offset:     int32(offset),
+		for _, row := range rows {
+			variants = append(variants, sqlc.Variant(row))
+		}
+	case historic:
+		var rows []sqlc.GetVariantsAfterAsOfRow
+		rows, err = queries.GetVariantsAfterAsOf(ctx, sqlc.GetVariantsAfterAsOfParams{
+			LanguageID:     languageTagId,
+			AsOf:           asOf,
+			IncludeDeleted: includeDeleted,
+			IncludeRetired: includeRetired,
+			AfterID:        cursor.ID,
+			RowLimit:       int32(pageSize + 1),
+		})
+		for _, row := range rows {
+			variants = append(variants, sqlc.Variant(row))
+		}
+	case cursor.Direction == pagePrev:
+		variants, err = queries.GetVariantsBefore(ctx, sqlc.GetVariantsBeforeParams{
+			LanguageID:     languageTagId,
+			IncludeDeleted: includeDeleted,
+			IncludeRetired: includeRetired,
+			BeforeID:       cursor.ID,
+			RowLimit:       int32(pageSize + 1),
+		})
+	default:
+		variants, err = queries.GetVariantsAfter(ctx, sqlc.GetVariantsAfterParams{
+			LanguageID:     languageTagId,
+			IncludeDeleted: includeDeleted,
+			IncludeRetired: includeRetired,
+			AfterID:        cursor.ID,
+			RowLimit:       int32(pageSize + 1),
 		})
 	}
 
@@ -131,18 +282,63 @@ func getPaginatedVariants(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var response PaginatedVariantsResponse
+	hasMore := len(variants) > pageSize
+	if hasMore {
+		variants = variants[:pageSize]
+	}
+
+	var hasNext, hasPrev bool
+	if cursor.Direction == pagePrev {
+		for i, j := 0, len(variants)-1; i < j; i, j = i+1, j-1 {
+			variants[i], variants[j] = variants[j], variants[i]
+		}
+		// The token was issued from a page after this one.
+		hasNext, hasPrev = len(variants) > 0, hasMore
+	} else {
+		hasNext, hasPrev = hasMore, pageTokenStr != "" && len(variants) > 0
+	}
+
+	response := PaginatedVariantsResponse{Variants: []LanguageTagVariantsResponse{}}
 	for _, v := range variants {
-		response.Variants = append(response.Variants, LanguageTagVariantsResponse{
-			ID:            v.ID,
-			LanguageTagID: v.LanguageID.Int32,
-			VariantTag:    v.VariantTag,
-			Description:   v.Description.String,
+		response.Variants = append(response.Variants, toVariantResponse(v))
+	}
+
+	if hasNext {
+		response.NextPageToken = encodePageToken(pageCursor{
+			Direction: pageNext,
+			ID:        variants[len(variants)-1].ID,
+			Scope:     scope,
+		})
+	}
+	if hasPrev {
+		response.PrevPageToken = encodePageToken(pageCursor{
+			Direction: pagePrev,
+			ID:        variants[0].ID,
+			Scope:     scope,
 		})
 	}
 
-	if len(variants) == pageSize {
-		response.NextPageToken = strconv.Itoa(offset + pageSize)
+	if includeTotalCount {
+		var count int64
+		if historic {
+			count, err = queries.CountVariantsAsOf(ctx, sqlc.CountVariantsAsOfParams{
+				LanguageID:     languageTagId,
+				AsOf:           asOf,
+				IncludeDeleted: includeDeleted,
+				IncludeRetired: includeRetired,
+			})
+		} else {
+			count, err = queries.CountVariants(ctx, sqlc.CountVariantsParams{
+				LanguageID:     languageTagId,
+				IncludeDeleted: includeDeleted,
+				IncludeRetired: includeRetired,
+			})
+		}
+		if err != nil {
+			http.Error(w, "Database query error", http.StatusInternalServerError)
+			return
+		}
+		response.TotalCount = &count
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -151,98 +347,605 @@ func getPaginatedVariants(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// postLanguageTagVariant handles creating a new language tag variant
+// postLanguageTagVariant handles creating language tag variants in bulk
 //
-//	@Summary		Create a new language tag variant
-//	@Description	Create a new language tag variant
+//	@Summary		Create language tag variants
+//	@Description	Create one or more language tag variants with batched inserts in a single transaction.
+//	@Description	In atomic mode (the default) either every variant is created and the response is 201 with their IDs, or none is and the response is 422 with the status of each item.
+//	@Description	In partial mode the valid items are created and the response is always 207 with the status of each item. Items the database refuses are rolled back on their own and reported with 409, 422 or 500.
+//	@Description	A language_id or country_id of 0 leaves the variant without one.
 //	@tags			Language variants
 //	@Accept			json
 //	@Produce		json
-//	@Param			variant	body		[]LanguageTagVariantsRequest	true	"Language Tag Variant"
-//	@Success		201		{object}	[]LanguageTagVariantsResponse
-//	@Failure		400		{string}	string	"Invalid request payload"
-//	@Failure		500		{string}	string	"Database query error"
+//	@Param			mode			query		string							false	"Failure handling"	Enums(atomic, partial)	default(atomic)
+//	@Param			variant			body		[]LanguageTagVariantsRequest	true	"Language Tag Variants"
+//	@Param			Idempotency-Key	header		string							false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{array}		LanguageTagVariantsResponse		"Every variant was created"
+//	@Success		207				{object}	VariantBulkResponse				"Per-item results in partial mode"
+//	@Failure		400				{string}	string							"Invalid request payload"
+//	@Failure		409				{string}	string							"A request with this Idempotency-Key is still being processed"
+//	@Failure		422				{object}	VariantBulkResponse				"Invalid items in atomic mode; nothing was created"
+//	@Failure		500				{string}	string							"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language-variant [post]
 func postLanguageTagVariant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = "atomic"
+	}
+	if mode != "atomic" && mode != "partial" {
+		http.Error(w, "Invalid mode", http.StatusBadRequest)
+		return
+	}
+
 	var req []LanguageTagVariantsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request payload", http.StatusBadRequest)
 		return
 	}
+	if len(req) == 0 || len(req) > maxBulkVariants {
+		http.Error(w, fmt.Sprintf("Expected between 1 and %d variants", maxBulkVariants), http.StatusBadRequest)
+		return
+	}
 
-	var response []LanguageTagVariantsResponse
+	results, err := validateBulkVariants(ctx, req)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := VariantBulkResponse{Mode: mode, Results: results}
+	var valid []int
+	for i, result := range results {
+		if result.Error == "" {
+			valid = append(valid, i)
+		} else {
+			response.Failed++
+		}
+	}
+
+	if mode == "atomic" && response.Failed > 0 {
+		writeBulkResponse(w, http.StatusUnprocessableEntity, response)
+		return
+	}
+
+	variants, failures, err := insertVariantBatches(ctx, req, valid, mode == "partial")
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	created := make([]LanguageTagVariantsResponse, 0, len(valid))
+	for _, i := range valid {
+		if err, failed := failures[i]; failed {
+			response.Results[i].Status, response.Results[i].Error = bulkInsertError(err)
+			response.Failed++
+			continue
+		}
+		created = append(created, toVariantResponse(variants[i]))
+		response.Results[i].Status = http.StatusCreated
+		response.Results[i].ID = variants[i].ID
+		response.Created++
+	}
+
+	if mode == "partial" {
+		writeBulkResponse(w, http.StatusMultiStatus, response)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(created); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// validateBulkVariants returns one result per item, with an error and a 422
+// status for items that cannot be inserted.
+func validateBulkVariants(ctx context.Context, req []LanguageTagVariantsRequest) ([]VariantBulkItemResult, error) {
+	var languageIDs, countryIDs []int32
 	for _, v := range req {
-		arg := sqlc.InsertVariantParams{
-			LanguageID:  sql.NullInt32{Int32: v.LanguageTagID, Valid: false},
-			CountryID:   sql.NullInt32{Int32: v.CountryID, Valid: false},
-			VariantTag:  v.VariantTag,
-			Description: sql.NullString{String: v.Description, Valid: true},
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+		if v.LanguageTagID != 0 {
+			languageIDs = append(languageIDs, v.LanguageTagID)
 		}
+		if v.CountryID != 0 {
+			countryIDs = append(countryIDs, v.CountryID)
+		}
+	}
 
-		err := queries.InsertVariant(r.Context(), arg)
-		if err != nil {
-			println(err.Error())
-			http.Error(w, "Database query error", http.StatusInternalServerError)
-			return
+	existingLanguages, err := queries.GetExistingLanguageIDs(ctx, languageIDs)
+	if err != nil {
+		return nil, err
+	}
+	existingCountries, err := queries.GetExistingCountryIDs(ctx, countryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]VariantBulkItemResult, len(req))
+	for i, v := range req {
+		results[i].Index = i
+
+		var problem string
+		switch {
+		case strings.TrimSpace(v.VariantTag) == "":
+			problem = "variant_tag is required"
+		case len(v.VariantTag) > 255:
+			problem = "variant_tag is longer than 255 characters"
+		case v.LanguageTagID != 0 && !slices.Contains(existingLanguages, v.LanguageTagID):
+			problem = fmt.Sprintf("language %d does not exist", v.LanguageTagID)
+		case v.CountryID != 0 && !slices.Contains(existingCountries, v.CountryID):
+			problem = fmt.Sprintf("country %d does not exist", v.CountryID)
+		}
+
+		if problem != "" {
+			results[i].Status = http.StatusUnprocessableEntity
+			results[i].Error = problem
+		}
+	}
+	return results, nil
+}
+
+// insertVariantBatches inserts the items of req at the given indexes in one
+// transaction, variantBatchSize rows per statement, and returns the rows by
+// index. In partial mode a batch that fails is rolled back to a savepoint and
+// its items are inserted one at a time, each under its own savepoint, so that
+// the items the database refuses are returned with their errors instead of
+// failing the others.
+func insertVariantBatches(ctx context.Context, req []LanguageTagVariantsRequest, indexes []int, partial bool) (map[int]sqlc.Variant, map[int]error, error) {
+	variants := make(map[int]sqlc.Variant, len(indexes))
+	failures := map[int]error{}
+	if len(indexes) == 0 {
+		return variants, failures, nil
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	for start := 0; start < len(indexes); start += variantBatchSize {
+		batch := indexes[start:min(start+variantBatchSize, len(indexes))]
+		if !partial {
+			if err := insertVariants(ctx, qtx, req, batch, variants); err != nil {
+				return nil, nil, err
+			}
+			continue
 		}
 
-		response = append(response, LanguageTagVariantsResponse{
-			LanguageTagID: v.LanguageTagID,
-			VariantTag:    v.VariantTag,
-			Description:   v.Description,
+		err := inSavepoint(ctx, tx, func() error {
+			return insertVariants(ctx, qtx, req, batch, variants)
 		})
+		if err == nil {
+			continue
+		}
+		for _, i := range batch {
+			err := inSavepoint(ctx, tx, func() error {
+				return insertVariants(ctx, qtx, req, []int{i}, variants)
+			})
+			if err != nil {
+				failures[i] = err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
 	}
+	return variants, failures, nil
+}
+
+// insertVariants inserts the items of req at the given indexes with one
+// statement and adds the rows to variants by index.
+func insertVariants(ctx context.Context, qtx *sqlc.Queries, req []LanguageTagVariantsRequest, indexes []int, variants map[int]sqlc.Variant) error {
+	arg := sqlc.InsertVariantsParams{
+		LanguageIds:  make([]int32, len(indexes)),
+		CountryIds:   make([]int32, len(indexes)),
+		VariantTags:  make([]string, len(indexes)),
+		Descriptions: make([]string, len(indexes)),
+	}
+	for n, i := range indexes {
+		arg.LanguageIds[n] = req[i].LanguageTagID
+		arg.CountryIds[n] = req[i].CountryID
+		arg.VariantTags[n] = req[i].VariantTag
+		arg.Descriptions[n] = req[i].Description
+	}
+
+	rows, err := qtx.InsertVariants(ctx, arg)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		variants[indexes[row.Position-1]] = sqlc.Variant{
+			ID:           row.ID,
+			LanguageID:   row.LanguageID,
+			CountryID:    row.CountryID,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+			VariantTag:   row.VariantTag,
+			Description:  row.Description,
+			Version:      row.Version,
+			DeletedAt:    row.DeletedAt,
+			Status:       row.Status,
+			DeprecatedAt: row.DeprecatedAt,
+			ReplacedBy:   row.ReplacedBy,
+		}
+	}
+	return nil
+}
 
+// inSavepoint runs f under a savepoint of tx, which is rolled back to if f
+// fails so that the transaction can go on.
+func inSavepoint(ctx context.Context, tx *sql.Tx, f func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_item"); err != nil {
+		return err
+	}
+	if err := f(); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_item"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_item")
+	return err
+}
+
+// bulkInsertError returns the status and message reporting an item that the
+// database refused.
+func bulkInsertError(err error) (int, string) {
+	switch {
+	case isForeignKeyViolation(err):
+		return http.StatusUnprocessableEntity, "language or country no longer exists"
+	case isUniqueViolation(err):
+		return http.StatusConflict, "variant already exists"
+	default:
+		return http.StatusInternalServerError, "Database query error"
+	}
+}
+
+func writeBulkResponse(w http.ResponseWriter, status int, response VariantBulkResponse) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
 
+// getLanguageTagVariantByID returns a single language tag variant
+//
+//	@Summary		Get a language tag variant
+//	@Description	Get a language tag variant by ID
+//	@tags			Language variants
+//	@Produce		json
+//	@Param			id				path		int		true	"Variant ID"
+//	@Param			as_of			query		string	false	"Get the variant as it was at this RFC 3339 time or date"
+//	@Param			If-None-Match	header		string	false	"ETag of a copy of the variant, to get 304 if it has not changed"
+//	@Success		200				{object}	LanguageTagVariantsResponse
+//	@Success		304				{string}	string	"Not modified"
+//	@Header			200				{string}	ETag	"Version of the variant, for If-Match and If-None-Match"
+//	@Failure		400				{string}	string	"Invalid item ID or as_of"
+//	@Failure		404				{string}	string	"Variant not found"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language-variant/{id} [get]
+func getLanguageTagVariantByID(w http.ResponseWriter, r *http.Request, id int32) {
+	writeVariant(w, r, id)
+}
+
 // updateLanguageTagVariant handles updating an existing language tag variant
 //
 //	@Summary		Update an existing language tag variant
-//	@Description	Update an existing language tag variant
+//	@Description	Replace every field of an existing language tag variant. A country_id of 0 clears the country.
 //	@tags			Language variants
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int							true	"Variant ID"
-//	@Param			variant	body		LanguageTagVariantsRequest	true	"Language Tag Variant"
-//	@Success		200		{object}	LanguageTagVariantsResponse
-//	@Failure		400		{string}	string	"Invalid request payload"
-//	@Failure		404		{string}	string	"Variant not found"
-//	@Failure		500		{string}	string	"Database query error"
+//	@Param			id			path		int							true	"Variant ID"
+//	@Param			If-Match	header		string						true	"ETag of the variant"
+//	@Param			variant		body		LanguageTagVariantsRequest	true	"Language Tag Variant"
+//	@Success		200			{object}	LanguageTagVariantsResponse
+//	@Failure		400			{string}	string	"Invalid request payload"
+//	@Failure		404			{string}	string	"Variant not found"
+//	@Failure		412			{string}	string	"The variant has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/language-variant/{id} [put]
 func updateLanguageTagVariant(w http.ResponseWriter, r *http.Request, LanguageTagVariantId int) {
-	var req LanguageTagVariantsResponse
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	var req LanguageTagVariantsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request payload", http.StatusBadRequest)
 		return
 	}
+	if req.VariantTag == "" {
+		http.Error(w, "variant_tag is required", http.StatusBadRequest)
+		return
+	}
 
 	arg := sqlc.UpdateVariantParams{
 		ID:          int32(LanguageTagVariantId),
-		LanguageID:  sql.NullInt32{Int32: req.LanguageTagID, Valid: true},
+		LanguageID:  sql.NullInt32{Int32: req.LanguageTagID, Valid: req.LanguageTagID != 0},
+		CountryID:   sql.NullInt32{Int32: req.CountryID, Valid: req.CountryID != 0},
 		VariantTag:  req.VariantTag,
 		Description: sql.NullString{String: req.Description, Valid: true},
 		UpdatedAt:   time.Now(),
 	}
 
-	err := queries.UpdateVariant(r.Context(), arg)
+	tx, qtx, err := beginAudited(r.Context())
 	if err != nil {
-		println(err.Error())
 		http.Error(w, "Database query error", http.StatusInternalServerError)
 		return
 	}
+	defer tx.Rollback()
 
-	response := LanguageTagVariantsResponse{
-		ID:            int32(LanguageTagVariantId),
-		LanguageTagID: req.LanguageTagID,
-		VariantTag:    req.VariantTag,
-		Description:   req.Description,
+	if !matchLocked(w, r, "Variant not found", qtx, lockVariant(r, arg.ID)) {
+		return
+	}
+
+	affected, err := qtx.UpdateVariant(r.Context(), arg)
+	if isForeignKeyViolation(err) {
+		http.Error(w, "Unknown language_id or country_id", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Variant not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	writeVariant(w, r, int32(LanguageTagVariantId))
+}
+
+// patchLanguageTagVariant handles partially updating a language tag variant
+//
+//	@Summary		Partially update a language tag variant
+//	@Description	Change only the fields present in the body. null clears language_id, country_id or description; variant_tag cannot be null.
+//	@tags			Language variants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Variant ID"
+//	@Param			If-Match	header		string							true	"ETag of the variant"
+//	@Param			variant		body		LanguageTagVariantPatchRequest	true	"Fields to change"
+//	@Success		200			{object}	LanguageTagVariantsResponse
+//	@Failure		400			{string}	string	"Invalid request payload"
+//	@Failure		404			{string}	string	"Variant not found"
+//	@Failure		412			{string}	string	"The variant has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language-variant/{id} [patch]
+func patchLanguageTagVariant(w http.ResponseWriter, r *http.Request, id int32) {
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	arg := sqlc.PatchVariantParams{ID: id}
+	for name, raw := range fields {
+		var err error
+		switch name {
+		case "language_id":
+			arg.SetLanguageID = true
+			arg.LanguageID, err = decodeNullInt32(raw)
+		case "country_id":
+			arg.SetCountryID = true
+			arg.CountryID, err = decodeNullInt32(raw)
+		case "variant_tag":
+			arg.VariantTag, err = decodeNullString(raw)
+			if err == nil && (!arg.VariantTag.Valid || arg.VariantTag.String == "") {
+				http.Error(w, "variant_tag cannot be empty", http.StatusBadRequest)
+				return
+			}
+		case "description":
+			arg.SetDescription = true
+			arg.Description, err = decodeNullString(raw)
+		default:
+			http.Error(w, "Unknown field "+name, http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Invalid "+name, http.StatusBadRequest)
+			return
+		}
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Variant not found", qtx, lockVariant(r, id)) {
+		return
+	}
+
+	affected, err := qtx.PatchVariant(r.Context(), arg)
+	if isForeignKeyViolation(err) {
+		http.Error(w, "Unknown language_id or country_id", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Variant not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	writeVariant(w, r, id)
+}
+
+// decodeNullInt32 decodes a PATCH field that may be null.
+func decodeNullInt32(raw json.RawMessage) (sql.NullInt32, error) {
+	if bytes.Equal(raw, []byte("null")) {
+		return sql.NullInt32{}, nil
+	}
+	var v int32
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: v, Valid: true}, nil
+}
+
+// decodeNullString decodes a PATCH field that may be null.
+func decodeNullString(raw json.RawMessage) (sql.NullString, error) {
+	if bytes.Equal(raw, []byte("null")) {
+		return sql.NullString{}, nil
+	}
+	var v string
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: v, Valid: true}, nil
+}
+
+// deleteLanguageTagVariant handles deleting a language tag variant
+//
+//	@Summary		Delete a language tag variant
+//	@Description	Mark a language tag variant as deleted. It is left out of lists but can still be read by ID, and restored until it is purged after the retention period.
+//	@tags			Language variants
+//	@Param			id			path		int		true	"Variant ID"
+//	@Param			If-Match	header		string	true	"ETag of the variant"
+//	@Success		204			{string}	string	"Deleted"
+//	@Failure		400			{string}	string	"Invalid item ID"
+//	@Failure		404			{string}	string	"Variant not found or already deleted"
+//	@Failure		412			{string}	string	"The variant has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language-variant/{id} [delete]
+func deleteLanguageTagVariant(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeDeletion(w, r, "Variant not found or already deleted", lockVariant(r, id), func(qtx *sqlc.Queries) (int64, error) {
+		return qtx.DeleteVariant(r.Context(), id)
+	})
+	if ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// restoreLanguageTagVariant handles restoring a deleted language tag variant
+//
+//	@Summary		Restore a language tag variant
+//	@Description	Restore a deleted language tag variant that has not been purged yet
+//	@tags			Language variants
+//	@Produce		json
+//	@Param			id	path		int	true	"Variant ID"
+//	@Success		200	{object}	LanguageTagVariantsResponse
+//	@Failure		400	{string}	string	"Invalid item ID"
+//	@Failure		404	{string}	string	"Deleted variant not found"
+//	@Failure		500	{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language-variant/{id}/restore [post]
+func restoreLanguageTagVariant(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeDeletion(w, r, "Deleted variant not found", nil, func(qtx *sqlc.Queries) (int64, error) {
+		return qtx.RestoreVariant(r.Context(), id)
+	})
+	if ok {
+		writeVariant(w, r, id)
+	}
+}
+
+// getLanguageVariants lists the variants of a language tag
+//
+//	@Summary		List the variants of a language tag
+//	@Description	Get every variant of a language tag, ordered by ID
+//	@Tags			Language tags
+//	@Produce		json
+//	@Param			id				path		int		true	"Language Tag ID"
+//	@Param			as_of			query		string	false	"List the variants as they were at this RFC 3339 time or date"
+//	@Param			include_deleted	query		bool	false	"Include deleted variants"
+//	@Param			include_retired	query		bool	false	"Include retired variants"
+//	@Success		200				{array}		LanguageTagVariantsResponse
+//	@Failure		400				{string}	string	"Invalid item ID, as_of, include_deleted or include_retired"
+//	@Failure		404				{string}	string	"Language tag not found"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language/{id}/variants [get]
+func getLanguageVariants(w http.ResponseWriter, r *http.Request, languageID int32) {
+	ctx := r.Context()
+
+	if _, err := getLanguageTag(r, languageID); errors.Is(err, errInvalidAsOf) {
+		http.Error(w, "Invalid as_of parameter", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Language tag not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	includeDeleted, err := parseIncludeDeleted(r)
+	if err != nil {
+		http.Error(w, "Invalid include_deleted parameter", http.StatusBadRequest)
+		return
+	}
+
+	includeRetired, err := parseIncludeRetired(r)
+	if err != nil {
+		http.Error(w, "Invalid include_retired parameter", http.StatusBadRequest)
+		return
+	}
+
+	languageTagID := sql.NullInt32{Int32: languageID, Valid: true}
+	var variants []sqlc.Variant
+	if asOf, historic, _ := parseAsOf(r); historic {
+		var rows []sqlc.GetVariantsByLanguageTagIDAsOfRow
+		rows, err = queries.GetVariantsByLanguageTagIDAsOf(ctx, sqlc.GetVariantsByLanguageTagIDAsOfParams{
+			LanguageID:     languageTagID,
+			AsOf:           asOf,
+			IncludeDeleted: includeDeleted,
+			IncludeRetired: includeRetired,
+		})
+		for _, row := range rows {
+			variants = append(variants, sqlc.Variant(row))
+		}
+	} else {
+		variants, err = queries.GetVariantsByLanguageTagID(ctx, sqlc.GetVariantsByLanguageTagIDParams{
+			LanguageID:     languageTagID,
+			IncludeDeleted: includeDeleted,
+			IncludeRetired: includeRetired,
+		})
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := []LanguageTagVariantsResponse{}
+	for _, v := range variants {
+		response = append(response, toVariantResponse(v))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -250,3 +953,130 @@ func updateLanguageTagVariant(w http.ResponseWriter, r *http.Request, LanguageTa
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
+
+// writeVariant responds with the state of variant id, as it was at the as_of
+// parameter of r if there is one.
+func writeVariant(w http.ResponseWriter, r *http.Request, id int32) {
+	asOf, historic, err := parseAsOf(r)
+	if err != nil {
+		http.Error(w, "Invalid as_of parameter", http.StatusBadRequest)
+		return
+	}
+
+	var variant sqlc.Variant
+	if historic {
+		var row sqlc.GetVariantByIDAsOfRow
+		row, err = queries.GetVariantByIDAsOf(r.Context(), sqlc.GetVariantByIDAsOfParams{ID: id, AsOf: asOf})
+		variant = sqlc.Variant(row)
+	} else {
+		variant, err = queries.GetVariantByID(r.Context(), id)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Variant not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	setDeprecationHeaders(w, variant.Status, variant.DeprecatedAt, variant.ReplacedBy, "/language-variant/")
+	if notModified(w, r, versionETag(variant.Version)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toVariantResponse(variant)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// revertLanguageTagVariant restores a previous version of a variant
+//
+//	@Summary		Revert a language tag variant
+//	@Description	Copy a previous version of a language tag variant back into it, which records it as a new version. Versions are numbered from 1 and listed by GET /audit/variant/{id}, or read with as_of.
+//	@tags			Language variants
+//	@Produce		json
+//	@Param			id		path		int		true	"Variant ID"
+//	@Param			version	query		int		true	"Version to restore"
+//	@Success		200		{object}	LanguageTagVariantsResponse
+//	@Failure		400		{string}	string	"Invalid item ID or version"
+//	@Failure		404		{string}	string	"Variant or version not found"
+//	@Failure		409		{string}	string	"The language or country of the version no longer exists"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language-variant/{id}/revert [post]
+func revertLanguageTagVariant(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := revertRow(w, r, "Variant", func(qtx *sqlc.Queries, version int32) (int64, error) {
+		return qtx.RevertVariant(r.Context(), sqlc.RevertVariantParams{ID: id, Version: version})
+	})
+	if ok {
+		writeVariant(w, r, id)
+	}
+}
+
+// setLanguageTagVariantStatus changes the lifecycle status of a variant
+//
+//	@Summary		Deprecate, retire or reactivate a language tag variant
+//	@Description	Set the status of a language tag variant. deprecated_at defaults to now, and replaced_by names the variant to use instead.
+//	@tags			Language variants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"Variant ID"
+//	@Param			status	body		StatusRequest	true	"New status"
+//	@Success		200		{object}	LanguageTagVariantsResponse
+//	@Failure		400		{string}	string	"Invalid item ID, status or replaced_by"
+//	@Failure		404		{string}	string	"Variant not found"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/language-variant/{id}/status [post]
+func setLanguageTagVariantStatus(w http.ResponseWriter, r *http.Request, id int32) {
+	ok := changeStatus(w, r, id, "Variant not found", func(qtx *sqlc.Queries, l lifecycle) (int64, error) {
+		return qtx.SetVariantStatus(r.Context(), sqlc.SetVariantStatusParams{
+			Status:       l.Status,
+			DeprecatedAt: l.DeprecatedAt,
+			ReplacedBy:   l.ReplacedBy,
+			ID:           id,
+		})
+	})
+	if ok {
+		writeVariant(w, r, id)
+	}
+}
+
+// lockVariant returns a function locking variant id in the transaction of its
+// queries and returning its ETag.
+func lockVariant(r *http.Request, id int32) func(*sqlc.Queries) (string, error) {
+	return func(qtx *sqlc.Queries) (string, error) {
+		version, err := qtx.LockVariant(r.Context(), id)
+		return versionETag(version), err
+	}
+}
+
+func toVariantResponse(v sqlc.Variant) LanguageTagVariantsResponse {
+	response := LanguageTagVariantsResponse{
+		ID:            v.ID,
+		LanguageTagID: v.LanguageID.Int32,
+		VariantTag:    v.VariantTag,
+		Description:   v.Description.String,
+		Version:       v.Version,
+		Status:        v.Status,
+		DeprecatedAt:  timePtr(v.DeprecatedAt),
+	}
+	if v.CountryID.Valid {
+		response.CountryID = &v.CountryID.Int32
+	}
+	if v.DeletedAt.Valid {
+		response.DeletedAt = &v.DeletedAt.Time
+	}
+	if v.ReplacedBy.Valid {
+		response.ReplacedBy = &v.ReplacedBy.Int32
+	}
+	return response
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+type LocaleURLRequest struct {
+	SiteID     int32  `json:"site_id"`
+	Locale     string `json:"locale"`
+	LanguageID int32  `json:"language_id"`
+	CountryID  int32  `json:"country_id"`
+	Strategy   string `json:"strategy" enums:"path_prefix,subdomain,cctld,query_param"`
+	Value      string `json:"value"`
+}
+
+type LocaleURLResponse struct {
+	ID         int32  `json:"id"`
+	SiteID     int32  `json:"site_id"`
+	Locale     string `json:"locale"`
+	LanguageID int32  `json:"language_id,omitempty"`
+	CountryID  int32  `json:"country_id,omitempty"`
+	Strategy   string `json:"strategy"`
+	Value      string `json:"value"`
+}
+
+// LocaleURLHandler handles requests related to per-locale URL strategies
+//
+//	@Summary		Handles locale URL strategies
+//	@Description	List, create, update or delete the URL strategy of a site's locale
+//	@tags			Locale URLs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	false	"Locale URL ID"
+//	@Success		200	{object}	LocaleURLResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func LocaleURLHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	if path == "/locale-url" || path == "/locale-url/" {
+		switch r.Method {
+		case http.MethodGet:
+			getLocaleURLs(w, r)
+		case http.MethodPost:
+			postLocaleURL(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	idStr := strings.TrimPrefix(path, "/locale-url/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		getLocaleURLByID(w, r, int32(id))
+	case http.MethodPut:
+		updateLocaleURL(w, r, int32(id))
+	case http.MethodDelete:
+		deleteLocaleURL(w, r, int32(id))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getLocaleURLs returns the locale URL strategies of a site
+//
+//	@Summary		List locale URL strategies
+//	@Description	List the URL strategy of every locale configured for a site
+//	@tags			Locale URLs
+//	@Produce		json
+//	@Param			site_id	query		int	true	"Site ID"
+//	@Success		200		{array}		LocaleURLResponse
+//	@Failure		400		{string}	string	"Invalid site_id"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/locale-url [get]
+func getLocaleURLs(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.Atoi(r.URL.Query().Get("site_id"))
+	if err != nil {
+		http.Error(w, "Invalid site_id", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := queries.GetLocaleURLsBySiteID(r.Context(), int32(siteID))
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := []LocaleURLResponse{}
+	for _, row := range rows {
+		response = append(response, LocaleURLResponse{
+			ID:         row.ID,
+			SiteID:     row.SiteID,
+			Locale:     row.Locale,
+			LanguageID: row.LanguageID.Int32,
+			CountryID:  row.CountryID.Int32,
+			Strategy:   row.Strategy,
+			Value:      row.Value,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getLocaleURLByID returns a single locale URL strategy
+//
+//	@Summary		Get a locale URL strategy
+//	@Description	Get a locale URL strategy by ID
+//	@tags			Locale URLs
+//	@Produce		json
+//	@Param			id				path		int		true	"Locale URL ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a copy of the locale URL, to get 304 if it has not changed"
+//	@Success		200				{object}	LocaleURLResponse
+//	@Success		304				{string}	string	"Not modified"
+//	@Header			200				{string}	ETag	"When the locale URL last changed, for If-Match and If-None-Match"
+//	@Failure		404				{string}	string	"Locale URL not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/locale-url/{id} [get]
+func getLocaleURLByID(w http.ResponseWriter, r *http.Request, id int32) {
+	row, err := queries.GetLocaleURLByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Locale URL not found", http.StatusNotFound)
+		return
+	}
+
+	response := LocaleURLResponse{
+		ID:         row.ID,
+		SiteID:     row.SiteID,
+		Locale:     row.Locale,
+		LanguageID: row.LanguageID.Int32,
+		CountryID:  row.CountryID.Int32,
+		Strategy:   row.Strategy,
+		Value:      row.Value,
+	}
+
+	if notModified(w, r, timeETag(row.UpdatedAt)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// postLocaleURL configures the URL strategy of a locale
+//
+//	@Summary		Create a locale URL strategy
+//	@Description	Configure how a locale of a site is encoded in its URLs
+//	@tags			Locale URLs
+//	@Accept			json
+//	@Produce		json
+//	@Param			localeUrl		body		LocaleURLRequest	true	"Locale URL strategy"
+//	@Param			Idempotency-Key	header		string				false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{object}	LocaleURLResponse
+//	@Failure		400				{string}	string	"Invalid request payload"
+//	@Failure		409				{string}	string	"Locale already configured for this site, or a request with this Idempotency-Key is still being processed"
+//	@Failure		422				{string}	string	"Idempotency-Key already used with a different request"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/locale-url [post]
+func postLocaleURL(w http.ResponseWriter, r *http.Request) {
+	var req LocaleURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateLocaleURL(r, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	id, err := qtx.InsertLocaleURL(r.Context(), sqlc.InsertLocaleURLParams{
+		SiteID:     req.SiteID,
+		Locale:     req.Locale,
+		LanguageID: sql.NullInt32{Int32: req.LanguageID, Valid: req.LanguageID != 0},
+		CountryID:  sql.NullInt32{Int32: req.CountryID, Valid: req.CountryID != 0},
+		Strategy:   req.Strategy,
+		Value:      req.Value,
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "Locale already configured for this site", http.StatusConflict)
+		return
+	}
+	if isForeignKeyViolation(err) {
+		http.Error(w, "Unknown site_id, language_id or country_id", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := LocaleURLResponse{
+		ID:         id,
+		SiteID:     req.SiteID,
+		Locale:     req.Locale,
+		LanguageID: req.LanguageID,
+		CountryID:  req.CountryID,
+		Strategy:   req.Strategy,
+		Value:      req.Value,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// updateLocaleURL replaces the URL strategy of a locale
+//
+//	@Summary		Update a locale URL strategy
+//	@Description	Replace the URL strategy of a locale. The site cannot be changed.
+//	@tags			Locale URLs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Locale URL ID"
+//	@Param			If-Match	header		string				true	"ETag of the locale URL"
+//	@Param			localeUrl	body		LocaleURLRequest	true	"Locale URL strategy"
+//	@Success		200			{object}	LocaleURLResponse
+//	@Failure		400			{string}	string	"Invalid request payload"
+//	@Failure		404			{string}	string	"Locale URL not found"
+//	@Failure		409			{string}	string	"Locale already configured for this site"
+//	@Failure		412			{string}	string	"The locale URL has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/locale-url/{id} [put]
+func updateLocaleURL(w http.ResponseWriter, r *http.Request, id int32) {
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	var req LocaleURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	existing, err := queries.GetLocaleURLByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Locale URL not found", http.StatusNotFound)
+		return
+	}
+	req.SiteID = existing.SiteID
+
+	if err := validateLocaleURL(r, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Locale URL not found", qtx, lockLocaleURL(r, id)) {
+		return
+	}
+
+	affected, err := qtx.UpdateLocaleURL(r.Context(), sqlc.UpdateLocaleURLParams{
+		ID:         id,
+		Locale:     req.Locale,
+		LanguageID: sql.NullInt32{Int32: req.LanguageID, Valid: req.LanguageID != 0},
+		CountryID:  sql.NullInt32{Int32: req.CountryID, Valid: req.CountryID != 0},
+		Strategy:   req.Strategy,
+		Value:      req.Value,
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "Locale already configured for this site", http.StatusConflict)
+		return
+	}
+	if isForeignKeyViolation(err) {
+		http.Error(w, "Unknown language_id or country_id", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Locale URL not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	getLocaleURLByID(w, r, id)
+}
+
+// deleteLocaleURL removes the URL strategy of a locale
+//
+//	@Summary		Delete a locale URL strategy
+//	@Description	Remove a locale from a site's URL configuration
+//	@tags			Locale URLs
+//	@Param			id			path		int		true	"Locale URL ID"
+//	@Param			If-Match	header		string	true	"ETag of the locale URL"
+//	@Success		204			{string}	string	"Deleted"
+//	@Failure		404			{string}	string	"Locale URL not found"
+//	@Failure		412			{string}	string	"The locale URL has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/locale-url/{id} [delete]
+func deleteLocaleURL(w http.ResponseWriter, r *http.Request, id int32) {
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Locale URL not found", qtx, lockLocaleURL(r, id)) {
+		return
+	}
+
+	affected, err := qtx.DeleteLocaleURL(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Locale URL not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockLocaleURL returns a function locking locale URL id in the transaction
+// of its queries and returning its ETag.
+func lockLocaleURL(r *http.Request, id int32) func(*sqlc.Queries) (string, error) {
+	return func(qtx *sqlc.Queries) (string, error) {
+		updatedAt, err := qtx.LockLocaleURL(r.Context(), id)
+		return timeETag(updatedAt), err
+	}
+}
+
+// validateLocaleURL checks the strategy against the referenced country, whose
+// TLD backs the cctld strategy.
+func validateLocaleURL(r *http.Request, req LocaleURLRequest) error {
+	if req.SiteID == 0 {
+		return errors.New("site_id is required")
+	}
+
+	locale := urlpattern.Locale{
+		Tag:      req.Locale,
+		Strategy: urlpattern.Strategy(req.Strategy),
+		Value:    req.Value,
+	}
+
+	if req.CountryID != 0 {
+		country, err := queries.GetCountryById(r.Context(), req.CountryID)
+		if err != nil {
+			return errors.New("country not found")
+		}
+		locale.TLD = country.Tld
+	}
+
+	return locale.Validate()
+}
@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/hreflang"
+)
+
+type LocalizedNameRequest struct {
+	EntityType string `json:"entity_type" enums:"country,language"`
+	EntityID   int32  `json:"entity_id"`
+	Locale     string `json:"locale"`
+	Name       string `json:"name"`
+}
+
+type LocalizedNameResponse struct {
+	ID         int32  `json:"id"`
+	EntityType string `json:"entity_type"`
+	EntityID   int32  `json:"entity_id"`
+	Locale     string `json:"locale"`
+	Name       string `json:"name"`
+}
+
+// LocalizedNameHandler handles requests related to localized names
+//
+//	@Summary		Handles localized names
+//	@Description	List, create, update or delete the name of a country or language in a locale
+//	@tags			Localized names
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	false	"Localized name ID"
+//	@Success		200	{object}	LocalizedNameResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func LocalizedNameHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	if path == "/localized-name" || path == "/localized-name/" {
+		switch r.Method {
+		case http.MethodGet:
+			getLocalizedNames(w, r)
+		case http.MethodPost:
+			postLocalizedName(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	idStr := strings.TrimPrefix(path, "/localized-name/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		getLocalizedNameByID(w, r, int32(id))
+	case http.MethodPut:
+		updateLocalizedName(w, r, int32(id))
+	case http.MethodDelete:
+		deleteLocalizedName(w, r, int32(id))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getLocalizedNames returns localized names, optionally filtered
+//
+//	@Summary		List localized names
+//	@Description	List the localized names of countries and languages, which search matches besides their English names
+//	@tags			Localized names
+//	@Produce		json
+//	@Param			entity_type	query		string	false	"Only names of this kind of entity"	Enums(country, language)
+//	@Param			entity_id	query		int		false	"Only names of this entity"
+//	@Param			locale		query		string	false	"Only names in this locale"
+//	@Success		200			{array}		LocalizedNameResponse
+//	@Failure		400			{string}	string	"Invalid entity_id"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/localized-name [get]
+func getLocalizedNames(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := sqlc.GetLocalizedNamesParams{
+		EntityType: sql.NullString{String: query.Get("entity_type"), Valid: query.Get("entity_type") != ""},
+		Locale:     sql.NullString{String: query.Get("locale"), Valid: query.Get("locale") != ""},
+	}
+	if s := query.Get("entity_id"); s != "" {
+		entityID, err := strconv.Atoi(s)
+		if err != nil {
+			http.Error(w, "Invalid entity_id", http.StatusBadRequest)
+			return
+		}
+		params.EntityID = sql.NullInt32{Int32: int32(entityID), Valid: true}
+	}
+
+	rows, err := queries.GetLocalizedNames(r.Context(), params)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := []LocalizedNameResponse{}
+	for _, row := range rows {
+		response = append(response, localizedNameResponse(row))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getLocalizedNameByID returns a single localized name
+//
+//	@Summary		Get a localized name
+//	@Description	Get a localized name by ID
+//	@tags			Localized names
+//	@Produce		json
+//	@Param			id				path		int		true	"Localized name ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a copy of the localized name, to get 304 if it has not changed"
+//	@Success		200				{object}	LocalizedNameResponse
+//	@Success		304				{string}	string	"Not modified"
+//	@Header			200				{string}	ETag	"When the localized name last changed, for If-Match and If-None-Match"
+//	@Failure		404				{string}	string	"Localized name not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/localized-name/{id} [get]
+func getLocalizedNameByID(w http.ResponseWriter, r *http.Request, id int32) {
+	row, err := queries.GetLocalizedNameByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Localized name not found", http.StatusNotFound)
+		return
+	}
+
+	if notModified(w, r, timeETag(row.UpdatedAt)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(localizedNameResponse(row)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// postLocalizedName adds the name of a country or language in a locale
+//
+//	@Summary		Create a localized name
+//	@Description	Add the name of a country or language in a locale. The locale is stored in canonical hreflang case.
+//	@tags			Localized names
+//	@Accept			json
+//	@Produce		json
+//	@Param			localizedName	body		LocalizedNameRequest	true	"Localized name"
+//	@Param			Idempotency-Key	header		string					false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{object}	LocalizedNameResponse
+//	@Failure		400				{string}	string	"Invalid request payload"
+//	@Failure		409				{string}	string	"The entity already has a name in this locale, or a request with this Idempotency-Key is still being processed"
+//	@Failure		422				{string}	string	"Idempotency-Key already used with a different request"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/localized-name [post]
+func postLocalizedName(w http.ResponseWriter, r *http.Request) {
+	var req LocalizedNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	req, err := validateLocalizedName(r, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	id, err := qtx.InsertLocalizedName(r.Context(), sqlc.InsertLocalizedNameParams{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Locale:     req.Locale,
+		Name:       req.Name,
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "The entity already has a name in this locale", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := LocalizedNameResponse{
+		ID:         id,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Locale:     req.Locale,
+		Name:       req.Name,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// updateLocalizedName replaces a localized name
+//
+//	@Summary		Update a localized name
+//	@Description	Replace the locale and name of a localized name. The entity cannot be changed.
+//	@tags			Localized names
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int						true	"Localized name ID"
+//	@Param			If-Match		header		string					true	"ETag of the localized name"
+//	@Param			localizedName	body		LocalizedNameRequest	true	"Localized name"
+//	@Success		200				{object}	LocalizedNameResponse
+//	@Failure		400				{string}	string	"Invalid request payload"
+//	@Failure		404				{string}	string	"Localized name not found"
+//	@Failure		409				{string}	string	"The entity already has a name in this locale"
+//	@Failure		412				{string}	string	"The localized name has changed since its ETag"
+//	@Failure		428				{string}	string	"If-Match header required"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/localized-name/{id} [put]
+func updateLocalizedName(w http.ResponseWriter, r *http.Request, id int32) {
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	var req LocalizedNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	existing, err := queries.GetLocalizedNameByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Localized name not found", http.StatusNotFound)
+		return
+	}
+	req.EntityType = existing.EntityType
+	req.EntityID = existing.EntityID
+
+	req, err = validateLocalizedName(r, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Localized name not found", qtx, lockLocalizedName(r, id)) {
+		return
+	}
+
+	affected, err := qtx.UpdateLocalizedName(r.Context(), sqlc.UpdateLocalizedNameParams{
+		ID:     id,
+		Locale: req.Locale,
+		Name:   req.Name,
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "The entity already has a name in this locale", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Localized name not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	getLocalizedNameByID(w, r, id)
+}
+
+// deleteLocalizedName removes a localized name
+//
+//	@Summary		Delete a localized name
+//	@Description	Remove the name of a country or language in a locale
+//	@tags			Localized names
+//	@Param			id			path		int		true	"Localized name ID"
+//	@Param			If-Match	header		string	true	"ETag of the localized name"
+//	@Success		204			{string}	string	"Deleted"
+//	@Failure		404			{string}	string	"Localized name not found"
+//	@Failure		412			{string}	string	"The localized name has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/localized-name/{id} [delete]
+func deleteLocalizedName(w http.ResponseWriter, r *http.Request, id int32) {
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Localized name not found", qtx, lockLocalizedName(r, id)) {
+		return
+	}
+
+	affected, err := qtx.DeleteLocalizedName(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Localized name not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockLocalizedName returns a function locking localized name id in the
+// transaction of its queries and returning its ETag.
+func lockLocalizedName(r *http.Request, id int32) func(*sqlc.Queries) (string, error) {
+	return func(qtx *sqlc.Queries) (string, error) {
+		updatedAt, err := qtx.LockLocalizedName(r.Context(), id)
+		return timeETag(updatedAt), err
+	}
+}
+
+func localizedNameResponse(row sqlc.LocalizedName) LocalizedNameResponse {
+	return LocalizedNameResponse{
+		ID:         row.ID,
+		EntityType: row.EntityType,
+		EntityID:   row.EntityID,
+		Locale:     row.Locale,
+		Name:       row.Name,
+	}
+}
+
+// validateLocalizedName checks that the named entity exists and has not been
+// deleted, since localized_name has no foreign key to it, and returns req with
+// its locale in canonical case.
+func validateLocalizedName(r *http.Request, req LocalizedNameRequest) (LocalizedNameRequest, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return req, errors.New("name is required")
+	}
+
+	tag, err := hreflang.Parse(req.Locale)
+	if err != nil {
+		return req, err
+	}
+	req.Locale = tag.String()
+
+	var deleted sql.NullTime
+	switch req.EntityType {
+	case "country":
+		country, err := queries.GetCountryById(r.Context(), req.EntityID)
+		if err != nil {
+			return req, errors.New("country not found")
+		}
+		deleted = country.DeletedAt
+	case "language":
+		language, err := queries.GetLanguageTagByID(r.Context(), req.EntityID)
+		if err != nil {
+			return req, errors.New("language not found")
+		}
+		deleted = language.DeletedAt
+	default:
+		return req, errors.New("entity_type must be country or language")
+	}
+	if deleted.Valid {
+		return req, errors.New(req.EntityType + " has been deleted")
+	}
+
+	return req, nil
+}
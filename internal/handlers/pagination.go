@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/config"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+type pageDirection string
+
+const (
+	pageNext pageDirection = "next"
+	pagePrev pageDirection = "prev"
+)
+
+var errInvalidPageToken = errors.New("invalid page_token")
+
+// pageCursor is the content of a page token. ID, or the sort keys in Values
+// for sortable lists, identify the last row of the previous page when going
+// forward, and the first row of the next page when going backward. Scope
+// ties the token to the filters and sort it was issued for.
+type pageCursor struct {
+	Direction pageDirection `json:"d"`
+	ID        int32         `json:"id,omitempty"`
+	Values    []string      `json:"v,omitempty"`
+	Scope     string        `json:"s,omitempty"`
+}
+
+// pageInfo holds the envelope fields shared by paginated responses.
+type pageInfo struct {
+	NextPageToken string
+	PrevPageToken string
+	TotalCount    *int64
+}
+
+// parsePageSize returns the page_size parameter clamped to maxPageSize, or
+// defaultPageSize when it is missing or invalid.
+func parsePageSize(value string) int {
+	pageSize, err := strconv.Atoi(value)
+	if err != nil || pageSize <= 0 {
+		return defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		return maxPageSize
+	}
+	return pageSize
+}
+
+// parseIncludeTotalCount reads the include_total_count parameter.
+func parseIncludeTotalCount(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// fetchPage runs a sorted and filtered list query for the page requested by
+// r. It writes the error response itself and reports false on failure.
+func fetchPage[T any](w http.ResponseWriter, r *http.Request, res listquery.Resource, q listquery.Query, fields func(*T) []any) ([]T, pageInfo, bool) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	pageSize := parsePageSize(query.Get("page_size"))
+
+	includeTotalCount, err := parseIncludeTotalCount(query.Get("include_total_count"))
+	if err != nil {
+		http.Error(w, "Invalid include_total_count", http.StatusBadRequest)
+		return nil, pageInfo{}, false
+	}
+
+	scope := pageScope(res.From + "?" + q.String())
+
+	var cursor *listquery.Cursor
+	if token := query.Get("page_token"); token != "" {
+		c, err := decodePageToken(token, scope)
+		if err != nil || len(c.Values) == 0 {
+			http.Error(w, "Invalid page_token", http.StatusBadRequest)
+			return nil, pageInfo{}, false
+		}
+		cursor = &listquery.Cursor{Backward: c.Direction == pagePrev, Values: c.Values}
+	}
+
+	page, err := listquery.Fetch(ctx, database, res, q, cursor, pageSize, fields)
+	if errors.Is(err, listquery.ErrInvalid) {
+		http.Error(w, "Invalid page_token", http.StatusBadRequest)
+		return nil, pageInfo{}, false
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return nil, pageInfo{}, false
+	}
+
+	var info pageInfo
+	if page.Next != nil {
+		info.NextPageToken = encodePageToken(pageCursor{Direction: pageNext, Values: page.Next.Values, Scope: scope})
+	}
+	if page.Prev != nil {
+		info.PrevPageToken = encodePageToken(pageCursor{Direction: pagePrev, Values: page.Prev.Values, Scope: scope})
+	}
+
+	if includeTotalCount {
+		count, err := listquery.Count(ctx, database, res, q)
+		if err != nil {
+			http.Error(w, "Database query error", http.StatusInternalServerError)
+			return nil, pageInfo{}, false
+		}
+		info.TotalCount = &count
+	}
+
+	return page.Items, info, true
+}
+
+// pageScope shortens a canonical query string to keep tokens small.
+func pageScope(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// encodePageToken returns an opaque token for c, signed so that clients
+// cannot forge or alter it.
+func encodePageToken(c pageCursor) string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signPageToken(payload))
+}
+
+// decodePageToken verifies token and checks it was issued for scope.
+func decodePageToken(token, scope string) (pageCursor, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, signPageToken(payload)) {
+		return pageCursor{}, errInvalidPageToken
+	}
+
+	var c pageCursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return pageCursor{}, errInvalidPageToken
+	}
+	if c.Direction != pageNext && c.Direction != pagePrev || c.Scope != scope {
+		return pageCursor{}, errInvalidPageToken
+	}
+	return c, nil
+}
+
+func signPageToken(payload []byte) []byte {
+	mac := hmac.New(sha256.New, config.GetPageTokenSecret())
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
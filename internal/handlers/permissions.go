@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/auth"
+)
+
+// editable lets viewers read a resource and role change it.
+func editable(role auth.Role) map[auth.Action]auth.Role {
+	return map[auth.Action]auth.Role{
+		auth.ActionRead:   auth.RoleViewer,
+		auth.ActionWrite:  role,
+		auth.ActionDelete: role,
+	}
+}
+
+var readOnly = map[auth.Action]auth.Role{auth.ActionRead: auth.RoleViewer}
+
+// permissions is who may do what. Editors manage the data that changes with
+// each site; the ISO reference data and its localized names, bulk imports and API keys are for
+// admins, as are the webhooks that changes are sent to.
+var permissions = auth.Policy{
+	"api_key":        {auth.ActionRead: auth.RoleAdmin, auth.ActionWrite: auth.RoleAdmin, auth.ActionDelete: auth.RoleAdmin},
+	"audit":          {auth.ActionRead: auth.RoleEditor},
+	"changes":        readOnly,
+	"country":        editable(auth.RoleAdmin),
+	"diff":           readOnly,
+	"export":         readOnly,
+	"hreflang":       readOnly,
+	"import":         {auth.ActionWrite: auth.RoleAdmin},
+	"language":       editable(auth.RoleAdmin),
+	"locale_url":     editable(auth.RoleEditor),
+	"localized_name": editable(auth.RoleAdmin),
+	"redirect":       editable(auth.RoleEditor),
+	"search":         readOnly,
+	"site":           editable(auth.RoleEditor),
+	"sitemap":        readOnly,
+	"slug":           editable(auth.RoleEditor),
+	"url":            readOnly,
+	"variant":        editable(auth.RoleEditor),
+	"webhook":        {auth.ActionRead: auth.RoleAdmin, auth.ActionWrite: auth.RoleAdmin, auth.ActionDelete: auth.RoleAdmin},
+}
+
+// resourceRoute maps the paths under prefix to a resource. Routes marked
+// readOnly only compute a response, so a POST to them is a read.
+type resourceRoute struct {
+	prefix   string
+	resource string
+	readOnly bool
+}
+
+// resourceRoutes are matched in order, so more specific prefixes come first.
+var resourceRoutes = []resourceRoute{
+	{prefix: "/api-keys", resource: "api_key"},
+	{prefix: "/audit", resource: "audit"},
+	{prefix: "/changes", resource: "changes"},
+	{prefix: "/country", resource: "country"},
+	{prefix: "/diff", resource: "diff", readOnly: true},
+	{prefix: "/export", resource: "export"},
+	{prefix: "/hreflang", resource: "hreflang", readOnly: true},
+	{prefix: "/import", resource: "import"},
+	{prefix: "/language-variant", resource: "variant"},
+	{prefix: "/language", resource: "language"},
+	{prefix: "/locale-url", resource: "locale_url"},
+	{prefix: "/localized-name", resource: "localized_name"},
+	{prefix: "/redirects/test", resource: "redirect", readOnly: true},
+	{prefix: "/redirects", resource: "redirect"},
+	{prefix: "/search", resource: "search"},
+	{prefix: "/site", resource: "site"},
+	{prefix: "/sitemap", resource: "sitemap", readOnly: true},
+	{prefix: "/slug/suggest", resource: "slug", readOnly: true},
+	{prefix: "/slug", resource: "slug"},
+	{prefix: "/url", resource: "url", readOnly: true},
+	{prefix: "/webhooks", resource: "webhook"},
+}
+
+// requestPermission returns the resource a request acts on and how, or false
+// for paths that are not a resource, such as /me/permissions.
+func requestPermission(r *http.Request) (string, auth.Action, bool) {
+	for _, route := range resourceRoutes {
+		if r.URL.Path != route.prefix && !strings.HasPrefix(r.URL.Path, route.prefix+"/") {
+			continue
+		}
+		if route.readOnly {
+			return route.resource, auth.ActionRead, true
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return route.resource, auth.ActionRead, true
+		case http.MethodDelete:
+			return route.resource, auth.ActionDelete, true
+		default:
+			return route.resource, auth.ActionWrite, true
+		}
+	}
+	return "", "", false
+}
+
+// requestRole is the role of the caller. Reads let through by
+// AUTH_PUBLIC_READS are a viewer's.
+func requestRole(r *http.Request) auth.Role {
+	if p, ok := PrincipalFromContext(r.Context()); ok {
+		return p.Role
+	}
+	return auth.RoleViewer
+}
+
+// Authorize rejects requests the caller's role is not allowed by
+// permissions. It runs inside Authenticate.
+func Authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, action, ok := requestPermission(r)
+		if ok {
+			if role := requestRole(r); !permissions.Allows(role, resource, action) {
+				http.Error(w, fmt.Sprintf("Role %s may not %s %s", role, action, resource), http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type PermissionsResponse struct {
+	Authenticated bool   `json:"authenticated"`
+	Kind          string `json:"kind,omitempty"`
+	Subject       string `json:"subject,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Role          string `json:"role"`
+	// Permissions lists the actions, read, write and delete, allowed on each
+	// resource; resources the caller can do nothing with are left out.
+	Permissions map[string][]auth.Action `json:"permissions"`
+}
+
+// MePermissionsHandler returns what the caller may do
+//
+//	@Summary		Get the caller's permissions
+//	@Description	Get the role of the caller and the actions it may perform on each resource, so that clients can hide what the caller cannot use
+//	@tags			Auth
+//	@Produce		json
+//	@Success		200	{object}	PermissionsResponse
+//	@Failure		405	{string}	string	"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/me/permissions [get]
+func MePermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	role := requestRole(r)
+	response := PermissionsResponse{
+		Role:        string(role),
+		Permissions: permissions.Permissions(role),
+	}
+	if p, ok := PrincipalFromContext(r.Context()); ok {
+		response.Authenticated = true
+		response.Kind = p.Kind
+		response.Subject = p.Subject
+		response.Name = p.Name
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
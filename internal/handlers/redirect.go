@@ -0,0 +1,623 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/redirect"
+)
+
+type RedirectRequest struct {
+	Locale     string     `json:"locale"`
+	MatchType  string     `json:"match_type" enums:"exact,prefix,regex" default:"exact"`
+	SourcePath string     `json:"source_path"`
+	TargetPath string     `json:"target_path"`
+	StatusCode int32      `json:"status_code" enums:"301,302,307,308" default:"301"`
+	Priority   int32      `json:"priority"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+type RedirectResponse struct {
+	ID         int32      `json:"id"`
+	Locale     string     `json:"locale"`
+	MatchType  string     `json:"match_type"`
+	SourcePath string     `json:"source_path"`
+	TargetPath string     `json:"target_path"`
+	StatusCode int32      `json:"status_code"`
+	Priority   int32      `json:"priority"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type RedirectTestRequest struct {
+	URL    string `json:"url"`
+	Locale string `json:"locale"`
+}
+
+type RedirectHop struct {
+	RuleID     int32  `json:"rule_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	StatusCode int    `json:"status_code"`
+}
+
+type RedirectTestResponse struct {
+	Result redirect.Result `json:"result"`
+	Chain  []RedirectHop   `json:"chain"`
+	Loop   bool            `json:"loop"`
+}
+
+// RedirectHandler handles requests related to redirect rules
+//
+//	@Summary		Handles redirect rules
+//	@Description	List, get, create, update or delete redirect rules
+//	@tags			Redirects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	false	"Redirect ID"
+//	@Success		200	{object}	RedirectResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func RedirectHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	switch path {
+	case "/redirects", "/redirects/":
+		switch r.Method {
+		case http.MethodGet:
+			getRedirects(w, r)
+		case http.MethodPost:
+			postRedirect(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	case "/redirects/test":
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		testRedirects(w, r)
+		return
+	case "/redirects/export":
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		exportRedirects(w, r)
+		return
+	}
+
+	idStr := strings.TrimPrefix(path, "/redirects/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		getRedirectByID(w, r, int32(id))
+	case http.MethodPut:
+		updateRedirect(w, r, int32(id))
+	case http.MethodDelete:
+		deleteRedirect(w, r, int32(id))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getRedirects lists redirect rules
+//
+//	@Summary		List redirects
+//	@Description	List redirect rules in priority order, including those created by slug changes, optionally for a single locale
+//	@tags			Redirects
+//	@Produce		json
+//	@Param			locale	query		string	false	"Locale"
+//	@Success		200		{array}		RedirectResponse
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects [get]
+func getRedirects(w http.ResponseWriter, r *http.Request) {
+	locale := r.URL.Query().Get("locale")
+
+	redirects, err := queries.GetRedirects(r.Context(), sql.NullString{String: locale, Valid: locale != ""})
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := []RedirectResponse{}
+	for _, rd := range redirects {
+		response = append(response, toRedirectResponse(rd))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getRedirectByID returns a redirect rule
+//
+//	@Summary		Get a redirect
+//	@Description	Get a redirect rule by ID
+//	@tags			Redirects
+//	@Produce		json
+//	@Param			id				path		int		true	"Redirect ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a copy of the redirect, to get 304 if it has not changed"
+//	@Success		200				{object}	RedirectResponse
+//	@Success		304				{string}	string	"Not modified"
+//	@Header			200				{string}	ETag	"When the redirect last changed, for If-Match and If-None-Match"
+//	@Failure		404				{string}	string	"Redirect not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects/{id} [get]
+func getRedirectByID(w http.ResponseWriter, r *http.Request, id int32) {
+	rd, err := queries.GetRedirectByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Redirect not found", http.StatusNotFound)
+		return
+	}
+
+	if notModified(w, r, timeETag(rd.UpdatedAt)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toRedirectResponse(rd)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// postRedirect creates a redirect rule
+//
+//	@Summary		Create a redirect
+//	@Description	Create an exact, prefix or regex redirect rule. A {locale} placeholder in the target is replaced by the request's locale.
+//	@tags			Redirects
+//	@Accept			json
+//	@Produce		json
+//	@Param			redirect		body		RedirectRequest	true	"Redirect rule"
+//	@Param			Idempotency-Key	header		string			false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{object}	RedirectResponse
+//	@Failure		400				{string}	string	"Invalid request payload"
+//	@Failure		409				{string}	string	"Redirect already exists, or a request with this Idempotency-Key is still being processed"
+//	@Failure		422				{string}	string	"Redirect would create a loop, or Idempotency-Key already used with a different request"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects [post]
+func postRedirect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, ok := decodeRedirectRequest(w, r)
+	if !ok {
+		return
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !checkRedirectLoops(w, r, qtx, 0, req) {
+		return
+	}
+
+	id, err := qtx.InsertRedirect(ctx, sqlc.InsertRedirectParams{
+		Locale:     req.Locale,
+		MatchType:  req.MatchType,
+		SourcePath: req.SourcePath,
+		TargetPath: req.TargetPath,
+		StatusCode: req.StatusCode,
+		Priority:   req.Priority,
+		ExpiresAt:  nullTime(req.ExpiresAt),
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "Redirect already exists", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	rd, err := queries.GetRedirectByID(ctx, id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve inserted redirect", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(toRedirectResponse(rd)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// updateRedirect replaces a redirect rule
+//
+//	@Summary		Update a redirect
+//	@Description	Replace a redirect rule
+//	@tags			Redirects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int				true	"Redirect ID"
+//	@Param			If-Match	header		string			true	"ETag of the redirect"
+//	@Param			redirect	body		RedirectRequest	true	"Redirect rule"
+//	@Success		200			{object}	RedirectResponse
+//	@Failure		400			{string}	string	"Invalid request payload"
+//	@Failure		404			{string}	string	"Redirect not found"
+//	@Failure		409			{string}	string	"Redirect already exists"
+//	@Failure		412			{string}	string	"The redirect has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		422			{string}	string	"Redirect would create a loop"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects/{id} [put]
+func updateRedirect(w http.ResponseWriter, r *http.Request, id int32) {
+	ctx := r.Context()
+
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	req, ok := decodeRedirectRequest(w, r)
+	if !ok {
+		return
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Redirect not found", qtx, lockRedirect(r, id)) {
+		return
+	}
+	if !checkRedirectLoops(w, r, qtx, id, req) {
+		return
+	}
+
+	affected, err := qtx.UpdateRedirect(ctx, sqlc.UpdateRedirectParams{
+		ID:         id,
+		Locale:     req.Locale,
+		MatchType:  req.MatchType,
+		SourcePath: req.SourcePath,
+		TargetPath: req.TargetPath,
+		StatusCode: req.StatusCode,
+		Priority:   req.Priority,
+		ExpiresAt:  nullTime(req.ExpiresAt),
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "Redirect already exists", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Redirect not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	rd, err := queries.GetRedirectByID(ctx, id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve updated redirect", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", timeETag(rd.UpdatedAt))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toRedirectResponse(rd)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// deleteRedirect removes a redirect rule
+//
+//	@Summary		Delete a redirect
+//	@Description	Delete a redirect rule
+//	@tags			Redirects
+//	@Param			id			path		int		true	"Redirect ID"
+//	@Param			If-Match	header		string	true	"ETag of the redirect"
+//	@Success		204			{string}	string	"Deleted"
+//	@Failure		404			{string}	string	"Redirect not found"
+//	@Failure		412			{string}	string	"The redirect has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects/{id} [delete]
+func deleteRedirect(w http.ResponseWriter, r *http.Request, id int32) {
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Redirect not found", qtx, lockRedirect(r, id)) {
+		return
+	}
+
+	affected, err := qtx.DeleteRedirect(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if affected == 0 {
+		http.Error(w, "Redirect not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// testRedirects evaluates a URL against the rule set
+//
+//	@Summary		Test a URL against the redirect rules
+//	@Description	Evaluate a URL or path for a locale, returning the matching rule with a trace of every rule considered and the full redirect chain
+//	@tags			Redirects
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		RedirectTestRequest	true	"URL and locale"
+//	@Success		200		{object}	RedirectTestResponse
+//	@Failure		400		{string}	string	"Invalid request payload"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects/test [post]
+func testRedirects(w http.ResponseWriter, r *http.Request) {
+	var req RedirectTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil || u.Path == "" {
+		http.Error(w, "Invalid url", http.StatusBadRequest)
+		return
+	}
+
+	engine, err := loadRedirectEngine(r.Context(), queries, 0, nil)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	hops, err := engine.Follow(u.Path, req.Locale, now)
+
+	response := RedirectTestResponse{
+		Result: engine.Evaluate(u.Path, req.Locale, now),
+		Chain:  []RedirectHop{},
+		Loop:   errors.Is(err, redirect.ErrLoop),
+	}
+	from := u.Path
+	for _, hop := range hops {
+		response.Chain = append(response.Chain, RedirectHop{
+			RuleID:     hop.Rule.ID,
+			From:       from,
+			To:         hop.Target,
+			StatusCode: hop.StatusCode,
+		})
+		from = hop.Target
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// exportRedirects renders the rule set as server configuration
+//
+//	@Summary		Export redirects
+//	@Description	Export the active redirect rules as an nginx map, Apache RewriteRules or JSON.
+//	@Description	Without a locale only rules that apply to every locale are exported.
+//	@tags			Redirects
+//	@Produce		plain
+//	@Produce		json
+//	@Param			format	query		string	false	"Export format"	Enums(nginx, apache, json)	default(json)
+//	@Param			locale	query		string	false	"Locale to export rules for"
+//	@Success		200		{string}	string	"Exported configuration"
+//	@Failure		400		{string}	string	"Unknown export format"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/redirects/export [get]
+func exportRedirects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = redirect.FormatJSON
+	}
+
+	engine, err := loadRedirectEngine(r.Context(), queries, 0, nil)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	out, err := engine.Export(format, query.Get("locale"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if format == redirect.FormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Write(out)
+}
+
+func decodeRedirectRequest(w http.ResponseWriter, r *http.Request) (RedirectRequest, bool) {
+	var req RedirectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return req, false
+	}
+	if req.MatchType == "" {
+		req.MatchType = string(redirect.Exact)
+	}
+	if req.StatusCode == 0 {
+		req.StatusCode = http.StatusMovedPermanently
+	}
+
+	if err := toRedirectRule(0, req).Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+
+	return req, true
+}
+
+// checkRedirectLoops rejects req when, replacing the rule with the given id
+// (or added as a new rule when id is 0), it would make the rule set loop in
+// any configured locale. It locks the rule set for the rest of the
+// transaction of qtx, so that concurrent writes cannot form a loop between
+// them.
+func checkRedirectLoops(w http.ResponseWriter, r *http.Request, qtx *sqlc.Queries, id int32, req RedirectRequest) bool {
+	ctx := r.Context()
+	candidate := toRedirectRule(id, req)
+
+	if err := qtx.LockRedirectRules(ctx); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	engine, err := loadRedirectEngine(ctx, qtx, id, &candidate)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	locales, err := qtx.GetLocaleURLLocales(ctx)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+
+	if err := engine.CheckLoops(time.Now(), locales); err != nil {
+		http.Error(w, "Redirect would create a loop: "+err.Error(), http.StatusUnprocessableEntity)
+		return false
+	}
+
+	return true
+}
+
+// loadRedirectEngine builds an engine from the rules stored in q. When
+// candidate is set it replaces the rule with the given id, or is added when id
+// is 0.
+func loadRedirectEngine(ctx context.Context, q *sqlc.Queries, id int32, candidate *redirect.Rule) (*redirect.Engine, error) {
+	stored, err := q.GetRedirects(ctx, sql.NullString{})
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []redirect.Rule
+	for _, rd := range stored {
+		if candidate != nil && rd.ID == id {
+			continue
+		}
+		rules = append(rules, redirect.Rule{
+			ID:         rd.ID,
+			Locale:     rd.Locale,
+			MatchType:  redirect.MatchType(rd.MatchType),
+			Source:     rd.SourcePath,
+			Target:     rd.TargetPath,
+			StatusCode: int(rd.StatusCode),
+			Priority:   int(rd.Priority),
+			ExpiresAt:  timePtr(rd.ExpiresAt),
+		})
+	}
+	if candidate != nil {
+		rules = append(rules, *candidate)
+	}
+
+	return redirect.NewEngine(rules)
+}
+
+func toRedirectRule(id int32, req RedirectRequest) redirect.Rule {
+	return redirect.Rule{
+		ID:         id,
+		Locale:     req.Locale,
+		MatchType:  redirect.MatchType(req.MatchType),
+		Source:     req.SourcePath,
+		Target:     req.TargetPath,
+		StatusCode: int(req.StatusCode),
+		Priority:   int(req.Priority),
+		ExpiresAt:  req.ExpiresAt,
+	}
+}
+
+// lockRedirect returns a function locking redirect id in the transaction of
+// its queries and returning its ETag.
+func lockRedirect(r *http.Request, id int32) func(*sqlc.Queries) (string, error) {
+	return func(qtx *sqlc.Queries) (string, error) {
+		updatedAt, err := qtx.LockRedirect(r.Context(), id)
+		return timeETag(updatedAt), err
+	}
+}
+
+func toRedirectResponse(rd sqlc.Redirect) RedirectResponse {
+	return RedirectResponse{
+		ID:         rd.ID,
+		Locale:     rd.Locale,
+		MatchType:  rd.MatchType,
+		SourcePath: rd.SourcePath,
+		TargetPath: rd.TargetPath,
+		StatusCode: rd.StatusCode,
+		Priority:   rd.Priority,
+		ExpiresAt:  timePtr(rd.ExpiresAt),
+		CreatedAt:  rd.CreatedAt,
+		UpdatedAt:  rd.UpdatedAt,
+	}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+)
+
+const (
+	defaultSearchLimit = 5
+	maxSearchLimit     = 50
+	maxSearchLength    = 200
+	maxSearchTerms     = 10
+)
+
+var searchTypes = []string{"country", "language", "variant"}
+
+type SearchResult struct {
+	Type  string `json:"type" enums:"country,language,variant"`
+	ID    int32  `json:"id"`
+	Label string `json:"label"`
+	// Field is the field that matched best, e.g. name, iso_639_1 or
+	// localized_name.
+	Field  string `json:"field"`
+	Locale string `json:"locale,omitempty"`
+	Value  string `json:"value"`
+	// Highlight is Value with matched words wrapped in <mark> tags.
+	Highlight string  `json:"highlight"`
+	Score     float64 `json:"score"`
+}
+
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// SearchHandler godoc
+//
+//	@Summary		Search countries, languages and variants
+//	@Description	Fuzzy, accent-insensitive search over names, localized names, ISO codes, TLDs and variant descriptions.
+//	@Description	Combines trigram similarity, substring and prefix matching and full-text search, and returns the best matching field of each entity, ranked by score.
+//	@Tags			Search
+//	@Produce		json
+//	@Param			q		query		string		true	"Search text"
+//	@Param			types	query		[]string	false	"Entity types to search"					Enums(country, language, variant)
+//	@Param			limit	query		int			false	"Maximum number of results per type"	default(5)	maximum(50)
+//	@Success		200		{object}	SearchResponse
+//	@Failure		400		{string}	string	"Invalid q, types or limit parameter"
+//	@Failure		405		{string}	string	"Method not allowed"
+//	@Failure		500		{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/search [get]
+func SearchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" || utf8.RuneCountInString(q) > maxSearchLength {
+		http.Error(w, "Invalid q parameter", http.StatusBadRequest)
+		return
+	}
+
+	tsquery := prefixTSQuery(q)
+	if tsquery == "" {
+		http.Error(w, "Invalid q parameter: no letters or digits", http.StatusBadRequest)
+		return
+	}
+
+	types := []string{}
+	for _, v := range query["types"] {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(searchTypes, t) {
+				http.Error(w, "Invalid types parameter", http.StatusBadRequest)
+				return
+			}
+			types = append(types, t)
+		}
+	}
+
+	limit := defaultSearchLimit
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxSearchLimit {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	rows, err := queries.Search(r.Context(), sqlc.SearchParams{
+		Q:            q,
+		Tsquery:      tsquery,
+		Types:        types,
+		PerTypeLimit: int32(limit),
+	})
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := SearchResponse{Query: q, Results: []SearchResult{}}
+	for _, row := range rows {
+		response.Results = append(response.Results, SearchResult{
+			Type:      row.EntityType,
+			ID:        row.EntityID,
+			Label:     row.Label,
+			Field:     row.Field,
+			Locale:    row.Locale.String,
+			Value:     row.Value,
+			Highlight: row.Highlight,
+			Score:     row.Score,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// prefixTSQuery turns free text into a tsquery matching every word as a
+// prefix, e.g. "portug bras" becomes "portug:* & bras:*". Only letters and
+// digits are kept, so the result is always valid tsquery syntax.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+type SiteRequest struct {
+	Name          string `json:"name"`
+	Scheme        string `json:"scheme"`
+	Host          string `json:"host"`
+	QueryParam    string `json:"query_param"`
+	DefaultLocale string `json:"default_locale"`
+}
+
+type SiteResponse struct {
+	ID            int32  `json:"id"`
+	Name          string `json:"name"`
+	Scheme        string `json:"scheme"`
+	Host          string `json:"host"`
+	QueryParam    string `json:"query_param"`
+	DefaultLocale string `json:"default_locale,omitempty"`
+}
+
+// SiteHandler godoc
+//
+//	@Summary		Manage sites
+//	@Description	Endpoint to handle operations on sites by method
+//	@Tags			Sites
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				false	"Site ID"
+//	@Success		200	{object}	urlpattern.Site	"Site with its locale URL configuration"
+//	@Failure		400	{string}	string			"Invalid item ID"
+//	@Failure		405	{string}	string			"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/site/{id} [get]
+//	@Router			/site [post]
+func SiteHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		path := r.URL.Path
+
+		if path == "/site" || path == "/site/" {
+			getAllSites(w, r)
+			return
+		}
+
+		idStr := strings.TrimPrefix(path, "/site/")
+
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			http.Error(w, "Invalid item ID", http.StatusBadRequest)
+			return
+		}
+
+		getSiteByID(w, r, int32(id))
+	case http.MethodPost:
+		createSite(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getAllSites godoc
+//
+//	@Summary		Get all sites
+//	@Description	Retrieve all sites without their locale configuration
+//	@Tags			Sites
+//	@Produce		json
+//	@Success		200	{array}		SiteResponse	"List of sites"
+//	@Failure		500	{string}	string			"Failed to get sites"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/site [get]
+func getAllSites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sites, err := queries.GetAllSites(ctx)
+	if err != nil {
+		http.Error(w, "Failed to get sites", http.StatusInternalServerError)
+		return
+	}
+
+	result := []SiteResponse{}
+	for _, site := range sites {
+		result = append(result, SiteResponse{
+			ID:            site.ID,
+			Name:          site.Name,
+			Scheme:        site.Scheme,
+			Host:          site.Host,
+			QueryParam:    site.QueryParam,
+			DefaultLocale: site.DefaultLocale.String,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getSiteByID godoc
+//
+//	@Summary		Get site by ID
+//	@Description	Retrieve a site together with the URL strategy of each of its locales
+//	@Tags			Sites
+//	@Produce		json
+//	@Param			id	path		int				true	"Site ID"
+//	@Success		200	{object}	urlpattern.Site	"Site with its locale URL configuration"
+//	@Failure		404	{string}	string			"Site not found"
+//	@Failure		500	{string}	string			"Failed to get site locales"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/site/{id} [get]
+func getSiteByID(w http.ResponseWriter, r *http.Request, id int32) {
+	site, err := LoadSite(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Site not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to get site locales", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(site); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// createSite godoc
+//
+//	@Summary		Create a new site
+//	@Description	Insert a new site whose locale URLs can then be configured
+//	@Tags			Sites
+//	@Accept			json
+//	@Produce		json
+//	@Param			site			body		SiteRequest		true	"Site"
+//	@Param			Idempotency-Key	header		string			false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{object}	SiteResponse	"Created site"
+//	@Failure		400				{string}	string			"Invalid input"
+//	@Failure		409				{string}	string			"A request with this Idempotency-Key is still being processed"
+//	@Failure		422				{string}	string			"Idempotency-Key already used with a different request"
+//	@Failure		500				{string}	string			"Failed to insert site"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/site [post]
+func createSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var input SiteRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if input.Name == "" || input.Host == "" || strings.ContainsAny(input.Host, "/:") {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+	if input.Scheme == "" {
+		input.Scheme = "https"
+	}
+	if input.QueryParam == "" {
+		input.QueryParam = "lang"
+	}
+
+	params := sqlc.InsertSiteParams{
+		Name:          input.Name,
+		Scheme:        input.Scheme,
+		Host:          strings.ToLower(input.Host),
+		QueryParam:    input.QueryParam,
+		DefaultLocale: sql.NullString{String: input.DefaultLocale, Valid: input.DefaultLocale != ""},
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		http.Error(w, "Failed to insert site", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	siteID, err := qtx.InsertSite(ctx, params)
+	if err != nil {
+		http.Error(w, "Failed to insert site", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to insert site", http.StatusInternalServerError)
+		return
+	}
+
+	result := SiteResponse{
+		ID:            siteID,
+		Name:          params.Name,
+		Scheme:        params.Scheme,
+		Host:          params.Host,
+		QueryParam:    params.QueryParam,
+		DefaultLocale: input.DefaultLocale,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// LoadSite assembles the URL configuration of a site from its row and its
+// locale_url rows. It returns sql.ErrNoRows when the site does not exist.
+func LoadSite(ctx context.Context, id int32) (urlpattern.Site, error) {
+	site, err := queries.GetSiteByID(ctx, id)
+	if err != nil {
+		return urlpattern.Site{}, err
+	}
+
+	locales, err := queries.GetLocaleURLsBySiteID(ctx, id)
+	if err != nil {
+		return urlpattern.Site{}, err
+	}
+
+	result := urlpattern.Site{
+		ID:            site.ID,
+		Name:          site.Name,
+		Scheme:        site.Scheme,
+		Host:          site.Host,
+		QueryParam:    site.QueryParam,
+		DefaultLocale: site.DefaultLocale.String,
+		Locales:       []urlpattern.Locale{},
+	}
+	for _, l := range locales {
+		result.Locales = append(result.Locales, urlpattern.Locale{
+			Tag:      l.Locale,
+			Strategy: urlpattern.Strategy(l.Strategy),
+			Value:    l.Value,
+			TLD:      strings.Trim(l.Tld.String, "."),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/sitemap"
+)
+
+type SitemapRequest struct {
+	SiteID  int32    `json:"site_id"`
+	Paths   []string `json:"paths"`
+	Gzip    bool     `json:"gzip"`
+	BaseURL string   `json:"base_url"`
+}
+
+// SitemapHandler godoc
+//
+//	@Summary		Generate a multilingual sitemap
+//	@Description	Generate sitemap XML with xhtml:link alternates for every canonical path in every locale of a site.
+//	@Description	Paths are posted as JSON or uploaded as a multipart "paths" file with one path per line.
+//	@Description	A single sitemap is returned as XML; once the 50,000 URL / 50 MB limits are hit, a zip with a sitemap index and its sitemaps is returned instead.
+//	@Tags			URLs
+//	@Accept			json
+//	@Accept			mpfd
+//	@Produce		xml
+//	@Produce		application/gzip
+//	@Produce		application/zip
+//	@Param			request		body		SitemapRequest	false	"Site, paths and options"
+//	@Param			site_id		formData	int				false	"Site ID (multipart upload)"
+//	@Param			paths		formData	file			false	"Newline separated canonical paths (multipart upload)"
+//	@Param			gzip		formData	bool			false	"Compress the generated files (multipart upload)"
+//	@Param			base_url	formData	string			false	"URL the sitemap files are served from (multipart upload)"
+//	@Success		200			{file}		file			"Sitemap, or zip of the sitemap index and sitemaps"
+//	@Failure		400			{string}	string			"Invalid input"
+//	@Failure		404			{string}	string			"Site not found"
+//	@Failure		405			{string}	string			"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/sitemap [post]
+func SitemapHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input SitemapRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if !readSitemapUpload(w, r, &input) {
+			return
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	if len(input.Paths) == 0 {
+		http.Error(w, "At least one path is required", http.StatusBadRequest)
+		return
+	}
+
+	site, ok := siteForRequest(w, r, input.SiteID)
+	if !ok {
+		return
+	}
+
+	files, err := sitemap.Generate(site, input.Paths, sitemap.Options{
+		BaseURL: input.BaseURL,
+		Gzip:    input.Gzip,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(files) == 1 {
+		if input.Gzip {
+			w.Header().Set("Content-Type", "application/gzip")
+		} else {
+			w.Header().Set("Content-Type", "application/xml")
+		}
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+files[0].Name+"\"")
+		w.Write(files[0].Data)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"sitemaps.zip\"")
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.Name)
+		if err != nil {
+			return
+		}
+		if _, err := fw.Write(f.Data); err != nil {
+			return
+		}
+	}
+	zw.Close()
+}
+
+func readSitemapUpload(w http.ResponseWriter, r *http.Request, input *SitemapRequest) bool {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return false
+	}
+
+	siteID, err := strconv.Atoi(r.FormValue("site_id"))
+	if err != nil {
+		http.Error(w, "Invalid site_id", http.StatusBadRequest)
+		return false
+	}
+	input.SiteID = int32(siteID)
+	input.Gzip, _ = strconv.ParseBool(r.FormValue("gzip"))
+	input.BaseURL = r.FormValue("base_url")
+
+	file, _, err := r.FormFile("paths")
+	if err != nil {
+		http.Error(w, "Missing paths file", http.StatusBadRequest)
+		return false
+	}
+	defer file.Close()
+
+	input.Paths, err = sitemap.ReadPaths(file)
+	if err != nil {
+		http.Error(w, "Invalid paths file", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
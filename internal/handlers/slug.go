@@ -0,0 +1,513 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/slug"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+	"github.com/lib/pq"
+)
+
+type SlugRequest struct {
+	ResourceKey string `json:"resource_key"`
+	Locale      string `json:"locale"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+}
+
+type SlugUpdateRequest struct {
+	Slug string `json:"slug"`
+}
+
+type SlugHistoryEntry struct {
+	Slug       string    `json:"slug"`
+	ReplacedAt time.Time `json:"replaced_at"`
+}
+
+type SlugResponse struct {
+	ID          int32              `json:"id"`
+	ResourceKey string             `json:"resource_key"`
+	Locale      string             `json:"locale"`
+	Slug        string             `json:"slug"`
+	History     []SlugHistoryEntry `json:"history,omitempty"`
+}
+
+type SlugSuggestRequest struct {
+	Text   string `json:"text"`
+	Locale string `json:"locale"`
+}
+
+type SlugSuggestResponse struct {
+	Slug string `json:"slug"`
+}
+
+// SlugHandler handles requests related to localized slugs
+//
+//	@Summary		Handles localized slugs
+//	@Description	List, get, create or change the slug of a resource in a locale
+//	@tags			Slugs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	false	"Slug ID"
+//	@Success		200	{object}	SlugResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func SlugHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	if path == "/slug" || path == "/slug/" {
+		switch r.Method {
+		case http.MethodGet:
+			getSlugs(w, r)
+		case http.MethodPost:
+			postSlug(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	idStr := strings.TrimPrefix(path, "/slug/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		getSlugByID(w, r, int32(id))
+	case http.MethodPut:
+		updateSlug(w, r, int32(id))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getSlugs lists slugs
+//
+//	@Summary		List slugs
+//	@Description	List slugs, optionally filtered by locale and resource key
+//	@tags			Slugs
+//	@Produce		json
+//	@Param			locale			query		string	false	"Locale"
+//	@Param			resource_key	query		string	false	"Resource key"
+//	@Success		200				{array}		SlugResponse
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/slug [get]
+func getSlugs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	locale := query.Get("locale")
+	resourceKey := query.Get("resource_key")
+
+	rows, err := queries.GetSlugs(r.Context(), sqlc.GetSlugsParams{
+		Locale:      sql.NullString{String: locale, Valid: locale != ""},
+		ResourceKey: sql.NullString{String: resourceKey, Valid: resourceKey != ""},
+	})
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := []SlugResponse{}
+	for _, row := range rows {
+		response = append(response, SlugResponse{
+			ID:          row.ID,
+			ResourceKey: row.ResourceKey,
+			Locale:      row.Locale,
+			Slug:        row.Slug,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getSlugByID returns a slug with its history
+//
+//	@Summary		Get a slug
+//	@Description	Get a slug together with the slugs it replaced, newest first
+//	@tags			Slugs
+//	@Produce		json
+//	@Param			id				path		int		true	"Slug ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a copy of the slug, to get 304 if it has not changed"
+//	@Success		200				{object}	SlugResponse
+//	@Success		304				{string}	string	"Not modified"
+//	@Header			200				{string}	ETag	"When the slug last changed, for If-Match and If-None-Match"
+//	@Failure		404				{string}	string	"Slug not found"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/slug/{id} [get]
+func getSlugByID(w http.ResponseWriter, r *http.Request, id int32) {
+	ctx := r.Context()
+
+	row, err := queries.GetSlugByID(ctx, id)
+	if err != nil {
+		http.Error(w, "Slug not found", http.StatusNotFound)
+		return
+	}
+	if notModified(w, r, timeETag(row.UpdatedAt)) {
+		return
+	}
+
+	history, err := queries.GetSlugHistory(ctx, id)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := SlugResponse{
+		ID:          row.ID,
+		ResourceKey: row.ResourceKey,
+		Locale:      row.Locale,
+		Slug:        row.Slug,
+	}
+	for _, h := range history {
+		response.History = append(response.History, SlugHistoryEntry{Slug: h.Slug, ReplacedAt: h.ReplacedAt})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// postSlug registers the slug of a resource in a locale
+//
+//	@Summary		Create a slug
+//	@Description	Register the slug of a resource in a locale. When slug is omitted, one is suggested from title.
+//	@tags			Slugs
+//	@Accept			json
+//	@Produce		json
+//	@Param			slug			body		SlugRequest	true	"Slug"
+//	@Param			Idempotency-Key	header		string		false	"Makes retries safe: a retry with the same key and body gets the first response again"
+//	@Success		201				{object}	SlugResponse
+//	@Failure		400				{string}	string	"Invalid request payload"
+//	@Failure		409				{string}	string	"Slug already in use, or a request with this Idempotency-Key is still being processed"
+//	@Failure		422				{string}	string	"Idempotency-Key already used with a different request"
+//	@Failure		500				{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/slug [post]
+func postSlug(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req SlugRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if req.ResourceKey == "" || req.Locale == "" {
+		http.Error(w, "resource_key and locale are required", http.StatusBadRequest)
+		return
+	}
+
+	if req.Slug == "" {
+		suggested, err := suggestUniqueSlug(ctx, req.Title, req.Locale)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Slug = suggested
+	}
+	if !slug.Valid(req.Slug) {
+		http.Error(w, "Invalid slug", http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	id, err := qtx.InsertSlug(ctx, sqlc.InsertSlugParams{
+		ResourceKey: req.ResourceKey,
+		Locale:      req.Locale,
+		Slug:        req.Slug,
+	})
+	if isUniqueViolation(err) {
+		http.Error(w, "Slug already in use", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := SlugResponse{
+		ID:          id,
+		ResourceKey: req.ResourceKey,
+		Locale:      req.Locale,
+		Slug:        req.Slug,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// updateSlug changes a slug and redirects the old one
+//
+//	@Summary		Change a slug
+//	@Description	Change a slug. The previous slug is kept in the history and a 301 redirect from it to the new slug is created, with paths built by the URL strategy of every site that configures the locale (e.g. /en/about-us).
+//	@tags			Slugs
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int					true	"Slug ID"
+//	@Param			If-Match	header		string				true	"ETag of the slug"
+//	@Param			slug		body		SlugUpdateRequest	true	"New slug"
+//	@Success		200			{object}	SlugResponse
+//	@Failure		400			{string}	string	"Invalid request payload"
+//	@Failure		404			{string}	string	"Slug not found"
+//	@Failure		409			{string}	string	"Slug already in use"
+//	@Failure		412			{string}	string	"The slug has changed since its ETag"
+//	@Failure		428			{string}	string	"If-Match header required"
+//	@Failure		500			{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/slug/{id} [put]
+func updateSlug(w http.ResponseWriter, r *http.Request, id int32) {
+	ctx := r.Context()
+
+	if !requireIfMatch(w, r) {
+		return
+	}
+
+	var req SlugUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+	if !slug.Valid(req.Slug) {
+		http.Error(w, "Invalid slug", http.StatusBadRequest)
+		return
+	}
+
+	tx, qtx, err := beginAudited(ctx)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	if !matchLocked(w, r, "Slug not found", qtx, lockSlug(r, id)) {
+		return
+	}
+
+	current, err := qtx.GetSlugByID(ctx, id)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	if current.Slug != req.Slug {
+		err = changeSlug(ctx, qtx, current, req.Slug)
+		if isUniqueViolation(err) {
+			http.Error(w, "Slug already in use", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Database query error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	getSlugByID(w, r, id)
+}
+
+// lockSlug returns a function locking slug id in the transaction of its
+// queries and returning its ETag.
+func lockSlug(r *http.Request, id int32) func(*sqlc.Queries) (string, error) {
+	return func(qtx *sqlc.Queries) (string, error) {
+		updatedAt, err := qtx.LockSlug(r.Context(), id)
+		return timeETag(updatedAt), err
+	}
+}
+
+// SlugSuggestHandler godoc
+//
+//	@Summary		Suggest a slug
+//	@Description	Suggest a slug for a title, transliterating non-Latin scripts and avoiding slugs already used in the locale
+//	@Tags			Slugs
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		SlugSuggestRequest	true	"Title and locale"
+//	@Success		200		{object}	SlugSuggestResponse
+//	@Failure		400		{string}	string	"Invalid request payload"
+//	@Failure		405		{string}	string	"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/slug/suggest [post]
+func SlugSuggestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req SlugSuggestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	suggested, err := suggestUniqueSlug(r.Context(), req.Text, req.Locale)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(SlugSuggestResponse{Slug: suggested}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// suggestUniqueSlug suggests a slug for text that is not yet used in locale,
+// appending -2, -3, ... when needed.
+func suggestUniqueSlug(ctx context.Context, text, locale string) (string, error) {
+	base := slug.Suggest(text, locale)
+	if base == "" {
+		return "", errors.New("cannot suggest a slug for this text")
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		taken, err := queries.SlugExists(ctx, sqlc.SlugExistsParams{Locale: locale, Slug: candidate})
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// changeSlug replaces the slug of current with newSlug, records the old slug
+// in the history and redirects it permanently to the new one. Redirects that
+// pointed at the old slug are retargeted so that no chains build up, and any
+// redirect away from the new slug is dropped since it is live again. The
+// changes are made with qtx and committed by the caller.
+func changeSlug(ctx context.Context, qtx *sqlc.Queries, current sqlc.GetSlugByIDRow, newSlug string) error {
+	if err := qtx.UpdateSlug(ctx, sqlc.UpdateSlugParams{ID: current.ID, Slug: newSlug}); err != nil {
+		return err
+	}
+	if err := qtx.InsertSlugHistory(ctx, sqlc.InsertSlugHistoryParams{SlugID: current.ID, Slug: current.Slug}); err != nil {
+		return err
+	}
+
+	if err := qtx.LockRedirectRules(ctx); err != nil {
+		return err
+	}
+	moves, err := slugMoves(ctx, qtx, current.Locale, current.Slug, newSlug)
+	if err != nil {
+		return err
+	}
+	for _, m := range moves {
+		if err := qtx.RetargetRedirects(ctx, sqlc.RetargetRedirectsParams{
+			NewTarget: m.to,
+			Locale:    current.Locale,
+			OldTarget: m.from,
+		}); err != nil {
+			return err
+		}
+		if err := qtx.UpsertRedirect(ctx, sqlc.UpsertRedirectParams{
+			Locale:     current.Locale,
+			SourcePath: m.from,
+			TargetPath: m.to,
+			StatusCode: http.StatusMovedPermanently,
+		}); err != nil {
+			return err
+		}
+		if err := qtx.DeleteRedirectBySource(ctx, sqlc.DeleteRedirectBySourceParams{
+			Locale:     current.Locale,
+			SourcePath: m.to,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type slugMove struct {
+	from, to string
+}
+
+// slugMoves returns the request paths of oldSlug and newSlug in locale as
+// built by the URL strategy of every site that configures the locale, e.g.
+// /en/about-us for a path prefix, without duplicates. When no site configures
+// the locale the slugs are mapped to /<slug>.
+func slugMoves(ctx context.Context, qtx *sqlc.Queries, locale, oldSlug, newSlug string) ([]slugMove, error) {
+	configs, err := qtx.GetLocaleURLsByLocale(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var moves []slugMove
+	seen := map[slugMove]bool{}
+	for _, c := range configs {
+		site := urlpattern.Site{
+			Scheme:     c.Scheme,
+			Host:       c.Host,
+			QueryParam: c.QueryParam,
+			Locales: []urlpattern.Locale{{
+				Tag:      c.Locale,
+				Strategy: urlpattern.Strategy(c.Strategy),
+				Value:    c.Value,
+				TLD:      strings.Trim(c.Tld.String, "."),
+			}},
+		}
+		from, err := site.Path("/"+oldSlug, c.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("site %d: %w", c.SiteID, err)
+		}
+		to, err := site.Path("/"+newSlug, c.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("site %d: %w", c.SiteID, err)
+		}
+
+		m := slugMove{from: from, to: to}
+		if !seen[m] {
+			seen[m] = true
+			moves = append(moves, m)
+		}
+	}
+	if len(moves) == 0 {
+		moves = append(moves, slugMove{from: "/" + oldSlug, to: "/" + newSlug})
+	}
+
+	return moves, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+)
+
+// Countries, languages and variants are deleted softly, since other systems
+// cache their IDs: DELETE sets deleted_at, lists leave deleted rows out unless
+// include_deleted=true, single GETs still return them with their deleted_at,
+// and restore brings them back. PurgeDeleted removes them for good once the
+// retention has passed.
+
+// deletedAtColumn is the deleted_at column of list resources. Rows that are
+// not deleted have the epoch, to take part in keyset comparisons.
+var deletedAtColumn = listquery.Column{Name: "deleted_at", SQL: "COALESCE(deleted_at, 'epoch')", Type: listquery.Time}
+
+// notDeleted is the list condition leaving out deleted rows.
+var notDeleted = listquery.Condition{SQL: "deleted_at IS NULL"}
+
+// parseIncludeDeleted reads the include_deleted parameter.
+func parseIncludeDeleted(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("include_deleted")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// coalescedTime returns a time read from a column COALESCEd to the epoch,
+// such as deletedAtColumn, or nil for the epoch.
+func coalescedTime(t time.Time) *time.Time {
+	if t.Equal(time.Unix(0, 0)) {
+		return nil
+	}
+	return &t
+}
+
+// changeDeletion deletes or restores a row with change, in an audited
+// transaction. It writes the error response itself and reports false on
+// failure; notFound is the 404 message when change affects no row. Deletions
+// pass lock, which locks the row and returns its ETag, to check If-Match.
+func changeDeletion(w http.ResponseWriter, r *http.Request, notFound string, lock func(*sqlc.Queries) (string, error), change func(*sqlc.Queries) (int64, error)) bool {
+	if lock != nil && !requireIfMatch(w, r) {
+		return false
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	defer tx.Rollback()
+
+	if lock != nil && !matchLocked(w, r, notFound, qtx, lock) {
+		return false
+	}
+
+	affected, err := change(qtx)
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	if affected == 0 {
+		http.Error(w, notFound, http.StatusNotFound)
+		return false
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// PurgeResult counts the rows PurgeDeleted removed.
+type PurgeResult struct {
+	Variants  int64
+	Languages int64
+	Countries int64
+}
+
+// PurgeDeleted removes the countries, languages and variants deleted before
+// before, in one transaction. Purging a language also removes its variants,
+// and purging a country clears it from the variants and locale URLs that
+// used it.
+func PurgeDeleted(ctx context.Context, before time.Time) (PurgeResult, error) {
+	var result PurgeResult
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	qtx := queries.WithTx(tx)
+	if err := qtx.SetAuditContext(ctx, sqlc.SetAuditContextParams{Actor: "purge"}); err != nil {
+		return result, err
+	}
+	if result.Variants, err = qtx.PurgeVariants(ctx, before); err != nil {
+		return result, err
+	}
+	if result.Languages, err = qtx.PurgeLanguages(ctx, before); err != nil {
+		return result, err
+	}
+	if result.Countries, err = qtx.PurgeCountries(ctx, before); err != nil {
+		return result, err
+	}
+	return result, tx.Commit()
+}
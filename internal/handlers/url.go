@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+type BuildURLRequest struct {
+	SiteID int32  `json:"site_id"`
+	Path   string `json:"path"`
+	Locale string `json:"locale"`
+}
+
+type BuildURLResponse struct {
+	URL string `json:"url"`
+}
+
+type ResolveURLRequest struct {
+	SiteID int32  `json:"site_id"`
+	URL    string `json:"url"`
+}
+
+type ResolveURLResponse struct {
+	Locale   string `json:"locale"`
+	Strategy string `json:"strategy"`
+	Path     string `json:"path"`
+}
+
+// URLBuildHandler godoc
+//
+//	@Summary		Build a localized URL
+//	@Description	Turn a canonical path into the URL of one of the site's locales
+//	@Tags			URLs
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		BuildURLRequest		true	"Canonical path and locale"
+//	@Success		200		{object}	BuildURLResponse	"Localized URL"
+//	@Failure		400		{string}	string				"Invalid input"
+//	@Failure		404		{string}	string				"Site not found"
+//	@Failure		405		{string}	string				"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/url/build [post]
+func URLBuildHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input BuildURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	site, ok := siteForRequest(w, r, input.SiteID)
+	if !ok {
+		return
+	}
+
+	localized, err := site.Build(input.Path, input.Locale)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(BuildURLResponse{URL: localized}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// URLResolveHandler godoc
+//
+//	@Summary		Resolve a localized URL
+//	@Description	Split an incoming URL into its locale and canonical path
+//	@Tags			URLs
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ResolveURLRequest	true	"Incoming URL"
+//	@Success		200		{object}	ResolveURLResponse	"Locale and canonical path"
+//	@Failure		400		{string}	string				"Invalid input"
+//	@Failure		404		{string}	string				"URL does not match any locale"
+//	@Failure		405		{string}	string				"Method not allowed"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/url/resolve [post]
+func URLResolveHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input ResolveURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+
+	site, ok := siteForRequest(w, r, input.SiteID)
+	if !ok {
+		return
+	}
+
+	locale, canonical, err := site.Resolve(input.URL)
+	if errors.Is(err, urlpattern.ErrNoMatch) {
+		http.Error(w, "URL does not match any locale", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := ResolveURLResponse{
+		Locale:   locale.Tag,
+		Strategy: string(locale.Strategy),
+		Path:     canonical,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// siteForRequest loads the site referenced by a request body, writing the
+// error response itself when it cannot.
+func siteForRequest(w http.ResponseWriter, r *http.Request, siteID int32) (urlpattern.Site, bool) {
+	if siteID == 0 {
+		http.Error(w, "site_id is required", http.StatusBadRequest)
+		return urlpattern.Site{}, false
+	}
+
+	site, err := LoadSite(r.Context(), siteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Site not found", http.StatusNotFound)
+		return urlpattern.Site{}, false
+	}
+	if err != nil {
+		http.Error(w, "Failed to get site locales", http.StatusInternalServerError)
+		return urlpattern.Site{}, false
+	}
+
+	return site, true
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+	"github.com/lib/pq"
+)
+
+// Countries, languages and variants are versioned by the number_version and
+// record_version triggers, which keep every version of a row in the
+// <table>_history table with the period it was current. GETs read the state
+// at a past time with as_of, and revert copies a version back into the row
+// as a new one.
+
+var errInvalidAsOf = errors.New("invalid as_of")
+
+// parseAsOf reads the as_of parameter, an RFC 3339 time or a date standing
+// for its start in UTC. ok is false when it is missing.
+func parseAsOf(r *http.Request) (asOf time.Time, ok bool, err error) {
+	value := r.URL.Query().Get("as_of")
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if asOf, err = time.Parse(time.RFC3339, value); err == nil {
+		return asOf, true, nil
+	}
+	if asOf, err = time.Parse(time.DateOnly, value); err == nil {
+		return asOf, true, nil
+	}
+	return time.Time{}, false, err
+}
+
+// asOfResource returns res reading the versions of its table that were
+// current at asOf. The time is part of the FROM clause so that page tokens
+// are only valid with the as_of they were issued for.
+func asOfResource(res listquery.Resource, asOf time.Time) listquery.Resource {
+	at := pq.QuoteLiteral(asOf.UTC().Format(time.RFC3339Nano))
+	res.From = "(SELECT * FROM " + res.From + "_history WHERE valid_from <= " + at +
+		" AND (valid_to IS NULL OR valid_to > " + at + ")) AS " + res.From
+	return res
+}
+
+// revertRow copies the version given by the version parameter of r back into
+// a row with revert, in an audited transaction. It writes the error response
+// itself and reports false on failure; notFound names the row in the 404.
+func revertRow(w http.ResponseWriter, r *http.Request, notFound string, revert func(*sqlc.Queries, int32) (int64, error)) bool {
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil || version < 1 {
+		http.Error(w, "Invalid version", http.StatusBadRequest)
+		return false
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	defer tx.Rollback()
+
+	affected, err := revert(qtx, int32(version))
+	if isForeignKeyViolation(err) {
+		http.Error(w, "The version refers to a language or country that no longer exists", http.StatusConflict)
+		return false
+	}
+	if isUniqueViolation(err) {
+		http.Error(w, "The version conflicts with another row", http.StatusConflict)
+		return false
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	if affected == 0 {
+		http.Error(w, notFound+" or version not found", http.StatusNotFound)
+		return false
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/webhook"
+)
+
+const (
+	webhookPollInterval = 5 * time.Second
+	// webhookBatchSize deliveries are sent concurrently, within webhookLease.
+	webhookBatchSize = 20
+	webhookTimeout   = 10 * time.Second
+	webhookLease     = time.Minute
+	// maxDeliveryAttempts is when a failing delivery is dead. With the
+	// backoff of webhook.Backoff it is retried for about 15 hours.
+	maxDeliveryAttempts = 12
+)
+
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// DeliverWebhooks queues the committed outbox events for the webhooks
+// subscribed to them and sends the deliveries that are due, until ctx is
+// done. Every replica can run it: events and deliveries are claimed with
+// SKIP LOCKED, so each delivery is sent by one worker at a time.
+func DeliverWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := dispatchOutboxEvents(ctx); err != nil {
+			log.Printf("webhooks: queueing events: %v", err)
+		}
+		if err := sendDueDeliveries(ctx); err != nil {
+			log.Printf("webhooks: sending deliveries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func dispatchOutboxEvents(ctx context.Context) error {
+	const maxEvents = 500
+	for {
+		n, err := queries.DispatchOutboxEvents(ctx, maxEvents)
+		if err != nil || n < maxEvents {
+			return err
+		}
+	}
+}
+
+func sendDueDeliveries(ctx context.Context) error {
+	for {
+		due, err := queries.ClaimDueDeliveries(ctx, sqlc.ClaimDueDeliveriesParams{
+			LeaseUntil:    time.Now().Add(webhookLease),
+			MaxDeliveries: webhookBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, d := range due {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sendDelivery(ctx, d)
+			}()
+		}
+		wg.Wait()
+
+		if len(due) < webhookBatchSize {
+			return nil
+		}
+	}
+}
+
+// sendDelivery makes an attempt at a delivery and records its outcome,
+// scheduling the next attempt after a failure.
+func sendDelivery(ctx context.Context, d sqlc.ClaimDueDeliveriesRow) {
+	status, err := webhook.Send(ctx, webhookClient, d.Url, d.Secret, webhook.Event{
+		SpecVersion:     "1.0",
+		ID:              strconv.FormatInt(d.EventID, 10),
+		Source:          "/uurl-admin",
+		Type:            d.Type,
+		Subject:         d.Entity + "/" + d.EntityID,
+		Time:            d.OccurredAt,
+		DataContentType: "application/json",
+		Data:            d.Data,
+	})
+
+	attempt := sqlc.RecordDeliveryAttemptParams{
+		ID:             d.ID,
+		Status:         "delivered",
+		NextAttemptAt:  time.Now(),
+		ResponseStatus: sql.NullInt32{Int32: int32(status), Valid: status != 0},
+	}
+	if err != nil {
+		attempt.LastError = err.Error()
+		if attempts := int(d.Attempts) + 1; attempts >= maxDeliveryAttempts {
+			attempt.Status = "dead"
+		} else {
+			attempt.Status = "pending"
+			attempt.NextAttemptAt = time.Now().Add(webhook.Backoff(attempts))
+		}
+	}
+
+	// The outcome is recorded even when ctx is done, since the endpoint may
+	// have received the event.
+	if err := queries.RecordDeliveryAttempt(context.WithoutCancel(ctx), attempt); err != nil {
+		log.Printf("webhooks: recording delivery %d: %v", d.ID, err)
+	}
+}
@@ -0,0 +1,447 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeonardoFreitas1/uurl-admin/db/sqlc"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/listquery"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/webhook"
+)
+
+type WebhookRequest struct {
+	URL string `json:"url"`
+	// EventTypes are the events to send, e.g. uurl.country.updated; every
+	// event when empty.
+	EventTypes []string `json:"event_types"`
+}
+
+type WebhookResponse struct {
+	ID         int32     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookSecretResponse is returned when a webhook is created, the only time
+// its signing secret can be read.
+type WebhookSecretResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID        int64  `json:"id"`
+	EventID   int64  `json:"event_id"`
+	EventType string `json:"event_type"`
+	// Status is pending, delivered or dead.
+	Status   string `json:"status"`
+	Attempts int32  `json:"attempts"`
+	// NextAttemptAt is when a pending delivery is sent next.
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int32     `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type PaginatedWebhookDeliveriesResponse struct {
+	Deliveries    []WebhookDeliveryResponse `json:"deliveries"`
+	NextPageToken string                    `json:"next_page_token,omitempty"`
+	PrevPageToken string                    `json:"prev_page_token,omitempty"`
+	TotalCount    *int64                    `json:"total_count,omitempty"`
+}
+
+// webhookEntities and webhookOperations make up the event types,
+// uurl.<entity>.<operation>, written by the outbox triggers.
+var (
+	webhookEntities   = []string{"country", "country_language", "language", "locale_url", "localized_name", "redirect", "site", "slug", "variant"}
+	webhookOperations = []string{"created", "updated", "deleted", "restored"}
+)
+
+var webhookDeliveryResource = listquery.Resource{
+	From: "webhook_delivery d JOIN outbox_event e ON e.id = d.event_id",
+	Key:  "d.id",
+	Columns: []listquery.Column{
+		{Name: "id", SQL: "d.id", Type: listquery.Int},
+		{Name: "webhook_id", SQL: "d.webhook_id", Type: listquery.Int},
+		{Name: "event_id", SQL: "d.event_id", Type: listquery.Int},
+		{Name: "event_type", SQL: "e.type", Type: listquery.String},
+		{Name: "status", SQL: "d.status", Type: listquery.String},
+		{Name: "attempts", SQL: "d.attempts", Type: listquery.Int},
+		{Name: "next_attempt_at", SQL: "d.next_attempt_at", Type: listquery.Time},
+		{Name: "last_attempt_at", SQL: "COALESCE(d.last_attempt_at, 'epoch')", Type: listquery.Time},
+		{Name: "response_status", SQL: "COALESCE(d.response_status, 0)", Type: listquery.Int},
+		{Name: "last_error", SQL: "d.last_error", Type: listquery.String},
+		{Name: "created_at", SQL: "d.created_at", Type: listquery.Time},
+	},
+}
+
+// webhookDeliveryRow holds the columns of webhookDeliveryResource.
+type webhookDeliveryRow struct {
+	WebhookDeliveryResponse
+	webhookID      int64
+	nextAttemptAt  time.Time
+	lastAttemptAt  time.Time
+	responseStatus int32
+}
+
+func webhookDeliveryFields(d *webhookDeliveryRow) []any {
+	return []any{&d.ID, &d.webhookID, &d.EventID, &d.EventType, &d.Status, &d.Attempts, &d.nextAttemptAt, &d.lastAttemptAt, &d.responseStatus, &d.LastError, &d.CreatedAt}
+}
+
+// WebhookHandler handles requests related to webhooks
+//
+//	@Summary		Handles webhooks
+//	@Description	List, get, register or remove the endpoints that changes are sent to, and list or redeliver their deliveries
+//	@tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	false	"Webhook ID"
+//	@Success		200	{object}	WebhookResponse
+//	@Failure		400	{string}	string	"Invalid request"
+//	@Failure		405	{string}	string	"Method not allowed"
+func WebhookHandler(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	if path == "/webhooks" || path == "/webhooks/" {
+		switch r.Method {
+		case http.MethodGet:
+			getWebhooks(w, r)
+		case http.MethodPost:
+			postWebhook(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	idStr, sub, hasSub := strings.Cut(strings.TrimPrefix(path, "/webhooks/"), "/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	if !hasSub {
+		switch r.Method {
+		case http.MethodGet:
+			getWebhookByID(w, r, int32(id))
+		case http.MethodDelete:
+			deleteWebhook(w, r, int32(id))
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	if sub == "deliveries" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		getWebhookDeliveries(w, r, int32(id))
+		return
+	}
+
+	deliveryStr, action, _ := strings.Cut(strings.TrimPrefix(sub, "deliveries/"), "/")
+	deliveryID, err := strconv.ParseInt(deliveryStr, 10, 64)
+	if !strings.HasPrefix(sub, "deliveries/") || action != "redeliver" || err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	redeliverWebhookDelivery(w, r, int32(id), deliveryID)
+}
+
+// getWebhooks lists webhooks
+//
+//	@Summary		List webhooks
+//	@Description	List every registered webhook. Secrets are never returned.
+//	@tags			Webhooks
+//	@Produce		json
+//	@Success		200	{array}		WebhookResponse
+//	@Failure		500	{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/webhooks [get]
+func getWebhooks(w http.ResponseWriter, r *http.Request) {
+	hooks, err := queries.ListWebhooks(r.Context())
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]WebhookResponse, len(hooks))
+	for i, h := range hooks {
+		response[i] = toWebhookResponse(h)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// getWebhookByID returns a webhook
+//
+//	@Summary		Get a webhook
+//	@Description	Get a webhook by ID
+//	@tags			Webhooks
+//	@Produce		json
+//	@Param			id	path		int	true	"Webhook ID"
+//	@Success		200	{object}	WebhookResponse
+//	@Failure		404	{string}	string	"Webhook not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/webhooks/{id} [get]
+func getWebhookByID(w http.ResponseWriter, r *http.Request, id int32) {
+	hook, err := queries.GetWebhook(r.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toWebhookResponse(hook)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// postWebhook registers a webhook
+//
+//	@Summary		Register a webhook
+//	@Description	Register an endpoint that changes to the reference data are POSTed to as CloudEvents, signed in the Webhook-Signature header as t=<unix time>,v1=<hex HMAC-SHA256 of "<t>.<body>"> with the secret.
+//	@Description	The secret is returned only in this response. Event types are uurl.<entity>.<created|updated|deleted|restored>.
+//	@tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body		WebhookRequest	true	"Webhook"
+//	@Success		201		{object}	WebhookSecretResponse
+//	@Failure		400		{string}	string	"Invalid input"
+//	@Failure		500		{string}	string	"Failed to create webhook"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/webhooks [post]
+func postWebhook(w http.ResponseWriter, r *http.Request) {
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid input", http.StatusBadRequest)
+		return
+	}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		http.Error(w, "URL must be an absolute http or https URL", http.StatusBadRequest)
+		return
+	}
+	if req.EventTypes == nil {
+		req.EventTypes = []string{}
+	}
+	for _, t := range req.EventTypes {
+		if !validEventType(t) {
+			http.Error(w, "Unknown event type "+strconv.Quote(t), http.StatusBadRequest)
+			return
+		}
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		http.Error(w, "Failed to generate webhook secret", http.StatusInternalServerError)
+		return
+	}
+
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to create webhook", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	hook, err := qtx.CreateWebhook(r.Context(), sqlc.CreateWebhookParams{
+		Url:        req.URL,
+		Secret:     secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		http.Error(w, "Failed to create webhook", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to create webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(WebhookSecretResponse{WebhookResponse: toWebhookResponse(hook), Secret: secret}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// deleteWebhook removes a webhook
+//
+//	@Summary		Remove a webhook
+//	@Description	Remove a webhook along with its deliveries. Pending deliveries are not sent.
+//	@tags			Webhooks
+//	@Param			id	path	int	true	"Webhook ID"
+//	@Success		204
+//	@Failure		404	{string}	string	"Webhook not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/webhooks/{id} [delete]
+func deleteWebhook(w http.ResponseWriter, r *http.Request, id int32) {
+	tx, qtx, err := beginAudited(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	deleted, err := qtx.DeleteWebhook(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "Failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getWebhookDeliveries lists the deliveries of a webhook
+//
+//	@Summary		List the deliveries of a webhook
+//	@Description	List the deliveries of a webhook, newest first by default, with the outcome of their last attempt. Every column can be filtered and sorted on, e.g. status=dead or event_type=uurl.country.updated.
+//	@tags			Webhooks
+//	@Produce		json
+//	@Param			id					path		int		true	"Webhook ID"
+//	@Param			status				query		string	false	"Delivery status"	Enums(pending, delivered, dead)
+//	@Param			sort				query		string	false	"Comma-separated columns, prefixed with - for descending order"
+//	@Param			filter				query		string	false	"Filter expression, e.g. attempts>=3"
+//	@Param			page_size			query		int		false	"Number of deliveries per page (max 100)"
+//	@Param			page_token			query		string	false	"Cursor of the page to fetch"
+//	@Param			include_total_count	query		bool	false	"Include the total number of matching deliveries"
+//	@Success		200					{object}	PaginatedWebhookDeliveriesResponse
+//	@Failure		400					{string}	string	"Invalid sort, filter or page_token parameter"
+//	@Failure		404					{string}	string	"Webhook not found"
+//	@Failure		500					{string}	string	"Database query error"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/webhooks/{id}/deliveries [get]
+func getWebhookDeliveries(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := queries.GetWebhook(r.Context(), id); errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+
+	q, err := webhookDeliveryResource.Parse(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// As a filter rather than a condition, the webhook is part of the scope
+	// of the page tokens.
+	q.Filters = append(q.Filters, listquery.Filter{Column: "webhook_id", Op: listquery.Eq, Values: []any{int64(id)}})
+	if len(q.Sort) == 0 {
+		q.Sort = []listquery.Sort{{Column: "id", Desc: true}}
+	}
+
+	rows, page, ok := fetchPage(w, r, webhookDeliveryResource, q, webhookDeliveryFields)
+	if !ok {
+		return
+	}
+
+	response := PaginatedWebhookDeliveriesResponse{
+		Deliveries:    make([]WebhookDeliveryResponse, len(rows)),
+		NextPageToken: page.NextPageToken,
+		PrevPageToken: page.PrevPageToken,
+		TotalCount:    page.TotalCount,
+	}
+	for i, row := range rows {
+		if row.Status == "pending" {
+			row.NextAttemptAt = &row.nextAttemptAt
+		}
+		row.LastAttemptAt = coalescedTime(row.lastAttemptAt)
+		if row.responseStatus != 0 {
+			row.ResponseStatus = &row.responseStatus
+		}
+		response.Deliveries[i] = row.WebhookDeliveryResponse
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// redeliverWebhookDelivery sends a delivery again
+//
+//	@Summary		Redeliver an event
+//	@Description	Send a delivery again as soon as possible, whatever its status, with a fresh set of attempts. Dead deliveries are revived this way once the endpoint is fixed.
+//	@tags			Webhooks
+//	@Param			id			path	int	true	"Webhook ID"
+//	@Param			delivery_id	path	int	true	"Delivery ID"
+//	@Success		202
+//	@Failure		404	{string}	string	"Delivery not found"
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func redeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, id int32, deliveryID int64) {
+	updated, err := queries.RedeliverWebhookDelivery(r.Context(), sqlc.RedeliverWebhookDeliveryParams{
+		ID:        deliveryID,
+		WebhookID: id,
+	})
+	if err != nil {
+		http.Error(w, "Database query error", http.StatusInternalServerError)
+		return
+	}
+	if updated == 0 {
+		http.Error(w, "Delivery not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func validEventType(t string) bool {
+	rest, ok := strings.CutPrefix(t, "uurl.")
+	if !ok {
+		return false
+	}
+	i := strings.LastIndexByte(rest, '.')
+	if i < 0 {
+		return false
+	}
+	entity, operation := rest[:i], rest[i+1:]
+	return slices.Contains(webhookEntities, entity) && slices.Contains(webhookOperations, operation)
+}
+
+func toWebhookResponse(h sqlc.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:         h.ID,
+		URL:        h.Url,
+		EventTypes: h.EventTypes,
+		CreatedAt:  h.CreatedAt,
+	}
+}
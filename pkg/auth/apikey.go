@@ -0,0 +1,67 @@
+// Package auth holds the credentials the admin API accepts: API keys, whose
+// hashes are stored in the database, and JWT bearer tokens signed by a key
+// of a JSON Web Key Set.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyPrefix starts every API key, so that keys are recognisable in
+// headers, logs and secret scanners.
+const APIKeyPrefix = "uurl_"
+
+// An API key reads uurl_<id>_<secret>. The id is stored in clear to find the
+// key without scanning, and the whole key is stored as a SHA-256 hash: keys
+// are random, so a slow password hash would add nothing.
+const (
+	apiKeyIDBytes     = 6
+	apiKeySecretBytes = 32
+)
+
+// NewAPIKey returns a random API key and its id.
+func NewAPIKey() (key, id string, err error) {
+	idBytes := make([]byte, apiKeyIDBytes)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	id = hex.EncodeToString(idBytes)
+	return APIKeyPrefix + id + "_" + base64.RawURLEncoding.EncodeToString(secret), id, nil
+}
+
+// ParseAPIKey returns the id of key, or false if key is not shaped like an
+// API key.
+func ParseAPIKey(key string) (id string, ok bool) {
+	rest, ok := strings.CutPrefix(key, APIKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(id) != 2*apiKeyIDBytes || secret == "" {
+		return "", false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// HashAPIKey returns the hash stored for key.
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// CheckAPIKey reports whether key hashes to hash, in constant time.
+func CheckAPIKey(key string, hash []byte) bool {
+	return subtle.ConstantTimeCompare(HashAPIKey(key), hash) == 1
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, id, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := ParseAPIKey(key)
+	if !ok || got != id {
+		t.Errorf("ParseAPIKey(%q) = %q, %v; want %q, true", key, got, ok, id)
+	}
+	if !CheckAPIKey(key, HashAPIKey(key)) {
+		t.Errorf("CheckAPIKey rejects the key it was hashed from")
+	}
+
+	other, _, err := NewAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == key {
+		t.Fatalf("NewAPIKey returned the same key twice")
+	}
+	if CheckAPIKey(other, HashAPIKey(key)) {
+		t.Errorf("CheckAPIKey accepts another key")
+	}
+}
+
+func TestParseAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+		ok   bool
+	}{
+		{"valid", "uurl_0123456789ab_c2VjcmV0", "0123456789ab", true},
+		{"secret with separators", "uurl_0123456789ab_se_cr-et", "0123456789ab", true},
+		{"empty", "", "", false},
+		{"prefix only", "uurl_", "", false},
+		{"missing prefix", "0123456789ab_c2VjcmV0", "", false},
+		{"other prefix", "key_0123456789ab_c2VjcmV0", "", false},
+		{"prefix is case sensitive", "UURL_0123456789ab_c2VjcmV0", "", false},
+		{"missing secret separator", "uurl_0123456789ab", "", false},
+		{"empty secret", "uurl_0123456789ab_", "", false},
+		{"empty id", "uurl__c2VjcmV0", "", false},
+		{"short id", "uurl_0123456789a_c2VjcmV0", "", false},
+		{"long id", "uurl_0123456789abc_c2VjcmV0", "", false},
+		{"non-hex id", "uurl_0123456789zz_c2VjcmV0", "", false},
+		{"bearer token", "eyJhbGciOiJSUzI1NiJ9.e30.c2ln", "", false},
+		{"overlong", "uurl_" + strings.Repeat("a", 100), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseAPIKey(tt.key)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseAPIKey(%q) = %q, %v; want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken   = errors.New("auth: malformed token")
+	ErrUnknownKey       = errors.New("auth: token signed by an unknown key")
+	ErrInvalidSignature = errors.New("auth: invalid token signature")
+	ErrExpiredToken     = errors.New("auth: token expired")
+	ErrInvalidClaims    = errors.New("auth: invalid token claims")
+)
+
+// Leeway is the clock skew allowed when checking exp and nbf.
+const Leeway = time.Minute
+
+// KeySet holds the public keys of a JSON Web Key Set that tokens may be
+// signed with. RS256 and ES256 are supported.
+type KeySet struct {
+	keys []jwk
+}
+
+type jwk struct {
+	id  string
+	alg string
+	key crypto.PublicKey
+}
+
+// ReadKeySetFile reads a JSON Web Key Set from a file.
+func ReadKeySetFile(path string) (*KeySet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadKeySet(f)
+}
+
+// ReadKeySet reads a JSON Web Key Set. Keys that are not signing keys of a
+// supported type are skipped; a set left without keys is an error.
+func ReadKeySet(r io.Reader) (*KeySet, error) {
+	var doc struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("auth: reading JWKS: %w", err)
+	}
+
+	ks := &KeySet{}
+	for i, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var key jwk
+		switch {
+		case k.Kty == "RSA" && (k.Alg == "" || k.Alg == "RS256"):
+			n, err := decodeBigInt(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("auth: JWKS key %d: n: %w", i, err)
+			}
+			e, err := decodeBigInt(k.E)
+			if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+				return nil, fmt.Errorf("auth: JWKS key %d: invalid e", i)
+			}
+			if n.BitLen() < 2048 {
+				return nil, fmt.Errorf("auth: JWKS key %d: RSA keys must have at least 2048 bits", i)
+			}
+			key = jwk{alg: "RS256", key: &rsa.PublicKey{N: n, E: int(e.Int64())}}
+		case k.Kty == "EC" && k.Crv == "P-256" && (k.Alg == "" || k.Alg == "ES256"):
+			pub, err := decodeP256(k.X, k.Y)
+			if err != nil {
+				return nil, fmt.Errorf("auth: JWKS key %d: %w", i, err)
+			}
+			key = jwk{alg: "ES256", key: pub}
+		default:
+			continue
+		}
+		key.id = k.Kid
+		ks.keys = append(ks.keys, key)
+	}
+	if len(ks.keys) == 0 {
+		return nil, errors.New("auth: JWKS has no RS256 or ES256 signing keys")
+	}
+	return ks, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeP256(x, y string) (*ecdsa.PublicKey, error) {
+	xb, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil || len(xb) != 32 {
+		return nil, errors.New("invalid P-256 x coordinate")
+	}
+	yb, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil || len(yb) != 32 {
+		return nil, errors.New("invalid P-256 y coordinate")
+	}
+	// crypto/ecdh rejects points that are not on the curve.
+	if _, err := ecdh.P256().NewPublicKey(slices.Concat([]byte{4}, xb, yb)); err != nil {
+		return nil, errors.New("point is not on P-256")
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(xb), Y: new(big.Int).SetBytes(yb)}, nil
+}
+
+// VerifyOptions are the claims a token must carry besides a valid signature
+// and expiry. Empty fields are not checked.
+type VerifyOptions struct {
+	Issuer   string
+	Audience string
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Claims are the decoded claims of a verified token.
+type Claims map[string]any
+
+// Subject returns the sub claim.
+func (c Claims) Subject() string {
+	s, _ := c["sub"].(string)
+	return s
+}
+
+// Strings returns the claim name as a list, whether it holds a single string
+// or an array of them.
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Verify checks the signature of a compact JWS token against the key set
+// and validates its exp, nbf, iss and aud claims. Tokens must expire.
+func (ks *KeySet) Verify(token string, opts VerifyOptions) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	// Only keys of the token's algorithm are tried, which rules out
+	// algorithm confusion.
+	var candidates []jwk
+	for _, k := range ks.keys {
+		if k.alg == header.Alg && (header.Kid == "" || k.id == header.Kid) {
+			candidates = append(candidates, k)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrUnknownKey
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	verified := false
+	for _, k := range candidates {
+		if verifySignature(k, digest[:], signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	if err := claims.validate(opts); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func verifySignature(k jwk, digest, signature []byte) bool {
+	switch pub := k.key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(pub, digest, r, s)
+	}
+	return false
+}
+
+func (c Claims) validate(opts VerifyOptions) error {
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	exp, ok := c.time("exp")
+	if !ok {
+		return fmt.Errorf("%w: exp is required", ErrInvalidClaims)
+	}
+	if now.After(exp.Add(Leeway)) {
+		return ErrExpiredToken
+	}
+	if nbf, ok := c.time("nbf"); ok && now.Add(Leeway).Before(nbf) {
+		return fmt.Errorf("%w: token not valid yet", ErrInvalidClaims)
+	}
+
+	if opts.Issuer != "" {
+		if iss, _ := c["iss"].(string); iss != opts.Issuer {
+			return fmt.Errorf("%w: unexpected issuer", ErrInvalidClaims)
+		}
+	}
+	if opts.Audience != "" && !c.hasAudience(opts.Audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidClaims)
+	}
+	return nil
+}
+
+func (c Claims) time(name string) (time.Time, bool) {
+	n, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(n), 0), true
+}
+
+func (c Claims) hasAudience(audience string) bool {
+	switch aud := c["aud"].(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, a := range aud {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
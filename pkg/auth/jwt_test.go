@@ -0,0 +1,323 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testNow = time.Unix(1_700_000_000, 0)
+
+type jwtTestKeys struct {
+	rsa   *rsa.PrivateKey
+	ec    *ecdsa.PrivateKey
+	other *rsa.PrivateKey
+	set   *KeySet
+}
+
+// testKeys generates the signing keys once, as RSA keys are slow to make.
+var testKeys = sync.OnceValues(func() (*jwtTestKeys, error) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	set, err := ReadKeySet(strings.NewReader(jwks(rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", ecKey.X, ecKey.Y))))
+	if err != nil {
+		return nil, err
+	}
+	return &jwtTestKeys{rsa: rsaKey, ec: ecKey, other: other, set: set}, nil
+})
+
+func mustTestKeys(t *testing.T) *jwtTestKeys {
+	t.Helper()
+	keys, err := testKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func jwks(keys ...map[string]string) string {
+	b, _ := json.Marshal(map[string]any{"keys": keys})
+	return string(b)
+}
+
+func rsaJWK(kid string, pub *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+		"n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, x, y *big.Int) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": kid, "use": "sig", "alg": "ES256", "crv": "P-256",
+		"x": b64(x.FillBytes(make([]byte, 32))), "y": b64(y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func segment(v any) string {
+	b, _ := json.Marshal(v)
+	return b64(b)
+}
+
+// signRS256 and signES256 return compact tokens; the header carries alg and,
+// if not empty, kid.
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signingInput := segment(header("RS256", kid)) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + b64(sig)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signingInput := segment(header("ES256", kid)) + "." + segment(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingInput + "." + b64(append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...))
+}
+
+func header(alg, kid string) map[string]string {
+	h := map[string]string{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		h["kid"] = kid
+	}
+	return h
+}
+
+// claims returns valid claims for testNow with the given changes; a nil value
+// removes the claim.
+func claims(changes map[string]any) map[string]any {
+	c := map[string]any{
+		"sub": "user-1",
+		"iss": "https://issuer.example",
+		"aud": "uurl-admin",
+		"exp": testNow.Add(time.Hour).Unix(),
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(c, name)
+		} else {
+			c[name] = value
+		}
+	}
+	return c
+}
+
+func TestVerify(t *testing.T) {
+	keys := mustTestKeys(t)
+
+	valid := signRS256(t, keys.rsa, "rsa", claims(nil))
+	parts := strings.Split(valid, ".")
+	tamperedSig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	tamperedSig[0] ^= 1
+
+	es256 := signES256(t, keys.ec, "ec", claims(nil))
+	esParts := strings.Split(es256, ".")
+	esSig, _ := base64.RawURLEncoding.DecodeString(esParts[2])
+	// JWS signatures are r || s; ASN.1 ones, as ecdsa.SignASN1 returns, are refused.
+	derSig, err := ecdsa.SignASN1(rand.Reader, keys.ec, make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hsInput := segment(header("HS256", "rsa")) + "." + segment(claims(nil))
+	pubDER, err := x509.MarshalPKIXPublicKey(&keys.rsa.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, pubDER)
+	mac.Write([]byte(hsInput))
+
+	opts := VerifyOptions{Issuer: "https://issuer.example", Audience: "uurl-admin", Now: func() time.Time { return testNow }}
+
+	tests := []struct {
+		name    string
+		token   string
+		opts    VerifyOptions
+		wantErr error
+	}{
+		{"RS256", valid, opts, nil},
+		{"ES256", es256, opts, nil},
+		{"without kid", signRS256(t, keys.rsa, "", claims(nil)), opts, nil},
+		{"without issuer or audience checks", signES256(t, keys.ec, "ec", claims(map[string]any{"iss": nil, "aud": nil})), VerifyOptions{Now: opts.Now}, nil},
+
+		{"unknown kid", signRS256(t, keys.rsa, "missing", claims(nil)), opts, ErrUnknownKey},
+		{"kid of a key of another alg", signRS256(t, keys.rsa, "ec", claims(nil)), opts, ErrUnknownKey},
+		{"alg none", segment(header("none", "")) + "." + segment(claims(nil)) + ".", opts, ErrUnknownKey},
+		{"alg none with kid", segment(header("none", "rsa")) + "." + segment(claims(nil)) + ".", opts, ErrUnknownKey},
+		{"HS256 keyed with the public key", hsInput + "." + b64(mac.Sum(nil)), opts, ErrUnknownKey},
+		{"missing alg", segment(map[string]string{"kid": "rsa"}) + "." + parts[1] + "." + parts[2], opts, ErrUnknownKey},
+
+		{"signed by another key", signRS256(t, keys.other, "rsa", claims(nil)), opts, ErrInvalidSignature},
+		{"tampered signature", parts[0] + "." + parts[1] + "." + b64(tamperedSig), opts, ErrInvalidSignature},
+		{"tampered claims", parts[0] + "." + segment(claims(map[string]any{"sub": "admin"})) + "." + parts[2], opts, ErrInvalidSignature},
+		{"empty signature", parts[0] + "." + parts[1] + ".", opts, ErrInvalidSignature},
+		{"ES256 signature too long", esParts[0] + "." + esParts[1] + "." + b64(append(esSig, 0)), opts, ErrInvalidSignature},
+		{"ES256 signature too short", esParts[0] + "." + esParts[1] + "." + b64(esSig[:63]), opts, ErrInvalidSignature},
+		{"ES256 ASN.1 signature", esParts[0] + "." + esParts[1] + "." + b64(derSig), opts, ErrInvalidSignature},
+
+		{"empty token", "", opts, ErrMalformedToken},
+		{"two segments", parts[0] + "." + parts[1], opts, ErrMalformedToken},
+		{"four segments", valid + ".x", opts, ErrMalformedToken},
+		{"header not base64url", "!." + parts[1] + "." + parts[2], opts, ErrMalformedToken},
+		{"header not JSON", b64([]byte("alg")) + "." + parts[1] + "." + parts[2], opts, ErrMalformedToken},
+		{"signature not base64url", parts[0] + "." + parts[1] + ".!", opts, ErrMalformedToken},
+
+		{"missing exp", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"exp": nil})), opts, ErrInvalidClaims},
+		{"exp not a number", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"exp": "tomorrow"})), opts, ErrInvalidClaims},
+		{"expired within leeway", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"exp": testNow.Add(-Leeway).Unix()})), opts, nil},
+		{"expired beyond leeway", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"exp": testNow.Add(-Leeway - time.Second).Unix()})), opts, ErrExpiredToken},
+		{"nbf within leeway", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"nbf": testNow.Add(Leeway).Unix()})), opts, nil},
+		{"nbf beyond leeway", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"nbf": testNow.Add(Leeway + time.Second).Unix()})), opts, ErrInvalidClaims},
+		{"nbf in the past", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"nbf": testNow.Add(-time.Hour).Unix()})), opts, nil},
+
+		{"issuer mismatch", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"iss": "https://other.example"})), opts, ErrInvalidClaims},
+		{"missing issuer", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"iss": nil})), opts, ErrInvalidClaims},
+		{"audience array", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"aud": []string{"other", "uurl-admin"}})), opts, nil},
+		{"audience mismatch", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"aud": "other"})), opts, ErrInvalidClaims},
+		{"audience array mismatch", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"aud": []string{"other"}})), opts, ErrInvalidClaims},
+		{"audience not a string", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"aud": 1})), opts, ErrInvalidClaims},
+		{"missing audience", signRS256(t, keys.rsa, "rsa", claims(map[string]any{"aud": nil})), opts, ErrInvalidClaims},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keys.set.Verify(tt.token, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v; want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Subject() != "user-1" {
+				t.Errorf("Verify() subject = %q; want %q", got.Subject(), "user-1")
+			}
+			if tt.wantErr != nil && got != nil {
+				t.Errorf("Verify() claims = %v; want nil", got)
+			}
+		})
+	}
+}
+
+func TestReadKeySet(t *testing.T) {
+	keys := mustTestKeys(t)
+
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey := rsaJWK("rsa", &keys.rsa.PublicKey)
+	ecKey := ecJWK("ec", keys.ec.X, keys.ec.Y)
+	with := func(key map[string]string, name, value string) map[string]string {
+		changed := map[string]string{}
+		for k, v := range key {
+			changed[k] = v
+		}
+		if value == "" {
+			delete(changed, name)
+		} else {
+			changed[name] = value
+		}
+		return changed
+	}
+
+	tests := []struct {
+		name     string
+		jwks     string
+		wantKeys int
+		wantErr  bool
+	}{
+		{"RSA and EC keys", jwks(rsaKey, ecKey), 2, false},
+		{"without use and alg", jwks(with(with(rsaKey, "use", ""), "alg", ""), with(with(ecKey, "use", ""), "alg", "")), 2, false},
+		{"encryption keys are skipped", jwks(rsaKey, with(ecKey, "use", "enc")), 1, false},
+		{"unsupported key types are skipped", jwks(rsaKey, map[string]string{"kty": "OKP", "crv": "Ed25519", "x": b64(make([]byte, 32))}), 1, false},
+		{"unsupported algorithms are skipped", jwks(rsaKey, with(rsaKey, "alg", "PS256"), with(ecKey, "alg", "ES384")), 1, false},
+		{"unsupported curves are skipped", jwks(rsaKey, with(ecKey, "crv", "P-384")), 1, false},
+
+		{"RSA key under 2048 bits", jwks(rsaJWK("small", &small.PublicKey)), 0, true},
+		{"RSA key under 2048 bits among others", jwks(rsaKey, rsaJWK("small", &small.PublicKey)), 0, true},
+		{"RSA exponent of 1", jwks(with(rsaKey, "e", b64([]byte{1}))), 0, true},
+		{"RSA exponent too large", jwks(with(rsaKey, "e", b64([]byte{1, 0, 0, 0, 0}))), 0, true},
+		{"RSA modulus missing", jwks(with(rsaKey, "n", "")), 0, true},
+		{"RSA modulus not base64url", jwks(with(rsaKey, "n", "!!")), 0, true},
+		{"EC point off the curve", jwks(ecJWK("ec", keys.ec.X, new(big.Int).Add(keys.ec.Y, big.NewInt(1)))), 0, true},
+		{"EC point at the origin", jwks(ecJWK("ec", big.NewInt(0), big.NewInt(0))), 0, true},
+		{"EC coordinate too short", jwks(with(ecKey, "x", b64(make([]byte, 31)))), 0, true},
+		{"EC coordinate missing", jwks(with(ecKey, "y", "")), 0, true},
+
+		{"no keys", `{"keys": []}`, 0, true},
+		{"only skipped keys", jwks(with(rsaKey, "use", "enc")), 0, true},
+		{"not JSON", "keys", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := ReadKeySet(strings.NewReader(tt.jwks))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadKeySet() error = %v; want error %v", err, tt.wantErr)
+			}
+			if err == nil && len(ks.keys) != tt.wantKeys {
+				t.Errorf("ReadKeySet() read %d keys; want %d", len(ks.keys), tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestClaimsStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+	}{
+		{"string", "a", []string{"a"}},
+		{"array", []any{"a", "b"}, []string{"a", "b"}},
+		{"array with other types", []any{"a", 1.0, nil, "b"}, []string{"a", "b"}},
+		{"number", 1.0, nil},
+		{"missing", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Claims{}
+			if tt.value != nil {
+				c["roles"] = tt.value
+			}
+			got := c.Strings("roles")
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || len(got) != len(tt.want) {
+				t.Errorf("Strings() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
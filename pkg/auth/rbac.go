@@ -0,0 +1,75 @@
+package auth
+
+import "slices"
+
+// Role is what a caller is allowed to do. Each role may do everything the
+// roles before it in Roles may.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// Roles lists the roles from least to most privileged.
+var Roles = []Role{RoleViewer, RoleEditor, RoleAdmin}
+
+// ParseRole returns the role named s.
+func ParseRole(s string) (Role, bool) {
+	r := Role(s)
+	return r, slices.Contains(Roles, r)
+}
+
+// Includes reports whether r may do everything other may.
+func (r Role) Includes(other Role) bool {
+	return slices.Index(Roles, r) >= slices.Index(Roles, other) && slices.Contains(Roles, other)
+}
+
+// HighestRole returns the most privileged of names that is a role, ignoring
+// the others.
+func HighestRole(names []string) (Role, bool) {
+	best, found := Role(""), false
+	for _, name := range names {
+		if r, ok := ParseRole(name); ok && (!found || r.Includes(best)) {
+			best, found = r, true
+		}
+	}
+	return best, found
+}
+
+// Action is what a request does to a resource.
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionWrite  Action = "write"
+	ActionDelete Action = "delete"
+)
+
+// Actions lists every action.
+var Actions = []Action{ActionRead, ActionWrite, ActionDelete}
+
+// Policy maps each resource to the least privileged role allowed each
+// action on it. Actions missing from a resource are denied to every role.
+type Policy map[string]map[Action]Role
+
+// Allows reports whether role may perform action on resource.
+func (p Policy) Allows(role Role, resource string, action Action) bool {
+	least, ok := p[resource][action]
+	return ok && role.Includes(least)
+}
+
+// Permissions returns the actions role may perform on each resource,
+// leaving out resources it may do nothing with.
+func (p Policy) Permissions(role Role) map[string][]Action {
+	permissions := make(map[string][]Action)
+	for resource := range p {
+		for _, action := range Actions {
+			if p.Allows(role, resource, action) {
+				permissions[resource] = append(permissions[resource], action)
+			}
+		}
+	}
+	return permissions
+}
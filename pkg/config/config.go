@@ -1,10 +1,12 @@
 package config
 
 import (
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	_ "github.com/lib/pq"
 
@@ -13,10 +15,33 @@ import (
 )
 
 var (
-	db      *sql.DB
-	Queries *sqlc.Queries
+	db              *sql.DB
+	databaseURL     string
+	Queries         *sqlc.Queries
+	pageTokenSecret []byte
+	auth            Auth
+	// softDeleteRetention is how long deleted rows are kept before the purge
+	// command removes them.
+	softDeleteRetention = 90 * 24 * time.Hour
+	// idempotencyKeyTTL is how long the response to a create request sent
+	// with an Idempotency-Key is replayed to retries.
+	idempotencyKeyTTL = 24 * time.Hour
 )
 
+// Auth configures how requests are authenticated.
+type Auth struct {
+	// JWKSFile is the path of the JSON Web Key Set that bearer tokens are
+	// verified against. Without it only API keys are accepted.
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+	// JWTRolesClaim names the claim holding the caller's role, or a list of
+	// roles of which the highest counts. Tokens without one are viewers.
+	JWTRolesClaim string
+	// PublicReads lets GET and HEAD requests through without credentials.
+	PublicReads bool
+}
+
 func init() {
 	err := godotenv.Load()
 	if err != nil {
@@ -32,7 +57,7 @@ func init() {
 		log.Fatal("Database configuration variables are missing")
 	}
 
-	connStr := fmt.Sprintf(
+	databaseURL = fmt.Sprintf(
 		"postgres://%s:%s@%s/%s?sslmode=disable",
 		dbUser,
 		dbPassword,
@@ -40,7 +65,7 @@ func init() {
 		dbName,
 	)
 
-	db, err = sql.Open("postgres", connStr)
+	db, err = sql.Open("postgres", databaseURL)
 	if err != nil {
 		log.Fatal("Failed to connect to the database:", err)
 	}
@@ -50,12 +75,71 @@ func init() {
 	}
 
 	Queries = sqlc.New(db)
+
+	pageTokenSecret = []byte(os.Getenv("PAGE_TOKEN_SECRET"))
+	if len(pageTokenSecret) == 0 {
+		log.Println("Warning: PAGE_TOKEN_SECRET is not set, page tokens will not survive a restart")
+		pageTokenSecret = make([]byte, 32)
+		if _, err := rand.Read(pageTokenSecret); err != nil {
+			log.Fatal("Failed to generate page token secret:", err)
+		}
+	}
+
+	auth = Auth{
+		JWKSFile:    os.Getenv("AUTH_JWKS_FILE"),
+		JWTIssuer:   os.Getenv("AUTH_JWT_ISSUER"),
+		JWTAudience: os.Getenv("AUTH_JWT_AUDIENCE"),
+		PublicReads: os.Getenv("AUTH_PUBLIC_READS") == "true",
+	}
+	if auth.JWTRolesClaim = os.Getenv("AUTH_JWT_ROLES_CLAIM"); auth.JWTRolesClaim == "" {
+		auth.JWTRolesClaim = "roles"
+	}
+
+	if v := os.Getenv("SOFT_DELETE_RETENTION"); v != "" {
+		if softDeleteRetention, err = time.ParseDuration(v); err != nil || softDeleteRetention <= 0 {
+			log.Fatal("Invalid SOFT_DELETE_RETENTION, expected a duration such as 2160h:", v)
+		}
+	}
+
+	if v := os.Getenv("IDEMPOTENCY_KEY_TTL"); v != "" {
+		if idempotencyKeyTTL, err = time.ParseDuration(v); err != nil || idempotencyKeyTTL <= 0 {
+			log.Fatal("Invalid IDEMPOTENCY_KEY_TTL, expected a duration such as 24h:", v)
+		}
+	}
 }
 
 func GetDB() *sql.DB {
 	return db
 }
 
+// GetDatabaseURL returns the connection string of the database, for
+// connections that cannot come from the pool, such as LISTEN.
+func GetDatabaseURL() string {
+	return databaseURL
+}
+
 func GetQueries() *sqlc.Queries {
 	return Queries
 }
+
+// GetPageTokenSecret returns the key used to sign pagination cursors.
+func GetPageTokenSecret() []byte {
+	return pageTokenSecret
+}
+
+// GetAuth returns the authentication settings.
+func GetAuth() Auth {
+	return auth
+}
+
+// GetSoftDeleteRetention returns how long deleted countries, languages and
+// variants are kept before they are purged.
+func GetSoftDeleteRetention() time.Duration {
+	return softDeleteRetention
+}
+
+// GetIdempotencyKeyTTL returns how long responses to create requests are kept
+// for retries with the same Idempotency-Key.
+func GetIdempotencyKeyTTL() time.Duration {
+	return idempotencyKeyTTL
+}
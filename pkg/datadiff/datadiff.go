@@ -0,0 +1,238 @@
+// Package datadiff compares two snapshots of tabular reference data by
+// natural key and describes the changes that would make the target match
+// the source.
+package datadiff
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/tabular"
+)
+
+type Op string
+
+const (
+	// Add is a row only the source has.
+	Add Op = "add"
+	// Update is a row both have with different values.
+	Update Op = "update"
+	// Remove is a row only the target has.
+	Remove Op = "remove"
+)
+
+type FieldChange struct {
+	Column string `json:"column"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+type Change struct {
+	Entity string `json:"entity"`
+	Op     Op     `json:"op"`
+	// Key joins the natural key values with "/", e.g. "pt/BR/pt-BR".
+	Key string `json:"key"`
+	// Row holds the source values of an added or updated row and the target
+	// values of a removed row.
+	Row    map[string]string `json:"row"`
+	Fields []FieldChange     `json:"fields,omitempty"`
+}
+
+type Summary struct {
+	Entity    string `json:"entity"`
+	Added     int    `json:"added"`
+	Updated   int    `json:"updated"`
+	Removed   int    `json:"removed"`
+	Unchanged int    `json:"unchanged"`
+}
+
+type Changeset struct {
+	Summary []Summary `json:"summary"`
+	Changes []Change  `json:"changes"`
+
+	headers map[string][]string
+}
+
+// Diff compares the tables of source and target with the same name. keys
+// gives the natural key columns of each table; tables without keys are
+// ignored. Keys match case-insensitively, since ISO codes are written in
+// either case; other values must match exactly. Only columns both tables
+// have are compared.
+func Diff(source, target []tabular.Table, keys map[string][]string) (Changeset, error) {
+	cs := Changeset{Changes: []Change{}, headers: map[string][]string{}}
+
+	var names []string
+	for _, t := range append(slices.Clone(source), target...) {
+		if _, ok := keys[t.Name]; ok && !slices.Contains(names, t.Name) {
+			names = append(names, t.Name)
+		}
+	}
+
+	for _, name := range names {
+		src, err := index(find(source, name), keys[name])
+		if err != nil {
+			return Changeset{}, fmt.Errorf("datadiff: source %s: %w", name, err)
+		}
+		dst, err := index(find(target, name), keys[name])
+		if err != nil {
+			return Changeset{}, fmt.Errorf("datadiff: target %s: %w", name, err)
+		}
+		cs.headers[name] = src.table.Header
+
+		var shared []string
+		for _, column := range src.table.Header {
+			if dst.table.Index(column) >= 0 && !slices.Contains(keys[name], column) {
+				shared = append(shared, column)
+			}
+		}
+
+		summary := Summary{Entity: name}
+		for _, k := range src.order {
+			i := src.rows[k]
+			j, ok := dst.rows[k]
+			if !ok {
+				summary.Added++
+				cs.Changes = append(cs.Changes, Change{Entity: name, Op: Add, Key: src.key(i), Row: src.row(i)})
+				continue
+			}
+
+			var fields []FieldChange
+			for _, column := range shared {
+				from, to := dst.table.Get(j, column), src.table.Get(i, column)
+				if from != to {
+					fields = append(fields, FieldChange{Column: column, From: from, To: to})
+				}
+			}
+			if len(fields) == 0 {
+				summary.Unchanged++
+				continue
+			}
+			summary.Updated++
+			cs.Changes = append(cs.Changes, Change{Entity: name, Op: Update, Key: src.key(i), Row: src.row(i), Fields: fields})
+		}
+		for _, k := range dst.order {
+			if _, ok := src.rows[k]; !ok {
+				j := dst.rows[k]
+				summary.Removed++
+				cs.Changes = append(cs.Changes, Change{Entity: name, Op: Remove, Key: dst.key(j), Row: dst.row(j)})
+			}
+		}
+		cs.Summary = append(cs.Summary, summary)
+	}
+	return cs, nil
+}
+
+// Empty reports whether the target already matches the source.
+func (cs Changeset) Empty() bool {
+	return len(cs.Changes) == 0
+}
+
+// Upserts returns the added and updated rows as tables in the source's
+// columns, ready to be imported into the target. Removals are left out.
+func (cs Changeset) Upserts() []tabular.Table {
+	var tables []tabular.Table
+	for _, s := range cs.Summary {
+		t := tabular.Table{Name: s.Entity, Header: cs.headers[s.Entity]}
+		for _, c := range cs.Changes {
+			if c.Entity != s.Entity || c.Op == Remove {
+				continue
+			}
+			row := make([]string, len(t.Header))
+			for i, column := range t.Header {
+				row[i] = c.Row[column]
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		if len(t.Rows) > 0 {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// WriteText writes the changeset for people: a summary line per entity,
+// then one line per change, marked +, ~ or -.
+func (cs Changeset) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, s := range cs.Summary {
+		fmt.Fprintf(&b, "%-16s %d to add, %d to update, %d only in target, %d unchanged\n",
+			s.Entity, s.Added, s.Updated, s.Removed, s.Unchanged)
+	}
+	if len(cs.Changes) > 0 {
+		b.WriteString("\n")
+	}
+	for _, c := range cs.Changes {
+		switch c.Op {
+		case Add:
+			fmt.Fprintf(&b, "+ %s %s\n", c.Entity, c.Key)
+		case Update:
+			fields := make([]string, len(c.Fields))
+			for i, f := range c.Fields {
+				fields[i] = fmt.Sprintf("%s %q -> %q", f.Column, f.From, f.To)
+			}
+			fmt.Fprintf(&b, "~ %s %s: %s\n", c.Entity, c.Key, strings.Join(fields, "; "))
+		case Remove:
+			fmt.Fprintf(&b, "- %s %s (only in target)\n", c.Entity, c.Key)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func find(tables []tabular.Table, name string) tabular.Table {
+	for _, t := range tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return tabular.Table{Name: name}
+}
+
+type indexed struct {
+	table   tabular.Table
+	columns []string
+	rows    map[string]int
+	order   []string
+}
+
+func index(t tabular.Table, key []string) (indexed, error) {
+	ix := indexed{table: t, columns: key, rows: map[string]int{}}
+	if len(t.Header) > 0 {
+		for _, column := range key {
+			if t.Index(column) < 0 {
+				return indexed{}, fmt.Errorf("missing key column %s", column)
+			}
+		}
+	}
+
+	for i := range t.Rows {
+		k := strings.ToLower(ix.key(i))
+		if _, ok := ix.rows[k]; ok {
+			// Like POST /import, the first of several rows with a key wins.
+			continue
+		}
+		ix.rows[k] = i
+		ix.order = append(ix.order, k)
+	}
+	return ix, nil
+}
+
+func (ix indexed) key(i int) string {
+	values := make([]string, len(ix.columns))
+	for j, column := range ix.columns {
+		values[j] = ix.table.Get(i, column)
+	}
+	return strings.Join(values, "/")
+}
+
+func (ix indexed) row(i int) map[string]string {
+	row := map[string]string{}
+	for j, column := range ix.table.Header {
+		if j < len(ix.table.Rows[i]) {
+			row[column] = ix.table.Rows[i][j]
+		}
+	}
+	return row
+}
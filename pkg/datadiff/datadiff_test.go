@@ -0,0 +1,170 @@
+package datadiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/tabular"
+)
+
+var keys = map[string][]string{
+	"language": {"iso_639_1"},
+	"variant":  {"iso_639_1", "variant_tag"},
+}
+
+func languages(rows ...[]string) tabular.Table {
+	return tabular.Table{Name: "language", Header: []string{"iso_639_1", "name"}, Rows: rows}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  []tabular.Table
+		target  []tabular.Table
+		summary []Summary
+		changes []Change
+	}{
+		{
+			name:    "identical",
+			source:  []tabular.Table{languages([]string{"de", "German"})},
+			target:  []tabular.Table{languages([]string{"de", "German"})},
+			summary: []Summary{{Entity: "language", Unchanged: 1}},
+			changes: []Change{},
+		},
+		{
+			name:    "add",
+			source:  []tabular.Table{languages([]string{"de", "German"}, []string{"fr", "French"})},
+			target:  []tabular.Table{languages([]string{"de", "German"})},
+			summary: []Summary{{Entity: "language", Added: 1, Unchanged: 1}},
+			changes: []Change{{Entity: "language", Op: Add, Key: "fr", Row: map[string]string{"iso_639_1": "fr", "name": "French"}}},
+		},
+		{
+			name:    "update",
+			source:  []tabular.Table{languages([]string{"de", "German"})},
+			target:  []tabular.Table{languages([]string{"de", "Deutsch"})},
+			summary: []Summary{{Entity: "language", Updated: 1}},
+			changes: []Change{{
+				Entity: "language", Op: Update, Key: "de",
+				Row:    map[string]string{"iso_639_1": "de", "name": "German"},
+				Fields: []FieldChange{{Column: "name", From: "Deutsch", To: "German"}},
+			}},
+		},
+		{
+			name:    "remove",
+			source:  []tabular.Table{languages([]string{"de", "German"})},
+			target:  []tabular.Table{languages([]string{"de", "German"}, []string{"xx", "Unknown"})},
+			summary: []Summary{{Entity: "language", Removed: 1, Unchanged: 1}},
+			changes: []Change{{Entity: "language", Op: Remove, Key: "xx", Row: map[string]string{"iso_639_1": "xx", "name": "Unknown"}}},
+		},
+		{
+			name:    "keys match in any case",
+			source:  []tabular.Table{languages([]string{"DE", "German"})},
+			target:  []tabular.Table{languages([]string{"de", "German"})},
+			summary: []Summary{{Entity: "language", Unchanged: 1}},
+			changes: []Change{},
+		},
+		{
+			name:    "first of duplicate keys wins",
+			source:  []tabular.Table{languages([]string{"de", "German"}, []string{"de", "Deutsch"})},
+			target:  []tabular.Table{languages([]string{"de", "German"})},
+			summary: []Summary{{Entity: "language", Unchanged: 1}},
+			changes: []Change{},
+		},
+		{
+			name:   "columns only one side has are ignored",
+			source: []tabular.Table{languages([]string{"de", "German"})},
+			target: []tabular.Table{{
+				Name:   "language",
+				Header: []string{"iso_639_1", "name", "note"},
+				Rows:   [][]string{{"de", "German", "target only"}},
+			}},
+			summary: []Summary{{Entity: "language", Unchanged: 1}},
+			changes: []Change{},
+		},
+		{
+			name: "composite key and missing target table",
+			source: []tabular.Table{{
+				Name:   "variant",
+				Header: []string{"iso_639_1", "variant_tag"},
+				Rows:   [][]string{{"pt", "pt-BR"}},
+			}},
+			summary: []Summary{{Entity: "variant", Added: 1}},
+			changes: []Change{{Entity: "variant", Op: Add, Key: "pt/pt-BR", Row: map[string]string{"iso_639_1": "pt", "variant_tag": "pt-BR"}}},
+		},
+		{
+			name:    "tables without keys are ignored",
+			source:  []tabular.Table{{Name: "site", Header: []string{"id"}, Rows: [][]string{{"1"}}}},
+			changes: []Change{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := Diff(tt.source, tt.target, keys)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if !reflect.DeepEqual(cs.Summary, tt.summary) {
+				t.Errorf("Diff() summary = %+v; want %+v", cs.Summary, tt.summary)
+			}
+			if !reflect.DeepEqual(cs.Changes, tt.changes) {
+				t.Errorf("Diff() changes = %+v; want %+v", cs.Changes, tt.changes)
+			}
+			if cs.Empty() != (len(tt.changes) == 0) {
+				t.Errorf("Empty() = %v with %d changes", cs.Empty(), len(tt.changes))
+			}
+		})
+	}
+}
+
+func TestDiffMissingKeyColumn(t *testing.T) {
+	source := []tabular.Table{{Name: "language", Header: []string{"name"}, Rows: [][]string{{"German"}}}}
+	if _, err := Diff(source, nil, keys); err == nil {
+		t.Error("Diff() with no key column succeeded; want an error")
+	}
+}
+
+func TestUpserts(t *testing.T) {
+	source := []tabular.Table{languages([]string{"de", "German"}, []string{"fr", "French"}, []string{"it", "Italian"})}
+	target := []tabular.Table{languages([]string{"de", "Deutsch"}, []string{"it", "Italian"}, []string{"xx", "Unknown"})}
+
+	cs, err := Diff(source, target, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []tabular.Table{languages([]string{"de", "German"}, []string{"fr", "French"})}
+	if got := cs.Upserts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Upserts() = %q; want %q", got, want)
+	}
+
+	cs, _ = Diff(target[:1], target, keys)
+	if got := cs.Upserts(); len(got) != 0 {
+		t.Errorf("Upserts() with only removals = %q; want none", got)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	source := []tabular.Table{languages([]string{"de", "German"}, []string{"fr", "French"})}
+	target := []tabular.Table{languages([]string{"de", "Deutsch"}, []string{"xx", "Unknown"})}
+
+	cs, err := Diff(source, target, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := cs.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `language         1 to add, 1 to update, 1 only in target, 0 unchanged
+
+~ language de: name "Deutsch" -> "German"
++ language fr
+- language xx (only in target)
+`
+	if b.String() != want {
+		t.Errorf("WriteText() = %q; want %q", b.String(), want)
+	}
+}
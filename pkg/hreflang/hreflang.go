@@ -0,0 +1,131 @@
+// Package hreflang builds the rel="alternate" hreflang set of a page from the
+// locale URL configuration of its site.
+package hreflang
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+// XDefault is the hreflang value of the fallback page for unmatched languages.
+const XDefault = "x-default"
+
+type Link struct {
+	Hreflang string `json:"hreflang"`
+	Href     string `json:"href"`
+}
+
+// Tag is a parsed hreflang value. Language is an ISO 639-1 code, or an ISO
+// 639-2/3 code for languages without one (haw, yue), Script an optional ISO
+// 15924 code and Region an optional ISO 3166-1 alpha-2 code.
+type Tag struct {
+	Language string
+	Script   string
+	Region   string
+}
+
+func (t Tag) String() string {
+	s := t.Language
+	if t.Script != "" {
+		s += "-" + t.Script
+	}
+	if t.Region != "" {
+		s += "-" + t.Region
+	}
+	return s
+}
+
+// Parse validates an hreflang value and returns it in canonical case
+// (language lower case, script title case, region upper case). x-default is
+// not a Tag and must be handled by the caller.
+func Parse(value string) (Tag, error) {
+	parts := strings.Split(strings.ReplaceAll(value, "_", "-"), "-")
+	if len(parts) > 3 {
+		return Tag{}, fmt.Errorf("invalid hreflang %q: too many subtags", value)
+	}
+
+	if !isAlpha(parts[0], 2) && !isAlpha(parts[0], 3) {
+		return Tag{}, fmt.Errorf("invalid hreflang %q: language must be a 2 or 3 letter ISO 639 code", value)
+	}
+	tag := Tag{Language: strings.ToLower(parts[0])}
+
+	rest := parts[1:]
+	if len(rest) > 0 && isAlpha(rest[0], 4) {
+		tag.Script = strings.ToUpper(rest[0][:1]) + strings.ToLower(rest[0][1:])
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		if !isAlpha(rest[0], 2) {
+			return Tag{}, fmt.Errorf("invalid hreflang %q: region must be an ISO 3166-1 alpha-2 code", value)
+		}
+		tag.Region = strings.ToUpper(rest[0])
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		return Tag{}, fmt.Errorf("invalid hreflang %q", value)
+	}
+
+	return tag, nil
+}
+
+// Alternates returns one link per configured locale of site followed by the
+// x-default link, which points at the default locale when the site has one.
+func Alternates(site urlpattern.Site, canonicalPath string) ([]Link, error) {
+	var links []Link
+	for _, l := range site.Locales {
+		tag, err := Parse(l.Tag)
+		if err != nil {
+			return nil, err
+		}
+
+		href, err := site.Build(canonicalPath, l.Tag)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, Link{Hreflang: tag.String(), Href: href})
+	}
+
+	if site.DefaultLocale != "" {
+		href, err := site.Build(canonicalPath, site.DefaultLocale)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, Link{Hreflang: XDefault, Href: href})
+	}
+
+	return links, nil
+}
+
+// HTML renders links as <link> elements for a page's <head>, one per line.
+func HTML(links []Link) string {
+	var b strings.Builder
+	for _, l := range links {
+		fmt.Fprintf(&b, "<link rel=\"alternate\" hreflang=\"%s\" href=\"%s\" />\n",
+			html.EscapeString(l.Hreflang), html.EscapeString(l.Href))
+	}
+	return b.String()
+}
+
+// LinkHeader renders links as the value of a single HTTP Link header.
+func LinkHeader(links []Link) string {
+	values := make([]string, 0, len(links))
+	for _, l := range links {
+		values = append(values, fmt.Sprintf("<%s>; rel=\"alternate\"; hreflang=\"%s\"", l.Href, l.Hreflang))
+	}
+	return strings.Join(values, ", ")
+}
+
+func isAlpha(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package hreflang
+
+import (
+	"testing"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"language", "en", "en", false},
+		{"language and region", "en-us", "en-US", false},
+		{"underscore separator", "pt_BR", "pt-BR", false},
+		{"script", "zh-hant", "zh-Hant", false},
+		{"script and region", "ZH-HANT-tw", "zh-Hant-TW", false},
+		{"three letter language", "haw", "haw", false},
+		{"three letter language and region", "yue-hk", "yue-HK", false},
+		{"three letter language and script", "yue-Hans-CN", "yue-Hans-CN", false},
+		{"empty", "", "", true},
+		{"one letter language", "e", "", true},
+		{"four letter language", "engl", "", true},
+		{"numeric language", "e1", "", true},
+		{"numeric region", "es-419", "", true},
+		{"three letter region", "en-USA", "", true},
+		{"too many subtags", "zh-Hant-TW-x", "", true},
+		{"x-default is not a tag", XDefault, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+			if (err != nil) != tt.wantErr || got.String() != tt.want {
+				t.Errorf("Parse(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAlternates(t *testing.T) {
+	site := urlpattern.Site{
+		Host:          "example.com",
+		DefaultLocale: "en-US",
+		Locales: []urlpattern.Locale{
+			{Tag: "en-US", Strategy: urlpattern.PathPrefix, Value: "en"},
+			{Tag: "haw", Strategy: urlpattern.Subdomain, Value: "haw"},
+		},
+	}
+
+	links, err := Alternates(site, "/about")
+	if err != nil {
+		t.Fatalf("Alternates() error = %v", err)
+	}
+	want := []Link{
+		{Hreflang: "en-US", Href: "https://example.com/en/about"},
+		{Hreflang: "haw", Href: "https://haw.example.com/about"},
+		{Hreflang: XDefault, Href: "https://example.com/en/about"},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("Alternates() = %v; want %v", links, want)
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("Alternates()[%d] = %v; want %v", i, links[i], want[i])
+		}
+	}
+
+	header := LinkHeader(links[:1])
+	if header != `<https://example.com/en/about>; rel="alternate"; hreflang="en-US"` {
+		t.Errorf("LinkHeader() = %s", header)
+	}
+	html := HTML(links[2:])
+	if html != "<link rel=\"alternate\" hreflang=\"x-default\" href=\"https://example.com/en/about\" />\n" {
+		t.Errorf("HTML() = %s", html)
+	}
+
+	site.Locales = append(site.Locales, urlpattern.Locale{Tag: "english", Strategy: urlpattern.PathPrefix, Value: "x"})
+	if _, err := Alternates(site, "/about"); err == nil {
+		t.Error("Alternates() with an invalid locale tag succeeded; want an error")
+	}
+}
@@ -0,0 +1,262 @@
+package listquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DBTX is satisfied by *sql.DB and *sql.Tx.
+type DBTX interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Cursor positions a page relative to a row: after it going forward, before
+// it going backward. Values holds the row's sort keys, key column last.
+type Cursor struct {
+	Backward bool
+	Values   []string
+}
+
+type Page[T any] struct {
+	Items []T
+	// Next and Prev are nil when there is no page in that direction.
+	Next *Cursor
+	Prev *Cursor
+}
+
+// Fetch returns up to size rows of res matching q, starting at cursor (nil
+// for the first page). fields returns pointers to the fields of a row that
+// the resource's columns scan into, in declaration order.
+func Fetch[T any](ctx context.Context, db DBTX, res Resource, q Query, cursor *Cursor, size int, fields func(*T) []any) (Page[T], error) {
+	order := res.order(q)
+	backward := cursor != nil && cursor.Backward
+
+	var b builder
+	where, err := b.where(res, q)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	if cursor != nil {
+		cond, err := b.keyset(res, order, cursor)
+		if err != nil {
+			return Page[T]{}, err
+		}
+		where = append(where, cond)
+	}
+
+	selected := make([]string, 0, len(res.Columns)+len(order))
+	for _, c := range res.Columns {
+		selected = append(selected, c.SQL)
+	}
+	orderBy := make([]string, len(order))
+	for i, s := range order {
+		selected = append(selected, s.sql)
+		// Reading backward walks the reversed order from the cursor.
+		if s.desc != backward {
+			orderBy[i] = s.sql + " DESC"
+		} else {
+			orderBy[i] = s.sql + " ASC"
+		}
+	}
+
+	stmt := "SELECT " + strings.Join(selected, ", ") + " FROM " + res.From
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	// One extra row tells whether there is another page.
+	stmt += " ORDER BY " + strings.Join(orderBy, ", ") + " LIMIT " + b.arg(size+1)
+
+	rows, err := db.QueryContext(ctx, stmt, b.args...)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	defer rows.Close()
+
+	var items []T
+	var keys [][]string
+	for rows.Next() {
+		var item T
+		dest := fields(&item)
+		raw := make([]any, len(order))
+		for i := range raw {
+			dest = append(dest, &raw[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return Page[T]{}, err
+		}
+		values := make([]string, len(raw))
+		for i, v := range raw {
+			values[i] = formatValue(v)
+		}
+		items = append(items, item)
+		keys = append(keys, values)
+	}
+	if err := rows.Close(); err != nil {
+		return Page[T]{}, err
+	}
+	if err := rows.Err(); err != nil {
+		return Page[T]{}, err
+	}
+
+	hasMore := len(items) > size
+	if hasMore {
+		items, keys = items[:size], keys[:size]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	page := Page[T]{Items: items}
+	if len(items) == 0 {
+		return page, nil
+	}
+
+	// A cursor always comes from a row on the other side of this page.
+	hasNext, hasPrev := hasMore, cursor != nil
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+	if hasNext {
+		page.Next = &Cursor{Values: keys[len(keys)-1]}
+	}
+	if hasPrev {
+		page.Prev = &Cursor{Backward: true, Values: keys[0]}
+	}
+	return page, nil
+}
+
+// Count returns the number of rows of res matching q.
+func Count(ctx context.Context, db DBTX, res Resource, q Query) (int64, error) {
+	var b builder
+	where, err := b.where(res, q)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt := "SELECT count(*) FROM " + res.From
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var count int64
+	err = db.QueryRowContext(ctx, stmt, b.args...).Scan(&count)
+	return count, err
+}
+
+type orderKey struct {
+	sql  string
+	typ  Type
+	desc bool
+}
+
+// order returns the sort of q followed by the key column, or the key column
+// alone by default.
+func (res Resource) order(q Query) []orderKey {
+	var order []orderKey
+	for _, s := range q.Sort {
+		col, _ := res.column(s.Column)
+		if col.SQL == res.Key {
+			return append(order, orderKey{sql: col.SQL, typ: Int, desc: s.Desc})
+		}
+		order = append(order, orderKey{sql: col.SQL, typ: col.Type, desc: s.Desc})
+	}
+	return append(order, orderKey{sql: res.Key, typ: Int})
+}
+
+// builder accumulates positional arguments.
+type builder struct {
+	args []any
+}
+
+func (b *builder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *builder) where(res Resource, q Query) ([]string, error) {
+	var conds []string
+
+	for _, f := range q.Filters {
+		col, ok := res.column(f.Column)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown filter column %q", ErrInvalid, f.Column)
+		}
+
+		switch f.Op {
+		case In:
+			placeholders := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				placeholders[i] = b.arg(v)
+			}
+			conds = append(conds, col.SQL+" IN ("+strings.Join(placeholders, ", ")+")")
+		case Contains:
+			conds = append(conds, col.SQL+" ILIKE '%' || "+b.arg(escapeLike(f.Values[0].(string)))+" || '%'")
+		case Ne:
+			conds = append(conds, col.SQL+" <> "+b.arg(f.Values[0]))
+		default:
+			conds = append(conds, col.SQL+" "+string(f.Op)+" "+b.arg(f.Values[0]))
+		}
+	}
+
+	for _, c := range q.Where {
+		parts := strings.Split(c.SQL, "?")
+		if len(parts)-1 != len(c.Args) {
+			return nil, fmt.Errorf("listquery: condition %q expects %d arguments, got %d", c.SQL, len(parts)-1, len(c.Args))
+		}
+		var sb strings.Builder
+		sb.WriteString(parts[0])
+		for i, arg := range c.Args {
+			sb.WriteString(b.arg(arg))
+			sb.WriteString(parts[i+1])
+		}
+		conds = append(conds, "("+sb.String()+")")
+	}
+
+	return conds, nil
+}
+
+// keyset returns the condition selecting rows strictly after the cursor in
+// the given order, or strictly before it when reading backward:
+// (a > x) OR (a = x AND b > y) OR ..., with each comparison following the
+// direction of its column.
+func (b *builder) keyset(res Resource, order []orderKey, cursor *Cursor) (string, error) {
+	if len(cursor.Values) != len(order) {
+		return "", fmt.Errorf("%w: cursor does not match the sort order", ErrInvalid)
+	}
+
+	values := make([]string, len(order))
+	for i, key := range order {
+		v, err := parseValue(key.typ, cursor.Values[i])
+		if err != nil {
+			return "", fmt.Errorf("%w: malformed cursor", ErrInvalid)
+		}
+		values[i] = b.arg(v)
+	}
+
+	alternatives := make([]string, len(order))
+	for i, key := range order {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, order[j].sql+" = "+values[j])
+		}
+		op := ">"
+		if key.desc != cursor.Backward {
+			op = "<"
+		}
+		terms = append(terms, key.sql+" "+op+" "+values[i])
+		alternatives[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,183 @@
+package listquery
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// stubDriver answers every query with the rows of the test, recording the
+// statement and its arguments.
+type stubDriver struct {
+	rows  [][]driver.Value
+	stmt  string
+	args  []any
+	width int
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error) { return stubConn{d}, nil }
+
+func (d *stubDriver) Connect(context.Context) (driver.Conn, error) { return stubConn{d}, nil }
+
+func (d *stubDriver) Driver() driver.Driver { return d }
+
+type stubConn struct{ d *stubDriver }
+
+func (c stubConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c stubConn) Close() error                        { return nil }
+func (c stubConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c stubConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.stmt = query
+	c.d.args = nil
+	for _, a := range args {
+		c.d.args = append(c.d.args, a.Value)
+	}
+	return &stubRows{rows: c.d.rows, width: c.d.width}, nil
+}
+
+type stubRows struct {
+	rows  [][]driver.Value
+	width int
+}
+
+func (r *stubRows) Columns() []string { return make([]string, r.width) }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type language struct {
+	ID   int64
+	Name string
+}
+
+var pageResource = Resource{
+	From: "language",
+	Columns: []Column{
+		{Name: "id", SQL: "id", Type: Int},
+		{Name: "name", SQL: "name", Type: String},
+	},
+	Key: "id",
+}
+
+func languageFields(l *language) []any { return []any{&l.ID, &l.Name} }
+
+// row returns the selected columns of a language followed by its sort keys,
+// name then id.
+func row(id int64, name string) []driver.Value {
+	return []driver.Value{id, name, name, id}
+}
+
+func TestFetch(t *testing.T) {
+	sortByName := Query{Sort: []Sort{{Column: "name"}}, Filters: []Filter{{Column: "name", Op: Ne, Values: []any{"Klingon"}}}}
+
+	tests := []struct {
+		name     string
+		cursor   *Cursor
+		rows     [][]driver.Value
+		wantIDs  []int64
+		wantNext *Cursor
+		wantPrev *Cursor
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "first page with more",
+			rows:     [][]driver.Value{row(3, "Basque"), row(1, "Catalan"), row(2, "Dutch")},
+			wantIDs:  []int64{3, 1},
+			wantNext: &Cursor{Values: []string{"Catalan", "1"}},
+			wantSQL:  "SELECT id, name, name, id FROM language WHERE name <> $1 ORDER BY name ASC, id ASC LIMIT $2",
+			wantArgs: []any{"Klingon", int64(3)},
+		},
+		{
+			name:     "last page",
+			cursor:   &Cursor{Values: []string{"Catalan", "1"}},
+			rows:     [][]driver.Value{row(2, "Dutch")},
+			wantIDs:  []int64{2},
+			wantPrev: &Cursor{Backward: true, Values: []string{"Dutch", "2"}},
+			wantSQL:  "SELECT id, name, name, id FROM language WHERE name <> $1 AND ((name > $2) OR (name = $2 AND id > $3)) ORDER BY name ASC, id ASC LIMIT $4",
+			wantArgs: []any{"Klingon", "Catalan", int64(1), int64(3)},
+		},
+		{
+			name:     "backward to the first page",
+			cursor:   &Cursor{Backward: true, Values: []string{"Dutch", "2"}},
+			rows:     [][]driver.Value{row(1, "Catalan"), row(3, "Basque")},
+			wantIDs:  []int64{3, 1},
+			wantNext: &Cursor{Values: []string{"Catalan", "1"}},
+			wantSQL:  "SELECT id, name, name, id FROM language WHERE name <> $1 AND ((name < $2) OR (name = $2 AND id < $3)) ORDER BY name DESC, id DESC LIMIT $4",
+			wantArgs: []any{"Klingon", "Dutch", int64(2), int64(3)},
+		},
+		{
+			name:     "backward with more before",
+			cursor:   &Cursor{Backward: true, Values: []string{"Dutch", "2"}},
+			rows:     [][]driver.Value{row(1, "Catalan"), row(3, "Basque"), row(4, "Arabic")},
+			wantIDs:  []int64{3, 1},
+			wantNext: &Cursor{Values: []string{"Catalan", "1"}},
+			wantPrev: &Cursor{Backward: true, Values: []string{"Basque", "3"}},
+		},
+		{
+			name:    "empty page",
+			cursor:  &Cursor{Values: []string{"Zulu", "9"}},
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &stubDriver{rows: tt.rows, width: 4}
+			db := sql.OpenDB(d)
+			defer db.Close()
+
+			page, err := Fetch(context.Background(), db, pageResource, sortByName, tt.cursor, 2, languageFields)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+
+			var ids []int64
+			for _, l := range page.Items {
+				ids = append(ids, l.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("Fetch() ids = %v; want %v", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(page.Next, tt.wantNext) {
+				t.Errorf("Fetch() next = %+v; want %+v", page.Next, tt.wantNext)
+			}
+			if !reflect.DeepEqual(page.Prev, tt.wantPrev) {
+				t.Errorf("Fetch() prev = %+v; want %+v", page.Prev, tt.wantPrev)
+			}
+			if tt.wantSQL != "" && d.stmt != tt.wantSQL {
+				t.Errorf("Fetch() statement = %s\nwant %s", d.stmt, tt.wantSQL)
+			}
+			if tt.wantArgs != nil && !reflect.DeepEqual(d.args, tt.wantArgs) {
+				t.Errorf("Fetch() args = %v; want %v", d.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	d := &stubDriver{rows: [][]driver.Value{{int64(12)}}, width: 1}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	q := Query{Filters: []Filter{{Column: "name", Op: Contains, Values: []any{"an"}}}}
+	count, err := Count(context.Background(), db, pageResource, q)
+	if err != nil || count != 12 {
+		t.Fatalf("Count() = %d, %v; want 12", count, err)
+	}
+	if !strings.HasPrefix(d.stmt, "SELECT count(*) FROM language WHERE name ILIKE") {
+		t.Errorf("Count() statement = %s", d.stmt)
+	}
+}
@@ -0,0 +1,325 @@
+// Package listquery gives list endpoints sorting, filtering and keyset
+// pagination from their query parameters. A resource declares its columns
+// once; Parse turns request parameters into a Query, and Fetch runs it as
+// parameterised SQL, so that no client input is ever spliced into a
+// statement.
+//
+// Supported parameters, with col being any declared column:
+//
+//	sort=name,-created_at       ascending name, then descending created_at
+//	col=v                       equality; repeating it matches any value
+//	col~=v                      case-insensitive substring match
+//	col!=v                      inequality
+//	col>=v, col<=v              inclusive bounds
+//	filter=col in (a, b)        expression form, with =, !=, ~=, <, <=, >, >=
+//	                            and in; repeat filter to combine conditions
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Time
+)
+
+type Op string
+
+const (
+	Eq       Op = "="
+	Ne       Op = "!="
+	Contains Op = "~="
+	Lt       Op = "<"
+	Le       Op = "<="
+	Gt       Op = ">"
+	Ge       Op = ">="
+	In       Op = "in"
+)
+
+// MaxInValues bounds the number of values of a single in filter.
+const MaxInValues = 100
+
+// ErrInvalid wraps every error caused by client input.
+var ErrInvalid = errors.New("listquery: invalid query")
+
+type Column struct {
+	// Name is the name clients use in sort and filter parameters.
+	Name string
+	// SQL is the column expression. Nullable columns should be wrapped in
+	// COALESCE so that they can take part in keyset comparisons.
+	SQL  string
+	Type Type
+}
+
+type Resource struct {
+	// From is the FROM clause, typically a table name.
+	From    string
+	Columns []Column
+	// Key is a unique, non-null integer column used to break sort ties.
+	Key string
+	// Params lists extra query parameters the handler interprets itself,
+	// which Parse must not mistake for filters.
+	Params []string
+}
+
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+type Filter struct {
+	Column string
+	Op     Op
+	Values []any
+}
+
+// Condition is a raw SQL condition added by a handler, with ? standing for
+// each of Args in order.
+type Condition struct {
+	SQL  string
+	Args []any
+}
+
+type Query struct {
+	Filters []Filter
+	Sort    []Sort
+	Where   []Condition
+}
+
+// reserved are the parameters handled by the pagination layer.
+var reserved = map[string]bool{
+	"sort":                true,
+	"filter":              true,
+	"page_size":           true,
+	"page_token":          true,
+	"include_total_count": true,
+}
+
+// Parse reads the sort and filter parameters of values. Unknown columns and
+// malformed values are reported as errors wrapping ErrInvalid.
+func (res Resource) Parse(values url.Values) (Query, error) {
+	var q Query
+
+	if s := values.Get("sort"); s != "" {
+		seen := map[string]bool{}
+		for _, field := range strings.Split(s, ",") {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimLeft(field, "+-")
+			if _, ok := res.column(field); !ok {
+				return Query{}, fmt.Errorf("%w: unknown sort column %q", ErrInvalid, field)
+			}
+			if seen[field] {
+				return Query{}, fmt.Errorf("%w: column %q sorted twice", ErrInvalid, field)
+			}
+			seen[field] = true
+			q.Sort = append(q.Sort, Sort{Column: field, Desc: desc})
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if reserved[key] || res.isParam(key) {
+			continue
+		}
+
+		name, op := key, Eq
+		for suffix, o := range map[string]Op{"~": Contains, "!": Ne, ">": Ge, "<": Le} {
+			if strings.HasSuffix(key, suffix) {
+				name, op = strings.TrimSuffix(key, suffix), o
+			}
+		}
+
+		raw := values[key]
+		if op == Eq && len(raw) > 1 {
+			op = In
+		} else if len(raw) > 1 {
+			return Query{}, fmt.Errorf("%w: %s given more than once", ErrInvalid, key)
+		}
+
+		f, err := res.filter(name, op, raw)
+		if err != nil {
+			return Query{}, err
+		}
+		q.Filters = append(q.Filters, f)
+	}
+
+	for _, expr := range values["filter"] {
+		name, op, raw, err := parseExpr(expr)
+		if err != nil {
+			return Query{}, err
+		}
+		f, err := res.filter(name, op, raw)
+		if err != nil {
+			return Query{}, err
+		}
+		q.Filters = append(q.Filters, f)
+	}
+
+	return q, nil
+}
+
+// String returns a canonical form of the sort and filters of q, suitable for
+// checking that a page token is reused with the same query.
+func (q Query) String() string {
+	var b strings.Builder
+	for _, s := range q.Sort {
+		if s.Desc {
+			b.WriteByte('-')
+		}
+		b.WriteString(s.Column)
+		b.WriteByte(',')
+	}
+	filters := make([]string, len(q.Filters))
+	for i, f := range q.Filters {
+		filters[i] = fmt.Sprintf("%s %s %v", f.Column, f.Op, f.Values)
+	}
+	sort.Strings(filters)
+	b.WriteString(strings.Join(filters, ";"))
+	return b.String()
+}
+
+func (res Resource) filter(name string, op Op, raw []string) (Filter, error) {
+	col, ok := res.column(name)
+	if !ok {
+		return Filter{}, fmt.Errorf("%w: unknown filter column %q", ErrInvalid, name)
+	}
+	if op == Contains && col.Type != String {
+		return Filter{}, fmt.Errorf("%w: ~= only applies to text columns", ErrInvalid)
+	}
+	if op == In && len(raw) > MaxInValues {
+		return Filter{}, fmt.Errorf("%w: more than %d values for %s", ErrInvalid, MaxInValues, name)
+	}
+
+	f := Filter{Column: name, Op: op}
+	for _, s := range raw {
+		v, err := parseValue(col.Type, s)
+		if err != nil {
+			return Filter{}, fmt.Errorf("%w: %s: %v", ErrInvalid, name, err)
+		}
+		f.Values = append(f.Values, v)
+	}
+	return f, nil
+}
+
+func (res Resource) column(name string) (Column, bool) {
+	for _, c := range res.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+func (res Resource) isParam(key string) bool {
+	for _, p := range res.Params {
+		if p == key {
+			return true
+		}
+	}
+	return false
+}
+
+// parseExpr parses a filter expression such as "name ~= fra" or
+// "iso_639_1 in (en, 'fr')".
+func parseExpr(expr string) (string, Op, []string, error) {
+	expr = strings.TrimSpace(expr)
+
+	end := strings.IndexFunc(expr, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end <= 0 {
+		return "", "", nil, fmt.Errorf("%w: malformed filter %q", ErrInvalid, expr)
+	}
+	name, rest := expr[:end], strings.TrimSpace(expr[end:])
+
+	var op Op
+	for _, candidate := range []Op{Contains, Ne, Le, Ge, Eq, Lt, Gt} {
+		if strings.HasPrefix(rest, string(candidate)) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" && len(rest) > 2 && strings.EqualFold(rest[:2], "in") && (rest[2] == ' ' || rest[2] == '(') {
+		op = In
+	}
+	if op == "" {
+		return "", "", nil, fmt.Errorf("%w: unknown operator in filter %q", ErrInvalid, expr)
+	}
+	rest = strings.TrimSpace(rest[len(op):])
+
+	if op != In {
+		return name, op, []string{unquote(rest)}, nil
+	}
+
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return "", "", nil, fmt.Errorf("%w: in expects a parenthesised list in %q", ErrInvalid, expr)
+	}
+	var values []string
+	for _, v := range strings.Split(rest[1:len(rest)-1], ",") {
+		if v = unquote(strings.TrimSpace(v)); v != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return "", "", nil, fmt.Errorf("%w: empty in list in %q", ErrInvalid, expr)
+	}
+	return name, op, values, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func parseValue(t Type, s string) (any, error) {
+	switch t {
+	case Int:
+		return strconv.ParseInt(s, 10, 64)
+	case Time:
+		if v, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return v, nil
+		}
+		v, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			return nil, fmt.Errorf("expected an RFC 3339 timestamp or a date, got %q", s)
+		}
+		return v, nil
+	default:
+		return s, nil
+	}
+}
+
+// formatValue is the inverse of parseValue, used to store sort keys in
+// cursors.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
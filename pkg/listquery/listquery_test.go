@@ -0,0 +1,271 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var languages = Resource{
+	From: "language",
+	Columns: []Column{
+		{Name: "id", SQL: "id", Type: Int},
+		{Name: "name", SQL: "name", Type: String},
+		{Name: "created_at", SQL: "created_at", Type: Time},
+	},
+	Key:    "id",
+	Params: []string{"as_of"},
+}
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		sort    string
+		want    []Sort
+		wantErr bool
+	}{
+		{"name", []Sort{{Column: "name"}}, false},
+		{"-created_at,name", []Sort{{Column: "created_at", Desc: true}, {Column: "name"}}, false},
+		{" +name , -id ", []Sort{{Column: "name"}, {Column: "id", Desc: true}}, false},
+		{"population", nil, true},
+		{"name,-name", nil, true},
+		{"name,", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			q, err := languages.Parse(url.Values{"sort": {tt.sort}})
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Parse(sort=%q) error = %v; want %v", tt.sort, err, ErrInvalid)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(q.Sort, tt.want) {
+				t.Errorf("Parse(sort=%q) = %+v, %v; want %+v", tt.sort, q.Sort, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    []Filter
+		wantErr bool
+	}{
+		{"equality", "name=French", []Filter{{Column: "name", Op: Eq, Values: []any{"French"}}}, false},
+		{"repeated equality is in", "id=1&id=2", []Filter{{Column: "id", Op: In, Values: []any{int64(1), int64(2)}}}, false},
+		{"contains", "name~=fra", []Filter{{Column: "name", Op: Contains, Values: []any{"fra"}}}, false},
+		{"not equal", "id!=3", []Filter{{Column: "id", Op: Ne, Values: []any{int64(3)}}}, false},
+		{"bounds in key order", "id>=2&id<=5", []Filter{{Column: "id", Op: Le, Values: []any{int64(5)}}, {Column: "id", Op: Ge, Values: []any{int64(2)}}}, false},
+		{"date", "created_at>=2024-05-01", []Filter{{Column: "created_at", Op: Ge, Values: []any{day}}}, false},
+		{"reserved and handler params are skipped", "page_size=5&page_token=x&include_total_count=true&as_of=2024-01-01", nil, false},
+		{"expression", "filter=" + url.QueryEscape("name ~= 'fr'"), []Filter{{Column: "name", Op: Contains, Values: []any{"fr"}}}, false},
+		{"expression in", "filter=" + url.QueryEscape("id in (1, '2')"), []Filter{{Column: "id", Op: In, Values: []any{int64(1), int64(2)}}}, false},
+		{"expression in without space", "filter=" + url.QueryEscape("id IN(4)"), []Filter{{Column: "id", Op: In, Values: []any{int64(4)}}}, false},
+		{"expression less than", "filter=" + url.QueryEscape("id<10"), []Filter{{Column: "id", Op: Lt, Values: []any{int64(10)}}}, false},
+		{"unknown column", "population=1", nil, true},
+		{"bad integer", "id=abc", nil, true},
+		{"bad date", "created_at>=yesterday", nil, true},
+		{"contains on a number", "id~=1", nil, true},
+		{"repeated bound", "id>=1&id>=2", nil, true},
+		{"malformed expression", "filter=" + url.QueryEscape("= 1"), nil, true},
+		{"unknown operator", "filter=" + url.QueryEscape("name like fr"), nil, true},
+		{"unparenthesised in", "filter=" + url.QueryEscape("id in 1, 2"), nil, true},
+		{"empty in", "filter=" + url.QueryEscape("id in ()"), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q, err := languages.Parse(values)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Parse(%q) error = %v; want %v", tt.query, err, ErrInvalid)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(q.Filters, tt.want) {
+				t.Errorf("Parse(%q) = %+v, %v; want %+v", tt.query, q.Filters, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTooManyInValues(t *testing.T) {
+	values := url.Values{}
+	for i := 0; i <= MaxInValues; i++ {
+		values.Add("id", "1")
+	}
+	if _, err := languages.Parse(values); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Parse(%d ids) error = %v; want %v", MaxInValues+1, err, ErrInvalid)
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	a, _ := languages.Parse(url.Values{"sort": {"-name"}, "id": {"1"}, "name~": {"fr"}})
+	b, _ := languages.Parse(url.Values{"name~": {"fr"}, "sort": {"-name"}, "id": {"1"}})
+	c, _ := languages.Parse(url.Values{"sort": {"name"}, "id": {"1"}, "name~": {"fr"}})
+
+	if a.String() != b.String() {
+		t.Errorf("String() = %q and %q for the same query", a.String(), b.String())
+	}
+	if a.String() == c.String() {
+		t.Errorf("String() = %q for different sorts", a.String())
+	}
+}
+
+func TestWhere(t *testing.T) {
+	q := Query{
+		Filters: []Filter{
+			{Column: "id", Op: In, Values: []any{int64(1), int64(2)}},
+			{Column: "name", Op: Contains, Values: []any{"50%_off"}},
+			{Column: "id", Op: Ne, Values: []any{int64(3)}},
+			{Column: "id", Op: Ge, Values: []any{int64(0)}},
+		},
+		Where: []Condition{{SQL: "deleted_at IS NULL OR ?::boolean", Args: []any{true}}},
+	}
+
+	var b builder
+	conds, err := b.where(languages, q)
+	if err != nil {
+		t.Fatalf("where() error = %v", err)
+	}
+	want := []string{
+		"id IN ($1, $2)",
+		"name ILIKE '%' || $3 || '%'",
+		"id <> $4",
+		"id >= $5",
+		"(deleted_at IS NULL OR $6::boolean)",
+	}
+	if !reflect.DeepEqual(conds, want) {
+		t.Errorf("where() = %q; want %q", conds, want)
+	}
+	wantArgs := []any{int64(1), int64(2), `50\%\_off`, int64(3), int64(0), true}
+	if !reflect.DeepEqual(b.args, wantArgs) {
+		t.Errorf("where() args = %v; want %v", b.args, wantArgs)
+	}
+
+	q.Where = []Condition{{SQL: "a = ? AND b = ?", Args: []any{1}}}
+	if _, err := (&builder{}).where(languages, q); err == nil {
+		t.Error("where() with a missing condition argument succeeded; want an error")
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     []Sort
+		cursor   Cursor
+		want     string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{
+			name:     "key only",
+			cursor:   Cursor{Values: []string{"7"}},
+			want:     "((id > $1))",
+			wantArgs: []any{int64(7)},
+		},
+		{
+			name:     "key only backward",
+			cursor:   Cursor{Backward: true, Values: []string{"7"}},
+			want:     "((id < $1))",
+			wantArgs: []any{int64(7)},
+		},
+		{
+			name:     "descending column then key",
+			sort:     []Sort{{Column: "name", Desc: true}},
+			cursor:   Cursor{Values: []string{"French", "7"}},
+			want:     "((name < $1) OR (name = $1 AND id > $2))",
+			wantArgs: []any{"French", int64(7)},
+		},
+		{
+			name:     "descending column then key backward",
+			sort:     []Sort{{Column: "name", Desc: true}},
+			cursor:   Cursor{Backward: true, Values: []string{"French", "7"}},
+			want:     "((name > $1) OR (name = $1 AND id < $2))",
+			wantArgs: []any{"French", int64(7)},
+		},
+		{
+			name:     "sorting by the key ends the order",
+			sort:     []Sort{{Column: "id", Desc: true}, {Column: "name"}},
+			cursor:   Cursor{Values: []string{"7"}},
+			want:     "((id < $1))",
+			wantArgs: []any{int64(7)},
+		},
+		{
+			name:     "time column",
+			sort:     []Sort{{Column: "created_at"}},
+			cursor:   Cursor{Values: []string{"2024-05-01T10:00:00.5Z", "7"}},
+			want:     "((created_at > $1) OR (created_at = $1 AND id > $2))",
+			wantArgs: []any{time.Date(2024, 5, 1, 10, 0, 0, 5e8, time.UTC), int64(7)},
+		},
+		{
+			name:    "cursor from another sort",
+			sort:    []Sort{{Column: "name"}},
+			cursor:  Cursor{Values: []string{"7"}},
+			wantErr: true,
+		},
+		{
+			name:    "malformed cursor value",
+			cursor:  Cursor{Values: []string{"seven"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b builder
+			got, err := b.keyset(languages, languages.order(Query{Sort: tt.sort}), &tt.cursor)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("keyset() error = %v; want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if err != nil || got != tt.want || !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("keyset() = %q %v, %v; want %q %v", got, b.args, err, tt.want, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 5e8, time.UTC)
+	tests := []struct {
+		value any
+		typ   Type
+		want  string
+	}{
+		{int64(42), Int, "42"},
+		{"French", String, "French"},
+		{[]byte("French"), String, "French"},
+		{at, Time, "2024-05-01T10:00:00.5Z"},
+	}
+
+	for _, tt := range tests {
+		got := formatValue(tt.value)
+		if got != tt.want {
+			t.Errorf("formatValue(%v) = %q; want %q", tt.value, got, tt.want)
+		}
+		back, err := parseValue(tt.typ, got)
+		if err != nil {
+			t.Errorf("parseValue(%q) error = %v", got, err)
+		}
+		if b, ok := tt.value.([]byte); ok {
+			tt.value = string(b)
+		}
+		if !reflect.DeepEqual(back, tt.value) {
+			t.Errorf("parseValue(formatValue(%v)) = %v", tt.value, back)
+		}
+	}
+}
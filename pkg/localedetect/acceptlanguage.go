@@ -0,0 +1,182 @@
+package localedetect
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+// MaxPreferences bounds how many Accept-Language entries are considered, so
+// that oversized headers cannot make negotiation expensive.
+const MaxPreferences = 32
+
+// Preference is a single language range of an Accept-Language header.
+type Preference struct {
+	Tag string
+	Q   float64
+}
+
+// ParseAcceptLanguage parses Accept-Language header values into language
+// ranges ordered by decreasing quality, keeping header order among equal
+// qualities. Malformed entries are skipped rather than failing the header.
+func ParseAcceptLanguage(headers ...string) []Preference {
+	var prefs []Preference
+
+	for _, entry := range strings.Split(strings.Join(headers, ","), ",") {
+		if len(prefs) == MaxPreferences {
+			break
+		}
+
+		params := strings.Split(entry, ";")
+		tag := normalize(params[0])
+		if !validRange(tag) {
+			continue
+		}
+
+		q, ok := 1.0, true
+		for _, param := range params[1:] {
+			name, value, found := strings.Cut(param, "=")
+			if !found || !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			q, ok = parseQuality(strings.TrimSpace(value))
+		}
+		if !ok {
+			continue
+		}
+
+		prefs = append(prefs, Preference{Tag: tag, Q: q})
+	}
+
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].Q > prefs[j].Q
+	})
+	return prefs
+}
+
+// Negotiate picks the configured locale of site that best satisfies the
+// Accept-Language header values. Each range is matched exactly first, then
+// by RFC 4647 lookup (dropping subtags from the end), then against any locale
+// sharing its primary language. Ranges with q=0 exclude matching locales.
+func Negotiate(site urlpattern.Site, headers ...string) (string, bool) {
+	prefs := ParseAcceptLanguage(headers...)
+
+	var excluded []string
+	for _, p := range prefs {
+		if p.Q == 0 {
+			excluded = append(excluded, p.Tag)
+		}
+	}
+	isExcluded := func(locale string) bool {
+		locale = normalize(locale)
+		for _, e := range excluded {
+			if e == "*" || e == locale || strings.HasPrefix(locale, e+"-") {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, p := range prefs {
+		if p.Q == 0 {
+			break
+		}
+
+		if p.Tag == "*" {
+			if l, ok := lookup(site, site.DefaultLocale); ok && !isExcluded(l) {
+				return l, true
+			}
+			for _, l := range site.Locales {
+				if !isExcluded(l.Tag) {
+					return l.Tag, true
+				}
+			}
+			continue
+		}
+
+		if l, ok := lookup(site, p.Tag); ok && !containsTag(excluded, p.Tag) {
+			return l, true
+		}
+
+		for tag := truncate(p.Tag); tag != ""; tag = truncate(tag) {
+			if l, ok := lookup(site, tag); ok && !isExcluded(l) {
+				return l, true
+			}
+		}
+
+		language := primary(p.Tag)
+		if l, ok := lookup(site, site.DefaultLocale); ok && primary(l) == language && !isExcluded(l) {
+			return l, true
+		}
+		for _, l := range site.Locales {
+			if primary(l.Tag) == language && !isExcluded(l.Tag) {
+				return l.Tag, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// validRange reports whether tag is "*" or a language range of alphanumeric
+// subtags of 1 to 8 characters starting with an alphabetic one.
+func validRange(tag string) bool {
+	if tag == "*" {
+		return true
+	}
+	if tag == "" {
+		return false
+	}
+
+	for i, subtag := range strings.Split(tag, "-") {
+		if len(subtag) == 0 || len(subtag) > 8 {
+			return false
+		}
+		for _, r := range subtag {
+			isAlpha := r >= 'a' && r <= 'z'
+			isDigit := r >= '0' && r <= '9'
+			if !isAlpha && !(isDigit && i > 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseQuality parses a qvalue, which must lie between 0 and 1.
+func parseQuality(value string) (float64, bool) {
+	q, err := strconv.ParseFloat(value, 64)
+	if err != nil || q != q || q < 0 || q > 1 {
+		return 0, false
+	}
+	return q, true
+}
+
+// truncate removes the last subtag of tag, and a trailing singleton with it,
+// as RFC 4647 lookup does.
+func truncate(tag string) string {
+	i := strings.LastIndex(tag, "-")
+	if i < 0 {
+		return ""
+	}
+	tag = tag[:i]
+	if j := strings.LastIndex(tag, "-"); j >= 0 && len(tag)-j == 2 {
+		tag = tag[:j]
+	}
+	return tag
+}
+
+func primary(tag string) string {
+	return strings.SplitN(normalize(tag), "-", 2)[0]
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
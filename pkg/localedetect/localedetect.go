@@ -0,0 +1,253 @@
+// Package localedetect resolves the locale of an incoming request the same way
+// the admin service defines it: from the site's URL strategies, a cookie, a
+// query parameter or the Accept-Language header, in a configurable order.
+//
+// Consumer services fetch the site configuration once with FetchSite and
+// refresh it with SetSite as often as they see fit:
+//
+//	site, err := localedetect.FetchSite(ctx, http.DefaultClient, "http://uurl-admin:8080", 1)
+//	d := localedetect.New(site)
+//	mux.Handle("/", d.Middleware(handler))
+package localedetect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+type Source string
+
+const (
+	Path           Source = "path"
+	Subdomain      Source = "subdomain"
+	CCTLD          Source = "cctld"
+	Cookie         Source = "cookie"
+	Query          Source = "query"
+	AcceptLanguage Source = "accept_language"
+	// Default is reported when no source matched and the site's default
+	// locale was used.
+	Default Source = "default"
+)
+
+// DefaultOrder trusts what the URL says over what the client prefers.
+var DefaultOrder = []Source{Path, Subdomain, CCTLD, Query, Cookie, AcceptLanguage}
+
+// DefaultCookieName is the cookie read when Detector.CookieName is empty.
+const DefaultCookieName = "locale"
+
+type Result struct {
+	Locale string `json:"locale"`
+	Source Source `json:"source"`
+	// Path is the canonical path when the locale came from the URL path or
+	// query, and the request path otherwise.
+	Path string `json:"path"`
+}
+
+type Detector struct {
+	// Order lists the sources to try, first match wins.
+	Order []Source
+	// CookieName is the cookie holding an explicitly chosen locale.
+	CookieName string
+	// TrustForwardedHost makes subdomain and ccTLD detection use the
+	// X-Forwarded-Host header set by a reverse proxy.
+	TrustForwardedHost bool
+
+	mu   sync.RWMutex
+	site urlpattern.Site
+}
+
+// New returns a detector for site trying sources in order, or DefaultOrder
+// when none are given.
+func New(site urlpattern.Site, order ...Source) *Detector {
+	if len(order) == 0 {
+		order = DefaultOrder
+	}
+	return &Detector{Order: order, CookieName: DefaultCookieName, site: site}
+}
+
+// SetSite replaces the site configuration, e.g. after fetching a fresh
+// snapshot. It is safe to call while requests are being detected.
+func (d *Detector) SetSite(site urlpattern.Site) {
+	d.mu.Lock()
+	d.site = site
+	d.mu.Unlock()
+}
+
+// Site returns the current site configuration.
+func (d *Detector) Site() urlpattern.Site {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.site
+}
+
+// Detect returns the locale of r. When no source matches it falls back to the
+// site's default locale, and reports false if there is none either.
+func (d *Detector) Detect(r *http.Request) (Result, bool) {
+	site := d.Site()
+	u := d.requestURL(r)
+	requestPath := u.RequestURI()
+
+	for _, source := range d.Order {
+		switch source {
+		case Path, Subdomain, CCTLD:
+			if l, canonical, ok := site.Match(u, strategyOf(source)); ok {
+				return Result{Locale: l.Tag, Source: source, Path: canonical}, true
+			}
+		case Query:
+			if l, canonical, ok := matchQuery(site, u); ok {
+				return Result{Locale: l, Source: Query, Path: canonical}, true
+			}
+		case Cookie:
+			name := d.CookieName
+			if name == "" {
+				name = DefaultCookieName
+			}
+			if c, err := r.Cookie(name); err == nil {
+				if l, ok := lookup(site, c.Value); ok {
+					return Result{Locale: l, Source: Cookie, Path: requestPath}, true
+				}
+			}
+		case AcceptLanguage:
+			if l, ok := Negotiate(site, r.Header.Values("Accept-Language")...); ok {
+				return Result{Locale: l, Source: AcceptLanguage, Path: requestPath}, true
+			}
+		}
+	}
+
+	if l, ok := lookup(site, site.DefaultLocale); ok {
+		return Result{Locale: l, Source: Default, Path: requestPath}, true
+	}
+
+	return Result{Path: requestPath}, false
+}
+
+type contextKey struct{}
+
+// Middleware detects the locale of every request and stores the result in its
+// context, where FromContext retrieves it.
+func (d *Detector) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if result, ok := d.Detect(r); ok {
+			r = r.WithContext(context.WithValue(r.Context(), contextKey{}, result))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// FromContext returns the result stored by Middleware.
+func FromContext(ctx context.Context) (Result, bool) {
+	result, ok := ctx.Value(contextKey{}).(Result)
+	return result, ok
+}
+
+// FetchSite downloads the URL configuration of a site from the admin API at
+// baseURL. Authentication, if required, is left to client's transport.
+func FetchSite(ctx context.Context, client *http.Client, baseURL string, siteID int32) (urlpattern.Site, error) {
+	endpoint := fmt.Sprintf("%s/site/%d", strings.TrimSuffix(baseURL, "/"), siteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return urlpattern.Site{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return urlpattern.Site{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return urlpattern.Site{}, fmt.Errorf("localedetect: fetching %s: %s", endpoint, resp.Status)
+	}
+
+	var site urlpattern.Site
+	if err := json.NewDecoder(resp.Body).Decode(&site); err != nil {
+		return urlpattern.Site{}, fmt.Errorf("localedetect: decoding site: %w", err)
+	}
+	return site, nil
+}
+
+func (d *Detector) requestURL(r *http.Request) *url.URL {
+	u := *r.URL
+	u.Host = r.Host
+	if d.TrustForwardedHost {
+		if fwd := r.Header.Get("X-Forwarded-Host"); fwd != "" {
+			u.Host = strings.TrimSpace(strings.Split(fwd, ",")[0])
+		}
+	}
+	if u.Host == "" {
+		u.Host = r.URL.Host
+	}
+	return &u
+}
+
+// matchQuery resolves the site's locale query parameter, accepting either a
+// locale tag or the value of a query_param locale.
+func matchQuery(site urlpattern.Site, u *url.URL) (string, string, bool) {
+	param := site.QueryParam
+	if param == "" {
+		param = "lang"
+	}
+
+	q := u.Query()
+	value := q.Get(param)
+	if value == "" {
+		return "", "", false
+	}
+
+	l, ok := lookup(site, value)
+	if !ok {
+		for _, candidate := range site.Locales {
+			if candidate.Strategy == urlpattern.QueryParam && strings.EqualFold(candidate.Value, value) {
+				l, ok = candidate.Tag, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return "", "", false
+	}
+
+	q.Del(param)
+	c := *u
+	c.RawQuery = q.Encode()
+	return l, c.RequestURI(), true
+}
+
+// lookup returns the configured tag equal to tag, ignoring case and accepting
+// underscores as separators.
+func lookup(site urlpattern.Site, tag string) (string, bool) {
+	tag = normalize(tag)
+	if tag == "" {
+		return "", false
+	}
+	for _, l := range site.Locales {
+		if normalize(l.Tag) == tag {
+			return l.Tag, true
+		}
+	}
+	return "", false
+}
+
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+func strategyOf(source Source) urlpattern.Strategy {
+	switch source {
+	case Path:
+		return urlpattern.PathPrefix
+	case Subdomain:
+		return urlpattern.Subdomain
+	default:
+		return urlpattern.CCTLD
+	}
+}
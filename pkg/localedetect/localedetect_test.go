@@ -0,0 +1,281 @@
+package localedetect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+var testSite = urlpattern.Site{
+	ID:            1,
+	Host:          "example.com",
+	QueryParam:    "lang",
+	DefaultLocale: "en-US",
+	Locales: []urlpattern.Locale{
+		{Tag: "en-US", Strategy: urlpattern.PathPrefix, Value: "en"},
+		{Tag: "en-GB", Strategy: urlpattern.PathPrefix, Value: "uk"},
+		{Tag: "pt-BR", Strategy: urlpattern.PathPrefix, Value: "pt-br"},
+		{Tag: "pt-PT", Strategy: urlpattern.Subdomain, Value: "pt"},
+		{Tag: "de-DE", Strategy: urlpattern.CCTLD, TLD: "de"},
+		{Tag: "fr-FR", Strategy: urlpattern.QueryParam, Value: "fr"},
+		{Tag: "zh-TW", Strategy: urlpattern.PathPrefix, Value: "tw"},
+	},
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []string
+		want   string
+		ok     bool
+	}{
+		{"exact match", []string{"pt-BR"}, "pt-BR", true},
+		{"typical browser header", []string{"pt-BR,pt;q=0.9,en-US;q=0.8,en;q=0.7"}, "pt-BR", true},
+		{"chrome en-GB", []string{"en-GB,en-US;q=0.9,en;q=0.8"}, "en-GB", true},
+		{"case insensitive", []string{"EN-gb"}, "en-GB", true},
+		{"underscore separator", []string{"pt_BR"}, "pt-BR", true},
+		{"whitespace everywhere", []string{"  fr-CA ; q = 0.4 ,  de-DE;q=0.5  "}, "de-DE", true},
+		{"quality ordering beats header order", []string{"de;q=0.1, pt-PT;q=0.8"}, "pt-PT", true},
+		{"equal quality keeps header order", []string{"de, pt-PT"}, "de-DE", true},
+		{"language only prefers default locale", []string{"en"}, "en-US", true},
+		{"language fallback to another region", []string{"fr-CH, fr;q=0.9, en;q=0.8"}, "fr-FR", true},
+		{"lookup drops script subtag", []string{"zh-Hant-TW"}, "zh-TW", true},
+		{"lookup drops private use singleton", []string{"pt-BR-x-private"}, "pt-BR", true},
+		{"unsupported language then supported", []string{"ja-JP, ko;q=0.9, pt;q=0.5"}, "pt-BR", true},
+		{"wildcard uses default locale", []string{"ja, *;q=0.1"}, "en-US", true},
+		{"q=0 excludes exact locale", []string{"en-US;q=0, en"}, "en-GB", true},
+		{"q=0 on language excludes its regions", []string{"en;q=0, *"}, "pt-BR", true},
+		{"q=0 everything", []string{"*;q=0"}, "", false},
+		{"only unacceptable", []string{"de;q=0"}, "", false},
+		{"invalid quality is skipped", []string{"de;q=abc, pt-PT;q=0.5"}, "pt-PT", true},
+		{"quality above one is skipped", []string{"de;q=1.5, pt-PT;q=0.5"}, "pt-PT", true},
+		{"negative quality is skipped", []string{"de;q=-1, pt-PT;q=0.5"}, "pt-PT", true},
+		{"NaN quality is skipped", []string{"de;q=NaN, pt-PT;q=0.5"}, "pt-PT", true},
+		{"unknown parameters are ignored", []string{"de;level=1;q=0.9"}, "de-DE", true},
+		{"empty entries", []string{",,, ;q=1, ,pt-BR"}, "pt-BR", true},
+		{"garbage tags", []string{"<script>, 12-34, en-US"}, "en-US", true},
+		{"overlong subtag", []string{"english-unitedstates, pt-BR;q=0.1"}, "pt-BR", true},
+		{"multiple header lines", []string{"ja", "de-DE;q=0.9"}, "de-DE", true},
+		{"empty header", []string{""}, "", false},
+		{"no header", nil, "", false},
+		{"grandfathered tag", []string{"i-klingon"}, "", false},
+		{"entries beyond the limit are ignored", []string{strings.Repeat("ja,", MaxPreferences) + "pt-BR"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Negotiate(testSite, tt.header...)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Negotiate(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("da, en-gb;q=0.8, en;q=0.7, *;q=0.1")
+	want := []Preference{{"da", 1}, {"en-gb", 0.8}, {"en", 0.7}, {"*", 0.1}}
+
+	if len(got) != len(want) {
+		t.Fatalf("ParseAcceptLanguage() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParseAcceptLanguage()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		host       string
+		header     map[string]string
+		cookie     string
+		order      []Source
+		trustProxy bool
+		want       Result
+		ok         bool
+	}{
+		{
+			name: "path prefix",
+			url:  "/pt-br/sobre?x=1",
+			host: "example.com",
+			want: Result{Locale: "pt-BR", Source: Path, Path: "/sobre?x=1"},
+			ok:   true,
+		},
+		{
+			name: "path prefix is case insensitive",
+			url:  "/PT-BR/",
+			host: "example.com",
+			want: Result{Locale: "pt-BR", Source: Path, Path: "/"},
+			ok:   true,
+		},
+		{
+			name: "prefix must be a whole segment",
+			url:  "/english/page",
+			host: "example.com",
+			want: Result{Locale: "en-US", Source: Default, Path: "/english/page"},
+			ok:   true,
+		},
+		{
+			name: "subdomain with port",
+			url:  "/sobre",
+			host: "pt.example.com:8443",
+			want: Result{Locale: "pt-PT", Source: Subdomain, Path: "/sobre"},
+			ok:   true,
+		},
+		{
+			name: "cctld",
+			url:  "/ueber",
+			host: "EXAMPLE.DE",
+			want: Result{Locale: "de-DE", Source: CCTLD, Path: "/ueber"},
+			ok:   true,
+		},
+		{
+			name: "query parameter value",
+			url:  "/page?lang=fr&x=1",
+			host: "example.com",
+			want: Result{Locale: "fr-FR", Source: Query, Path: "/page?x=1"},
+			ok:   true,
+		},
+		{
+			name: "query parameter locale tag",
+			url:  "/page?lang=pt_br",
+			host: "example.com",
+			want: Result{Locale: "pt-BR", Source: Query, Path: "/page"},
+			ok:   true,
+		},
+		{
+			name:   "cookie",
+			url:    "/page",
+			host:   "example.com",
+			cookie: "en-gb",
+			want:   Result{Locale: "en-GB", Source: Cookie, Path: "/page"},
+			ok:     true,
+		},
+		{
+			name:   "unknown cookie falls through to accept-language",
+			url:    "/page",
+			host:   "example.com",
+			cookie: "klingon",
+			header: map[string]string{"Accept-Language": "de-AT,de;q=0.9"},
+			want:   Result{Locale: "de-DE", Source: AcceptLanguage, Path: "/page"},
+			ok:     true,
+		},
+		{
+			name:   "url wins over cookie and header by default",
+			url:    "/en/page",
+			host:   "example.com",
+			cookie: "pt-BR",
+			header: map[string]string{"Accept-Language": "de"},
+			want:   Result{Locale: "en-US", Source: Path, Path: "/page"},
+			ok:     true,
+		},
+		{
+			name:   "custom order prefers cookie",
+			url:    "/en/page",
+			host:   "example.com",
+			cookie: "pt-BR",
+			order:  []Source{Cookie, Path},
+			want:   Result{Locale: "pt-BR", Source: Cookie, Path: "/en/page"},
+			ok:     true,
+		},
+		{
+			name:   "forwarded host ignored unless trusted",
+			url:    "/page",
+			host:   "internal:8080",
+			header: map[string]string{"X-Forwarded-Host": "example.de"},
+			want:   Result{Locale: "en-US", Source: Default, Path: "/page"},
+			ok:     true,
+		},
+		{
+			name:       "trusted forwarded host",
+			url:        "/page",
+			host:       "internal:8080",
+			header:     map[string]string{"X-Forwarded-Host": "example.de, proxy.internal"},
+			trustProxy: true,
+			want:       Result{Locale: "de-DE", Source: CCTLD, Path: "/page"},
+			ok:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New(testSite, tt.order...)
+			d.TrustForwardedHost = tt.trustProxy
+
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			r.Host = tt.host
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: DefaultCookieName, Value: tt.cookie})
+			}
+
+			got, ok := d.Detect(r)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Detect() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDetectWithoutDefault(t *testing.T) {
+	site := testSite
+	site.DefaultLocale = ""
+
+	r := httptest.NewRequest(http.MethodGet, "/page", nil)
+	r.Host = "example.com"
+
+	if got, ok := New(site).Detect(r); ok {
+		t.Errorf("Detect() = %+v, want no match", got)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var got Result
+	var ok bool
+	handler := New(testSite).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/uk/about", nil)
+	r.Host = "example.com"
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	want := Result{Locale: "en-GB", Source: Path, Path: "/about"}
+	if !ok || got != want {
+		t.Errorf("FromContext() = %+v, %v; want %+v, true", got, ok, want)
+	}
+}
+
+func TestFetchSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/site/1" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(testSite)
+	}))
+	defer server.Close()
+
+	site, err := FetchSite(context.Background(), server.Client(), server.URL+"/", 1)
+	if err != nil {
+		t.Fatalf("FetchSite() error = %v", err)
+	}
+	if site.Host != testSite.Host || len(site.Locales) != len(testSite.Locales) {
+		t.Errorf("FetchSite() = %+v, want %+v", site, testSite)
+	}
+
+	if _, err := FetchSite(context.Background(), server.Client(), server.URL, 2); err == nil {
+		t.Error("FetchSite() of a missing site should fail")
+	}
+}
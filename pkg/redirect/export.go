@@ -0,0 +1,143 @@
+package redirect
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Formats supported by Export.
+const (
+	FormatNginx  = "nginx"
+	FormatApache = "apache"
+	FormatJSON   = "json"
+)
+
+// Export renders the rules applying to locale as server configuration. Expired
+// rules are left out and the locale placeholder is substituted; with an empty
+// locale only rules for every locale are exported.
+func (e *Engine) Export(format, locale string, now time.Time) ([]byte, error) {
+	var rules []Rule
+	var indexes []int
+	for i, r := range e.rules {
+		if r.expired(now) || (locale == "" && r.Locale != "") || !r.appliesTo(locale) {
+			continue
+		}
+		r.Target = strings.ReplaceAll(r.Target, LocalePlaceholder, locale)
+		rules = append(rules, r)
+		indexes = append(indexes, i)
+	}
+
+	switch format {
+	case FormatNginx:
+		return e.exportNginx(rules, indexes), nil
+	case FormatApache:
+		return exportApache(rules), nil
+	case FormatJSON:
+		if rules == nil {
+			rules = []Rule{}
+		}
+		return json.MarshalIndent(rules, "", "  ")
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// exportNginx renders a single map from the path to "<status> <target>",
+// split into $redirect_status and $redirect_target, since nginx's return
+// directive needs a literal code. nginx tries exact keys before regex keys,
+// then regex keys in the order they appear, so rules other than exact ones
+// are regex keys in precedence order, and an exact rule is only kept when no
+// rule before it matches its source, in which case the engine never applies
+// it either. indexes are the positions of rules in e.rules.
+func (e *Engine) exportNginx(rules []Rule, indexes []int) []byte {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	var codes []int
+	b.WriteString("map $uri $redirect {\n    default \"\";\n")
+	for k, r := range rules {
+		var key, target string
+		switch r.MatchType {
+		case Exact:
+			if e.shadowed(indexes[:k], r.Source) {
+				continue
+			}
+			key, target = r.Source, r.Target
+		case Prefix:
+			key, target = "~^"+regexp.QuoteMeta(r.Source)+"(.*)$", r.Target+"$1"
+		case Regex:
+			key, target = "~"+r.Source, nginxGroups(r.Target)
+		}
+		fmt.Fprintf(&b, "    %s %s;\n", nginxQuote(key), nginxQuote(fmt.Sprintf("%d %s", r.StatusCode, target)))
+		if !slices.Contains(codes, r.StatusCode) {
+			codes = append(codes, r.StatusCode)
+		}
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "map $redirect $redirect_status {\n    default \"\";\n    %s $1;\n}\n\n", nginxQuote(`~^(\d+) `))
+	fmt.Fprintf(&b, "map $redirect $redirect_target {\n    default \"\";\n    %s $1;\n}\n\n", nginxQuote(`~^\d+ (.*)$`))
+
+	sort.Ints(codes)
+	b.WriteString("# Inside the server block:\n")
+	for _, code := range codes {
+		fmt.Fprintf(&b, "# if ($redirect_status = %d) { return %d $redirect_target; }\n", code, code)
+	}
+
+	return []byte(b.String())
+}
+
+// shadowed reports whether one of the rules at indexes matches path.
+func (e *Engine) shadowed(indexes []int, path string) bool {
+	for _, i := range indexes {
+		if _, ok := e.match(i, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// exportApache renders mod_rewrite rules for server or virtual host context,
+// in precedence order.
+func exportApache(rules []Rule) []byte {
+	var b strings.Builder
+	b.WriteString("RewriteEngine On\n")
+	for _, r := range rules {
+		var pattern, target string
+		switch r.MatchType {
+		case Exact:
+			pattern, target = "^"+regexp.QuoteMeta(r.Source)+"$", r.Target
+		case Prefix:
+			pattern, target = "^"+regexp.QuoteMeta(r.Source)+"(.*)$", r.Target+"$1"
+		case Regex:
+			pattern, target = r.Source, nginxGroups(r.Target)
+		}
+		fmt.Fprintf(&b, "RewriteRule %s %s [R=%d,L,NE]\n", apacheQuote(pattern), apacheQuote(target), r.StatusCode)
+	}
+	return []byte(b.String())
+}
+
+var namedGroup = regexp.MustCompile(`\$\{(\d+)\}`)
+
+// nginxGroups rewrites ${1} references to the $1 form nginx and Apache use.
+func nginxGroups(target string) string {
+	return namedGroup.ReplaceAllString(target, "$$$1")
+}
+
+func nginxQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func apacheQuote(s string) string {
+	if !strings.ContainsAny(s, " \t\"") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,291 @@
+// Package redirect evaluates redirect rules against request paths and exports
+// them as nginx, Apache or JSON configuration.
+package redirect
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MatchType string
+
+const (
+	Exact  MatchType = "exact"
+	Prefix MatchType = "prefix"
+	Regex  MatchType = "regex"
+)
+
+// LocalePlaceholder in a target is replaced by the locale the path was
+// evaluated for.
+const LocalePlaceholder = "{locale}"
+
+// MaxHops bounds how many redirects Follow walks before giving up.
+const MaxHops = 10
+
+var ErrLoop = errors.New("redirect loop")
+
+// Rule redirects paths matching Source to Target. Prefix rules append the
+// unmatched remainder of the path to Target and regex rules may reference
+// capture groups as $1 or ${name}. A rule with an empty Locale applies to
+// every locale.
+type Rule struct {
+	ID         int32      `json:"id"`
+	Locale     string     `json:"locale,omitempty"`
+	MatchType  MatchType  `json:"match_type"`
+	Source     string     `json:"source"`
+	Target     string     `json:"target"`
+	StatusCode int        `json:"status_code"`
+	Priority   int        `json:"priority"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// Validate checks the rule on its own, without regard to other rules.
+func (r Rule) Validate() error {
+	switch r.StatusCode {
+	case 301, 302, 307, 308:
+	default:
+		return fmt.Errorf("unsupported status code %d", r.StatusCode)
+	}
+
+	if r.Target == "" {
+		return errors.New("target is required")
+	}
+
+	switch r.MatchType {
+	case Exact, Prefix:
+		if !strings.HasPrefix(r.Source, "/") {
+			return errors.New("source must start with /")
+		}
+		if r.MatchType == Exact && r.Source == r.Target {
+			return ErrLoop
+		}
+	case Regex:
+		if _, err := regexp.Compile(r.Source); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown match type %q", r.MatchType)
+	}
+
+	return nil
+}
+
+func (r Rule) expired(now time.Time) bool {
+	return r.ExpiresAt != nil && !now.Before(*r.ExpiresAt)
+}
+
+func (r Rule) appliesTo(locale string) bool {
+	return r.Locale == "" || strings.EqualFold(r.Locale, locale)
+}
+
+// Step records why a single rule did or did not match.
+type Step struct {
+	RuleID  int32  `json:"rule_id"`
+	Outcome string `json:"outcome"`
+}
+
+type Result struct {
+	Matched    bool   `json:"matched"`
+	Rule       *Rule  `json:"rule,omitempty"`
+	Target     string `json:"target,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Trace      []Step `json:"trace"`
+}
+
+// Engine evaluates a rule set in precedence order: higher priority first,
+// then exact before prefix before regex rules, then longer sources first.
+type Engine struct {
+	rules    []Rule
+	compiled map[int]*regexp.Regexp
+}
+
+func NewEngine(rules []Rule) (*Engine, error) {
+	sorted := append([]Rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if rank(a.MatchType) != rank(b.MatchType) {
+			return rank(a.MatchType) < rank(b.MatchType)
+		}
+		if len(a.Source) != len(b.Source) {
+			return len(a.Source) > len(b.Source)
+		}
+		return a.ID < b.ID
+	})
+
+	e := &Engine{rules: sorted, compiled: map[int]*regexp.Regexp{}}
+	for i, r := range sorted {
+		if r.MatchType != Regex {
+			continue
+		}
+		re, err := regexp.Compile(r.Source)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", r.ID, err)
+		}
+		e.compiled[i] = re
+	}
+
+	return e, nil
+}
+
+// Rules returns the rule set in precedence order.
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// Evaluate returns the first rule matching path for locale at time now,
+// together with a trace of every rule considered.
+func (e *Engine) Evaluate(path, locale string, now time.Time) Result {
+	result := Result{Trace: []Step{}}
+
+	for i, r := range e.rules {
+		switch {
+		case r.expired(now):
+			result.Trace = append(result.Trace, Step{RuleID: r.ID, Outcome: "skipped: expired"})
+			continue
+		case !r.appliesTo(locale):
+			result.Trace = append(result.Trace, Step{RuleID: r.ID, Outcome: "skipped: locale " + r.Locale})
+			continue
+		}
+
+		target, ok := e.match(i, path)
+		if !ok {
+			result.Trace = append(result.Trace, Step{RuleID: r.ID, Outcome: "no match"})
+			continue
+		}
+
+		rule := r
+		result.Trace = append(result.Trace, Step{RuleID: r.ID, Outcome: "matched"})
+		result.Matched = true
+		result.Rule = &rule
+		result.Target = strings.ReplaceAll(target, LocalePlaceholder, locale)
+		result.StatusCode = r.StatusCode
+		return result
+	}
+
+	return result
+}
+
+// Follow evaluates path and then every target it redirects to, returning one
+// result per hop. It returns ErrLoop when a target redirects back to a path
+// already visited or the chain is longer than MaxHops.
+func (e *Engine) Follow(path, locale string, now time.Time) ([]Result, error) {
+	var hops []Result
+	visited := map[string]bool{path: true}
+
+	for len(hops) < MaxHops {
+		result := e.Evaluate(path, locale, now)
+		if !result.Matched {
+			return hops, nil
+		}
+		hops = append(hops, result)
+
+		next := pathOf(result.Target)
+		if next == "" {
+			return hops, nil
+		}
+		if visited[next] {
+			return hops, ErrLoop
+		}
+		visited[next] = true
+		path = next
+	}
+
+	return hops, ErrLoop
+}
+
+// CheckLoops follows the source of every literal rule and reports the first
+// rule whose chain loops. Rules for every locale are followed once per locale,
+// both those given and those of the locale-specific rules, so that {locale}
+// in their targets is filled in as it would be for a request. Regex sources
+// cannot be enumerated, but loops through them are still found when a literal
+// rule leads into them.
+func (e *Engine) CheckLoops(now time.Time, locales []string) error {
+	all := map[string]bool{}
+	var known []string
+	for _, l := range locales {
+		if l != "" && !all[strings.ToLower(l)] {
+			all[strings.ToLower(l)] = true
+			known = append(known, l)
+		}
+	}
+	for _, r := range e.rules {
+		if r.Locale != "" && !all[strings.ToLower(r.Locale)] {
+			all[strings.ToLower(r.Locale)] = true
+			known = append(known, r.Locale)
+		}
+	}
+	if len(known) == 0 {
+		known = []string{""}
+	}
+
+	for _, r := range e.rules {
+		if r.MatchType == Regex || r.expired(now) {
+			continue
+		}
+
+		followed := known
+		if r.Locale != "" {
+			followed = []string{r.Locale}
+		}
+		for _, locale := range followed {
+			if _, err := e.Follow(r.Source, locale, now); err != nil {
+				if locale == "" || r.Locale != "" {
+					return fmt.Errorf("rule %d: %w", r.ID, err)
+				}
+				return fmt.Errorf("rule %d in locale %s: %w", r.ID, locale, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Engine) match(i int, path string) (string, bool) {
+	r := e.rules[i]
+	switch r.MatchType {
+	case Exact:
+		if path == r.Source {
+			return r.Target, true
+		}
+	case Prefix:
+		if strings.HasPrefix(path, r.Source) {
+			return r.Target + strings.TrimPrefix(path, r.Source), true
+		}
+	case Regex:
+		re := e.compiled[i]
+		m := re.FindStringSubmatchIndex(path)
+		if m != nil {
+			return string(re.ExpandString(nil, r.Target, path, m)), true
+		}
+	}
+	return "", false
+}
+
+// pathOf returns the path of target when it stays on the same host, and an
+// empty string for absolute URLs, which leave the rule set's scope.
+func pathOf(target string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") {
+		return ""
+	}
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		return target[:i]
+	}
+	return target
+}
+
+func rank(t MatchType) int {
+	switch t {
+	case Exact:
+		return 0
+	case Prefix:
+		return 1
+	default:
+		return 2
+	}
+}
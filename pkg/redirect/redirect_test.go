@@ -0,0 +1,348 @@
+package redirect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func mustEngine(t *testing.T, rules ...Rule) *Engine {
+	t.Helper()
+	e, err := NewEngine(rules)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	return e
+}
+
+func TestEvaluatePrecedence(t *testing.T) {
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		rules      []Rule
+		path       string
+		locale     string
+		wantRule   int32
+		wantTarget string
+	}{
+		{
+			name: "exact before prefix before regex",
+			rules: []Rule{
+				{ID: 1, MatchType: Regex, Source: "^/docs/.*$", Target: "/regex", StatusCode: 301},
+				{ID: 2, MatchType: Prefix, Source: "/docs/", Target: "/prefix/", StatusCode: 301},
+				{ID: 3, MatchType: Exact, Source: "/docs/a", Target: "/exact", StatusCode: 301},
+			},
+			path: "/docs/a", wantRule: 3, wantTarget: "/exact",
+		},
+		{
+			name: "priority beats match type",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/docs/a", Target: "/exact", StatusCode: 301},
+				{ID: 2, MatchType: Regex, Source: "^/docs/(.*)$", Target: "/r/$1", StatusCode: 302, Priority: 10},
+			},
+			path: "/docs/a", wantRule: 2, wantTarget: "/r/a",
+		},
+		{
+			name: "longer prefix first",
+			rules: []Rule{
+				{ID: 1, MatchType: Prefix, Source: "/docs", Target: "/short", StatusCode: 301},
+				{ID: 2, MatchType: Prefix, Source: "/docs/api", Target: "/long", StatusCode: 301},
+			},
+			path: "/docs/api/x", wantRule: 2, wantTarget: "/long/x",
+		},
+		{
+			name: "lower id breaks ties",
+			rules: []Rule{
+				{ID: 2, MatchType: Regex, Source: "^/a$", Target: "/two", StatusCode: 301},
+				{ID: 1, MatchType: Regex, Source: "^/.$", Target: "/one", StatusCode: 301},
+			},
+			path: "/a", wantRule: 1, wantTarget: "/one",
+		},
+		{
+			name: "no match",
+			rules: []Rule{
+				{ID: 1, MatchType: Prefix, Source: "/a", Target: "/b", StatusCode: 301},
+			},
+			path: "/c", wantRule: -1,
+		},
+		{
+			name: "named capture groups",
+			rules: []Rule{
+				{ID: 1, MatchType: Regex, Source: `^/blog/(?P<year>\d{4})/(?P<slug>[^/]+)$`, Target: "/articles/${slug}?y=${year}", StatusCode: 308},
+			},
+			path: "/blog/2024/hello", wantRule: 1, wantTarget: "/articles/hello?y=2024",
+		},
+		{
+			name: "expired rules are skipped",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/expired", StatusCode: 301, ExpiresAt: &past},
+				{ID: 2, MatchType: Prefix, Source: "/", Target: "/live/", StatusCode: 301},
+			},
+			path: "/a", wantRule: 2, wantTarget: "/live/a",
+		},
+		{
+			name: "locale rules only apply to their locale",
+			rules: []Rule{
+				{ID: 1, Locale: "pt-BR", MatchType: Exact, Source: "/a", Target: "/pt", StatusCode: 301},
+				{ID: 2, MatchType: Exact, Source: "/a", Target: "/{locale}/a", StatusCode: 301, Priority: -1},
+			},
+			path: "/a", locale: "en", wantRule: 2, wantTarget: "/en/a",
+		},
+		{
+			name: "locale matching is case insensitive",
+			rules: []Rule{
+				{ID: 1, Locale: "pt-BR", MatchType: Exact, Source: "/a", Target: "/pt", StatusCode: 301},
+			},
+			path: "/a", locale: "pt-br", wantRule: 1, wantTarget: "/pt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mustEngine(t, tt.rules...).Evaluate(tt.path, tt.locale, now)
+			if tt.wantRule < 0 {
+				if result.Matched {
+					t.Errorf("Evaluate(%q) matched rule %d; want no match", tt.path, result.Rule.ID)
+				}
+				return
+			}
+			if !result.Matched || result.Rule.ID != tt.wantRule || result.Target != tt.wantTarget {
+				t.Errorf("Evaluate(%q) = %+v; want rule %d to %q", tt.path, result, tt.wantRule, tt.wantTarget)
+			}
+			if len(result.Trace) == 0 || result.Trace[len(result.Trace)-1].Outcome != "matched" {
+				t.Errorf("Evaluate(%q) trace = %+v; want it to end with the match", tt.path, result.Trace)
+			}
+		})
+	}
+}
+
+func TestRulesOrder(t *testing.T) {
+	e := mustEngine(t,
+		Rule{ID: 1, MatchType: Regex, Source: "^/a", Target: "/x", StatusCode: 301},
+		Rule{ID: 2, MatchType: Prefix, Source: "/a", Target: "/x", StatusCode: 301},
+		Rule{ID: 3, MatchType: Exact, Source: "/a", Target: "/x", StatusCode: 301},
+		Rule{ID: 4, MatchType: Prefix, Source: "/ab", Target: "/x", StatusCode: 301},
+		Rule{ID: 5, MatchType: Regex, Source: "^/b", Target: "/x", StatusCode: 301, Priority: 1},
+	)
+
+	var ids []int32
+	for _, r := range e.Rules() {
+		ids = append(ids, r.ID)
+	}
+	want := []int32{5, 3, 4, 2, 1}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("Rules() order = %v; want %v", ids, want)
+		}
+	}
+}
+
+func TestFollow(t *testing.T) {
+	e := mustEngine(t,
+		Rule{ID: 1, MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 301},
+		Rule{ID: 2, MatchType: Exact, Source: "/b", Target: "/c?x=1", StatusCode: 302},
+		Rule{ID: 3, MatchType: Exact, Source: "/c", Target: "https://other.example/c", StatusCode: 301},
+	)
+
+	hops, err := e.Follow("/a", "", now)
+	if err != nil {
+		t.Fatalf("Follow() error = %v", err)
+	}
+	var targets []string
+	for _, h := range hops {
+		targets = append(targets, h.Target)
+	}
+	if got := strings.Join(targets, " "); got != "/b /c?x=1 https://other.example/c" {
+		t.Errorf("Follow() targets = %s", got)
+	}
+}
+
+func TestCheckLoops(t *testing.T) {
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		rules   []Rule
+		locales []string
+		loop    bool
+	}{
+		{
+			name: "chain without loop",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 301},
+				{ID: 2, MatchType: Exact, Source: "/b", Target: "/c", StatusCode: 301},
+			},
+		},
+		{
+			name: "two rule loop",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 301},
+				{ID: 2, MatchType: Exact, Source: "/b", Target: "/a", StatusCode: 301},
+			},
+			loop: true,
+		},
+		{
+			name: "loop through a query string",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/b?from=a", StatusCode: 301},
+				{ID: 2, MatchType: Exact, Source: "/b", Target: "/a#top", StatusCode: 301},
+			},
+			loop: true,
+		},
+		{
+			name: "prefix rule into itself",
+			rules: []Rule{
+				{ID: 1, MatchType: Prefix, Source: "/old", Target: "/old/new", StatusCode: 301},
+			},
+			loop: true,
+		},
+		{
+			name: "loop through a regex rule",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/x/a", StatusCode: 301},
+				{ID: 2, MatchType: Regex, Source: "^/x/(.*)$", Target: "/$1", StatusCode: 301},
+			},
+			loop: true,
+		},
+		{
+			name: "absolute targets leave the rule set",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "https://example.com/a", StatusCode: 301},
+			},
+		},
+		{
+			name: "expired rules do not loop",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 301},
+				{ID: 2, MatchType: Exact, Source: "/b", Target: "/a", StatusCode: 301, ExpiresAt: &past},
+			},
+		},
+		{
+			name: "rules in different locales do not loop",
+			rules: []Rule{
+				{ID: 1, Locale: "en", MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 301},
+				{ID: 2, Locale: "de", MatchType: Exact, Source: "/b", Target: "/a", StatusCode: 301},
+			},
+		},
+		{
+			name: "wildcard rule loops through a configured locale",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/{locale}/a", StatusCode: 301},
+				{ID: 2, Locale: "de", MatchType: Exact, Source: "/de/a", Target: "/a", StatusCode: 301},
+			},
+			locales: []string{"en", "de"},
+			loop:    true,
+		},
+		{
+			name: "wildcard rule loops in a locale known only from the configuration",
+			rules: []Rule{
+				{ID: 1, MatchType: Exact, Source: "/a", Target: "/{locale}/a", StatusCode: 301},
+				{ID: 2, MatchType: Exact, Source: "/fr/a", Target: "/a", StatusCode: 301},
+			},
+			locales: []string{"fr"},
+			loop:    true,
+		},
+		{
+			name: "wildcard rule without loop in any locale",
+			rules: []Rule{
+				{ID: 1, MatchType: Prefix, Source: "/old/", Target: "/{locale}/new/", StatusCode: 301},
+				{ID: 2, Locale: "en", MatchType: Exact, Source: "/de/new/a", Target: "/old/a", StatusCode: 301},
+			},
+			locales: []string{"en", "de"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := mustEngine(t, tt.rules...).CheckLoops(now, tt.locales)
+			if errors.Is(err, ErrLoop) != tt.loop {
+				t.Errorf("CheckLoops() = %v; want loop %v", err, tt.loop)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		ok   bool
+	}{
+		{"exact", Rule{MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 301}, true},
+		{"regex", Rule{MatchType: Regex, Source: "^/(a|b)$", Target: "/$1", StatusCode: 308}, true},
+		{"unsupported status", Rule{MatchType: Exact, Source: "/a", Target: "/b", StatusCode: 303}, false},
+		{"missing target", Rule{MatchType: Exact, Source: "/a", StatusCode: 301}, false},
+		{"relative source", Rule{MatchType: Prefix, Source: "a", Target: "/b", StatusCode: 301}, false},
+		{"self redirect", Rule{MatchType: Exact, Source: "/a", Target: "/a", StatusCode: 301}, false},
+		{"invalid regex", Rule{MatchType: Regex, Source: "(", Target: "/b", StatusCode: 301}, false},
+		{"unknown match type", Rule{MatchType: "glob", Source: "/a", Target: "/b", StatusCode: 301}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err == nil) != tt.ok {
+				t.Errorf("Validate() = %v; want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	e := mustEngine(t,
+		Rule{ID: 1, MatchType: Prefix, Source: "/docs/", Target: "/{locale}/help/", StatusCode: 301},
+		Rule{ID: 2, MatchType: Exact, Source: "/docs/a", Target: "/shadowed", StatusCode: 302, Priority: -1},
+		Rule{ID: 3, MatchType: Exact, Source: "/old", Target: "/new", StatusCode: 308},
+		Rule{ID: 4, Locale: "de", MatchType: Exact, Source: "/de-only", Target: "/x", StatusCode: 301},
+	)
+
+	tests := []struct {
+		format   string
+		locale   string
+		contains []string
+		excludes []string
+	}{
+		{
+			format:   FormatNginx,
+			locale:   "en",
+			contains: []string{`"/old" "308 /new";`, `"~^/docs/(.*)$" "301 /en/help/$1";`, "return 308 $redirect_target;"},
+			excludes: []string{"/shadowed", "/de-only"},
+		},
+		{
+			format:   FormatApache,
+			locale:   "de",
+			contains: []string{`RewriteRule ^/old$ /new [R=308,L,NE]`, `RewriteRule ^/docs/(.*)$ /de/help/$1 [R=301,L,NE]`, "/de-only"},
+		},
+		{
+			format:   FormatJSON,
+			contains: []string{`"source": "/old"`},
+			excludes: []string{"/de-only"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := e.Export(tt.format, tt.locale, now)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(string(out), s) {
+					t.Errorf("Export(%s) does not contain %s:\n%s", tt.format, s, out)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(string(out), s) {
+					t.Errorf("Export(%s) contains %s:\n%s", tt.format, s, out)
+				}
+			}
+		})
+	}
+
+	if _, err := e.Export("caddy", "", now); err == nil {
+		t.Error("Export(caddy) succeeded; want an error")
+	}
+}
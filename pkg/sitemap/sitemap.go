@@ -0,0 +1,210 @@
+// Package sitemap generates multilingual XML sitemaps in which every localized
+// URL lists its alternates as xhtml:link elements.
+package sitemap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/hreflang"
+	"github.com/LeonardoFreitas1/uurl-admin/pkg/urlpattern"
+)
+
+// Limits imposed by the sitemaps protocol on a single sitemap file.
+const (
+	MaxURLs  = 50000
+	MaxBytes = 50 * 1024 * 1024
+)
+
+const (
+	urlsetOpen = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">` + "\n"
+	urlsetClose = "</urlset>\n"
+	indexOpen   = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n"
+	indexClose = "</sitemapindex>\n"
+)
+
+type Options struct {
+	// BaseURL is where the generated files are served from. It is used for the
+	// <loc> of each sitemap in the index and defaults to the site root.
+	BaseURL string
+	Gzip    bool
+	// MaxURLs and MaxBytes override the protocol limits, mostly for testing.
+	MaxURLs  int
+	MaxBytes int
+}
+
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Generate builds the sitemap for paths in every locale of site. A single
+// sitemap.xml is returned when everything fits in one file; otherwise the
+// URLs are split into sitemap-N.xml files referenced by a sitemap.xml index.
+// With Gzip set, every file is compressed and gets a .gz suffix.
+func Generate(site urlpattern.Site, paths []string, opts Options) ([]File, error) {
+	if len(site.Locales) == 0 {
+		return nil, errors.New("site has no locales")
+	}
+	if opts.MaxURLs <= 0 || opts.MaxURLs > MaxURLs {
+		opts.MaxURLs = MaxURLs
+	}
+	if opts.MaxBytes <= 0 || opts.MaxBytes > MaxBytes {
+		opts.MaxBytes = MaxBytes
+	}
+	if opts.BaseURL == "" {
+		scheme := site.Scheme
+		if scheme == "" {
+			scheme = "https"
+		}
+		opts.BaseURL = scheme + "://" + site.Host + "/"
+	}
+
+	var parts [][]byte
+	var current bytes.Buffer
+	count := 0
+
+	for _, path := range paths {
+		links, err := hreflang.Alternates(site, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, link := range links {
+			if link.Hreflang == hreflang.XDefault {
+				continue
+			}
+
+			entry := urlEntry(link.Href, links)
+			if len(urlsetOpen)+len(entry)+len(urlsetClose) > opts.MaxBytes {
+				return nil, fmt.Errorf("%s: entry exceeds the sitemap size limit", link.Href)
+			}
+
+			if count == opts.MaxURLs || current.Len()+len(entry)+len(urlsetClose) > opts.MaxBytes {
+				current.WriteString(urlsetClose)
+				parts = append(parts, bytes.Clone(current.Bytes()))
+				current.Reset()
+				count = 0
+			}
+			if count == 0 {
+				current.WriteString(urlsetOpen)
+			}
+
+			current.WriteString(entry)
+			count++
+		}
+	}
+
+	if count == 0 && len(parts) == 0 {
+		current.WriteString(urlsetOpen)
+	}
+	current.WriteString(urlsetClose)
+	parts = append(parts, current.Bytes())
+
+	suffix := ".xml"
+	if opts.Gzip {
+		suffix += ".gz"
+	}
+
+	if len(parts) == 1 {
+		file, err := newFile("sitemap"+suffix, parts[0], opts.Gzip)
+		if err != nil {
+			return nil, err
+		}
+		return []File{file}, nil
+	}
+
+	var files []File
+	var index bytes.Buffer
+	index.WriteString(indexOpen)
+	for i, part := range parts {
+		name := fmt.Sprintf("sitemap-%d%s", i+1, suffix)
+		file, err := newFile(name, part, opts.Gzip)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+
+		index.WriteString("  <sitemap>\n    <loc>")
+		xml.EscapeText(&index, []byte(strings.TrimSuffix(opts.BaseURL, "/")+"/"+name))
+		index.WriteString("</loc>\n  </sitemap>\n")
+	}
+	index.WriteString(indexClose)
+
+	indexFile, err := newFile("sitemap"+suffix, index.Bytes(), opts.Gzip)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]File{indexFile}, files...), nil
+}
+
+// ReadPaths reads canonical paths one per line, skipping blank lines and
+// lines starting with #.
+func ReadPaths(r io.Reader) ([]string, error) {
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	return paths, scanner.Err()
+}
+
+// WriteDir writes files into dir, creating it if needed.
+func WriteDir(dir string, files []File) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Name), f.Data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func urlEntry(loc string, links []hreflang.Link) string {
+	var b bytes.Buffer
+	b.WriteString("  <url>\n    <loc>")
+	xml.EscapeText(&b, []byte(loc))
+	b.WriteString("</loc>\n")
+	for _, l := range links {
+		b.WriteString(`    <xhtml:link rel="alternate" hreflang="`)
+		xml.EscapeText(&b, []byte(l.Hreflang))
+		b.WriteString(`" href="`)
+		xml.EscapeText(&b, []byte(l.Href))
+		b.WriteString("\"/>\n")
+	}
+	b.WriteString("  </url>\n")
+	return b.String()
+}
+
+func newFile(name string, data []byte, compress bool) (File, error) {
+	if !compress {
+		return File{Name: name, Data: data}, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return File{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return File{}, err
+	}
+	return File{Name: name, Data: buf.Bytes()}, nil
+}
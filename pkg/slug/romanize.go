@@ -0,0 +1,174 @@
+package slug
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// romanize rewrites the syllabic scripts in text, whose letters cannot be
+// transliterated one at a time, to Latin letters: Japanese kana (Hepburn),
+// Korean Hangul (Revised Romanization) and Devanagari. Other text is kept.
+func romanize(text string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case isKana(r):
+			n := kana(&b, runes[i:])
+			i += n - 1
+		case r >= hangulFirst && r <= hangulLast:
+			hangul(&b, r)
+		case r >= 0x0900 && r <= 0x097F:
+			devanagari(&b, runes, i)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isKana(r rune) bool {
+	return (r >= 0x3041 && r <= 0x3096) || (r >= 0x30A1 && r <= 0x30FC)
+}
+
+// kana writes the romanization of the kana at the start of runes and returns
+// the number of runes consumed: one, or two for a syllable with a small ya, yu
+// or yo, or for a small tsu doubling the consonant that follows.
+func kana(b *strings.Builder, runes []rune) int {
+	r := toHiragana(runes[0])
+	switch r {
+	case 'っ':
+		if len(runes) > 1 && isKana(runes[1]) {
+			var next strings.Builder
+			n := kana(&next, runes[1:])
+			s := next.String()
+			switch {
+			case strings.HasPrefix(s, "ch"):
+				b.WriteByte('t')
+			case s != "" && !strings.ContainsRune("aiueon", rune(s[0])):
+				b.WriteByte(s[0])
+			}
+			b.WriteString(s)
+			return n + 1
+		}
+		return 1
+	case 'ー':
+		return 1
+	}
+
+	s := hiragana[r]
+	if len(runes) > 1 && strings.HasSuffix(s, "i") && len(s) > 1 {
+		if small, ok := smallY[toHiragana(runes[1])]; ok {
+			s = strings.TrimSuffix(s, "i")
+			if !strings.HasSuffix(s, "sh") && !strings.HasSuffix(s, "ch") && s != "j" {
+				s += "y"
+			}
+			b.WriteString(s + small)
+			return 2
+		}
+	}
+	b.WriteString(s)
+	return 1
+}
+
+func toHiragana(r rune) rune {
+	if r >= 0x30A1 && r <= 0x30F6 {
+		return r - 0x60
+	}
+	return r
+}
+
+var smallY = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+var hiragana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa", 'ゕ': "ka", 'ゖ': "ke",
+}
+
+const (
+	hangulFirst = 0xAC00
+	hangulLast  = 0xD7A3
+)
+
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulVowels   = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+// hangul writes the romanization of a precomposed Hangul syllable, which
+// encodes its initial, vowel and final jamo arithmetically.
+func hangul(b *strings.Builder, r rune) {
+	i := int(r - hangulFirst)
+	b.WriteString(hangulInitials[i/(21*28)])
+	b.WriteString(hangulVowels[i%(21*28)/28])
+	b.WriteString(hangulFinals[i%28])
+}
+
+// devanagari writes the romanization of runes[i]. Consonants carry an inherent
+// "a" unless a vowel sign or virama follows, or they end a word.
+func devanagari(b *strings.Builder, runes []rune, i int) {
+	r := runes[i]
+	if s, ok := devanagariVowelSigns[r]; ok {
+		b.WriteString(s)
+		return
+	}
+	s, ok := devanagariLetters[r]
+	if !ok {
+		b.WriteRune(r)
+		return
+	}
+	b.WriteString(s)
+	if !devanagariConsonant(r) {
+		return
+	}
+
+	next := rune(utf8.RuneError)
+	for j := i + 1; j < len(runes); j++ {
+		if runes[j] != '़' {
+			next = runes[j]
+			break
+		}
+	}
+	_, sign := devanagariVowelSigns[next]
+	if !sign && devanagariLetters[next] != "" {
+		b.WriteByte('a')
+	}
+}
+
+func devanagariConsonant(r rune) bool {
+	return (r >= 'क' && r <= 'ह') || r == 'ळ' || (r >= '\u0958' && r <= '\u095F')
+}
+
+var devanagariLetters = map[rune]string{
+	'अ': "a", 'आ': "a", 'इ': "i", 'ई': "i", 'उ': "u", 'ऊ': "u", 'ऋ': "ri", 'ए': "e",
+	'ऐ': "ai", 'ओ': "o", 'औ': "au",
+	'क': "k", 'ख': "kh", 'ग': "g", 'घ': "gh", 'ङ': "n", 'च': "ch", 'छ': "chh", 'ज': "j",
+	'झ': "jh", 'ञ': "n", 'ट': "t", 'ठ': "th", 'ड': "d", 'ढ': "dh", 'ण': "n", 'त': "t",
+	'थ': "th", 'द': "d", 'ध': "dh", 'न': "n", 'प': "p", 'फ': "ph", 'ब': "b", 'भ': "bh",
+	'म': "m", 'य': "y", 'र': "r", 'ल': "l", 'ळ': "l", 'व': "v", 'श': "sh", 'ष': "sh",
+	'स': "s", 'ह': "h", '\u0958': "q", '\u0959': "kh", '\u095A': "g", '\u095B': "z",
+	'\u095C': "r", '\u095D': "rh", '\u095E': "f", '\u095F': "y",
+	'ं': "n", 'ँ': "n", 'ः': "h",
+}
+
+var devanagariVowelSigns = map[rune]string{
+	'ा': "a", 'ि': "i", 'ी': "i", 'ु': "u", 'ू': "u", 'ृ': "ri", 'े': "e", 'ै': "ai",
+	'ो': "o", 'ौ': "au", '्': "", '़': "",
+}
@@ -0,0 +1,208 @@
+// Package slug turns titles into URL slugs, transliterating non-Latin scripts
+// to ASCII so that every locale gets a readable, URL-safe slug.
+package slug
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MaxLength is the maximum length of a suggested slug.
+const MaxLength = 80
+
+var validSlug = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Valid reports whether s is a lower-case ASCII slug made of words joined by
+// single hyphens.
+func Valid(s string) bool {
+	return len(s) <= 255 && validSlug.MatchString(s)
+}
+
+// Suggest returns a slug for text. The locale selects language-specific
+// transliterations, e.g. German "ü" becomes "ue" rather than "u". Text in a
+// script without a transliteration, such as Chinese, gets a short slug derived
+// from a hash of the text; Suggest only returns "" for text without letters
+// or digits.
+func Suggest(text, locale string) string {
+	language := strings.ToLower(strings.SplitN(strings.ReplaceAll(locale, "_", "-"), "-", 2)[0])
+	overrides := languageOverrides[language]
+
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(romanize(text)) {
+		s, ok := overrides[r]
+		if !ok {
+			s, ok = transliterations[r]
+		}
+		if !ok && r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			s, ok = string(r), true
+		}
+		if !ok && (r == '\'' || r == '’' || r == 'ʼ' || unicode.Is(unicode.Mn, r)) {
+			continue
+		}
+
+		if !ok {
+			hyphen = b.Len() > 0
+			continue
+		}
+		if s == "" {
+			continue
+		}
+		if hyphen {
+			b.WriteByte('-')
+			hyphen = false
+		}
+		b.WriteString(s)
+	}
+
+	if b.Len() == 0 && strings.IndexFunc(text, isWordRune) >= 0 {
+		return hashed(text)
+	}
+	return truncate(b.String(), MaxLength)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// hashed returns a stable slug for text that cannot be transliterated.
+func hashed(text string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(strings.TrimSpace(text))))
+	return fmt.Sprintf("p-%08x", h.Sum32())
+}
+
+// truncate cuts s to at most n bytes, preferring a word boundary.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	if i := strings.LastIndex(s, "-"); i > n/2 {
+		s = s[:i]
+	}
+	return strings.Trim(s, "-")
+}
+
+var languageOverrides = map[string]map[rune]string{
+	"de": {'ä': "ae", 'ö': "oe", 'ü': "ue"},
+	"da": {'å': "aa", 'æ': "ae", 'ø': "oe"},
+	"nb": {'å': "aa", 'æ': "ae", 'ø': "oe"},
+	"no": {'å': "aa", 'æ': "ae", 'ø': "oe"},
+	"sv": {'å': "a", 'ä': "a", 'ö': "o"},
+	"uk": {'г': "h", 'ґ': "g", 'и': "y", 'і': "i", 'ї': "yi", 'є': "ye"},
+	"bg": {'щ': "sht", 'ъ': "a"},
+}
+
+var transliterations = buildTransliterations()
+
+func buildTransliterations() map[rune]string {
+	m := map[rune]string{}
+
+	// Latin letters with diacritics fold to their base letter.
+	folds := map[string]string{
+		"a": "àáâãäåāăąǎ",
+		"c": "çćĉċč",
+		"d": "ďđð",
+		"e": "èéêëēĕėęě",
+		"g": "ĝğġģ",
+		"h": "ĥħ",
+		"i": "ìíîïĩīĭįı",
+		"j": "ĵ",
+		"k": "ķ",
+		"l": "ĺļľŀł",
+		"n": "ñńņňŉ",
+		"o": "òóôõöøōŏőǒ",
+		"r": "ŕŗř",
+		"s": "śŝşšș",
+		"t": "ţťŧț",
+		"u": "ùúûüũūŭůűųǔ",
+		"w": "ŵ",
+		"y": "ýÿŷ",
+		"z": "źżž",
+	}
+	for base, letters := range folds {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	for r, s := range map[rune]string{'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th", 'ĳ': "ij", '&': "and"} {
+		m[r] = s
+	}
+
+	// Cyrillic, following the common Russian passport-style romanization.
+	for r, s := range map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+		'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+		'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+		'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+		'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ђ': "dj", 'ј': "j", 'љ': "lj",
+		'њ': "nj", 'ћ': "c", 'џ': "dz", 'ў': "u",
+	} {
+		m[r] = s
+	}
+
+	// Greek, following ELOT 743.
+	for r, s := range map[rune]string{
+		'α': "a", 'ά': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'έ': "e", 'ζ': "z",
+		'η': "i", 'ή': "i", 'θ': "th", 'ι': "i", 'ί': "i", 'ϊ': "i", 'ΐ': "i", 'κ': "k",
+		'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'ό': "o", 'π': "p", 'ρ': "r",
+		'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'ύ': "y", 'ϋ': "y", 'ΰ': "y", 'φ': "f",
+		'χ': "ch", 'ψ': "ps", 'ω': "o", 'ώ': "o",
+	} {
+		m[r] = s
+	}
+
+	// Arabic, with the Persian and Urdu letters, without vowels as they are
+	// rarely written.
+	for r, s := range map[rune]string{
+		'ا': "a", 'أ': "a", 'إ': "i", 'آ': "a", 'ٱ': "a", 'ب': "b", 'ت': "t", 'ث': "th",
+		'ج': "j", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "dh", 'ر': "r", 'ز': "z", 'س': "s",
+		'ش': "sh", 'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'ع': "", 'غ': "gh", 'ف': "f",
+		'ق': "q", 'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h", 'و': "w", 'ي': "y",
+		'ى': "a", 'ة': "a", 'ء': "", 'ؤ': "", 'ئ': "", 'ـ': "", 'پ': "p", 'چ': "ch",
+		'ژ': "zh", 'گ': "g", 'ک': "k", 'ی': "y", 'ٹ': "t", 'ڈ': "d", 'ڑ': "r", 'ں': "n",
+		'ھ': "h", 'ہ': "h", 'ے': "e",
+	} {
+		m[r] = s
+	}
+
+	// Hebrew consonants; vowel points are combining marks and dropped.
+	for r, s := range map[rune]string{
+		'א': "", 'ב': "b", 'ג': "g", 'ד': "d", 'ה': "h", 'ו': "v", 'ז': "z", 'ח': "ch",
+		'ט': "t", 'י': "y", 'כ': "k", 'ך': "k", 'ל': "l", 'מ': "m", 'ם': "m", 'נ': "n",
+		'ן': "n", 'ס': "s", 'ע': "", 'פ': "p", 'ף': "p", 'צ': "ts", 'ץ': "ts", 'ק': "k",
+		'ר': "r", 'ש': "sh", 'ת': "t",
+	} {
+		m[r] = s
+	}
+
+	// Armenian and Georgian.
+	for r, s := range map[rune]string{
+		'ա': "a", 'բ': "b", 'գ': "g", 'դ': "d", 'ե': "e", 'զ': "z", 'է': "e", 'ը': "y",
+		'թ': "t", 'ժ': "zh", 'ի': "i", 'լ': "l", 'խ': "kh", 'ծ': "ts", 'կ': "k", 'հ': "h",
+		'ձ': "dz", 'ղ': "gh", 'ճ': "ch", 'մ': "m", 'յ': "y", 'ն': "n", 'շ': "sh", 'ո': "o",
+		'չ': "ch", 'պ': "p", 'ջ': "j", 'ռ': "r", 'ս': "s", 'վ': "v", 'տ': "t", 'ր': "r",
+		'ց': "ts", 'ւ': "v", 'փ': "p", 'ք': "k", 'օ': "o", 'ֆ': "f", 'և': "ev",
+
+		'ა': "a", 'ბ': "b", 'გ': "g", 'დ': "d", 'ე': "e", 'ვ': "v", 'ზ': "z", 'თ': "t",
+		'ი': "i", 'კ': "k", 'ლ': "l", 'მ': "m", 'ნ': "n", 'ო': "o", 'პ': "p", 'ჟ': "zh",
+		'რ': "r", 'ს': "s", 'ტ': "t", 'უ': "u", 'ფ': "p", 'ქ': "k", 'ღ': "gh", 'ყ': "q",
+		'შ': "sh", 'ჩ': "ch", 'ც': "ts", 'ძ': "dz", 'წ': "ts", 'ჭ': "ch", 'ხ': "kh", 'ჯ': "j",
+		'ჰ': "h",
+	} {
+		m[r] = s
+	}
+
+	// Decimal digits of every script.
+	for _, zero := range []rune{'٠', '۰', '०', '০', '੦', '૦', '௦', '౦', '೦', '൦', '๐', '０'} {
+		for i := rune(0); i < 10; i++ {
+			m[zero+i] = string('0' + i)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,92 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		locale string
+		want   string
+	}{
+		{"ascii", "About Us", "en", "about-us"},
+		{"punctuation", "  Terms & Conditions!  ", "en", "terms-and-conditions"},
+		{"apostrophe", "Don't panic", "en", "dont-panic"},
+		{"latin diacritics", "Über uns", "fr", "uber-uns"},
+		{"german override", "Über uns", "de-DE", "ueber-uns"},
+		{"danish override", "Blåbærgrød", "da", "blaabaergroed"},
+		{"cyrillic", "Привет мир", "ru", "privet-mir"},
+		{"ukrainian override", "Гора Харків", "uk", "hora-kharkiv"},
+		{"greek", "Καλημέρα", "el", "kalimera"},
+		{"arabic", "مرحبا بالعالم", "ar", "mrhba-balalm"},
+		{"persian", "چاپ", "fa", "chap"},
+		{"hebrew", "שלום עולם", "he", "shlvm-vlm"},
+		{"armenian", "Բարեւ", "hy", "barev"},
+		{"georgian", "გამარჯობა", "ka", "gamarjoba"},
+		{"hiragana", "こんにちは", "ja", "konnichiha"},
+		{"katakana with small tsu", "マッチ", "ja", "matchi"},
+		{"small ya", "ちょっと きって", "ja", "chotto-kitte"},
+		{"hangul", "한국어 사이트", "ko", "hangukeo-saiteu"},
+		{"devanagari", "नमस्ते दुनिया", "hi", "namaste-duniya"},
+		{"devanagari virama", "हिन्दी", "hi", "hindi"},
+		{"arabic-indic digits", "صفحة ١٢", "ar", "sfha-12"},
+		{"mixed scripts keep the transliterated words", "Docs 中文", "zh", "docs"},
+		{"no letters", "!!!", "en", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Suggest(tt.text, tt.locale); got != tt.want {
+				t.Errorf("Suggest(%q, %q) = %q; want %q", tt.text, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestFallback(t *testing.T) {
+	for _, text := range []string{"中文网站", "ภาษาไทย", "東京"} {
+		got := Suggest(text, "")
+		if !Valid(got) || !strings.HasPrefix(got, "p-") {
+			t.Errorf("Suggest(%q) = %q; want a valid hashed slug", text, got)
+		}
+		if again := Suggest(text, ""); again != got {
+			t.Errorf("Suggest(%q) = %q, then %q; want a stable slug", text, got, again)
+		}
+	}
+	if Suggest("中文网站", "") == Suggest("东京", "") {
+		t.Error("Suggest returned the same hashed slug for different texts")
+	}
+}
+
+func TestSuggestTruncates(t *testing.T) {
+	got := Suggest(strings.Repeat("word ", 40), "en")
+	if len(got) > MaxLength || !Valid(got) || strings.HasSuffix(got, "-") {
+		t.Errorf("Suggest(long text) = %q (%d bytes); want a valid slug of at most %d bytes", got, len(got), MaxLength)
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{"about-us", true},
+		{"a1", true},
+		{"", false},
+		{"About-us", false},
+		{"about--us", false},
+		{"-about", false},
+		{"about-", false},
+		{"über", false},
+		{strings.Repeat("a", 256), false},
+	}
+
+	for _, tt := range tests {
+		if got := Valid(tt.slug); got != tt.want {
+			t.Errorf("Valid(%q) = %v; want %v", tt.slug, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package tabular
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteJSONL writes one JSON object per row, keys in header order. When key
+// is not empty every object also carries the table name under key, so that
+// several tables can share a stream.
+func WriteJSONL(w io.Writer, key string, tables ...Table) error {
+	bw := bufio.NewWriter(w)
+	for _, t := range tables {
+		for _, row := range t.Rows {
+			var b bytes.Buffer
+			b.WriteByte('{')
+			if key != "" {
+				writeJSONField(&b, key, t.Name)
+			}
+			for i, column := range t.Header {
+				if b.Len() > 1 {
+					b.WriteByte(',')
+				}
+				value := ""
+				if i < len(row) {
+					value = row[i]
+				}
+				writeJSONField(&b, column, value)
+			}
+			b.WriteString("}\n")
+			if _, err := bw.Write(b.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+func writeJSONField(b *bytes.Buffer, name, value string) {
+	k, _ := json.Marshal(name)
+	v, _ := json.Marshal(value)
+	b.Write(k)
+	b.WriteByte(':')
+	b.Write(v)
+}
+
+// ReadJSONL reads one JSON object per line, grouping objects into tables by
+// the value of key, or into a single table named name when key is empty or
+// absent from an object. Headers list keys in order of first appearance.
+// Numbers and booleans keep their JSON text; null becomes "".
+func ReadJSONL(r io.Reader, key, name string) ([]Table, error) {
+	var tables []*Table
+	byName := map[string]*Table{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields, err := decodeObject([]byte(text))
+		if err != nil {
+			return nil, fmt.Errorf("tabular: line %d: %w", line, err)
+		}
+
+		tableName := name
+		values := map[string]string{}
+		var order []string
+		for _, f := range fields {
+			if key != "" && f.name == key {
+				tableName = f.value
+				continue
+			}
+			values[f.name] = f.value
+			order = append(order, f.name)
+		}
+
+		t, ok := byName[tableName]
+		if !ok {
+			t = &Table{Name: tableName}
+			byName[tableName] = t
+			tables = append(tables, t)
+		}
+		for _, column := range order {
+			if t.Index(column) < 0 {
+				t.Header = append(t.Header, column)
+				for i := range t.Rows {
+					t.Rows[i] = append(t.Rows[i], "")
+				}
+			}
+		}
+
+		row := make([]string, len(t.Header))
+		for i, column := range t.Header {
+			row[i] = values[column]
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]Table, len(tables))
+	for i, t := range tables {
+		result[i] = *t
+	}
+	return result, nil
+}
+
+type jsonField struct {
+	name  string
+	value string
+}
+
+// decodeObject decodes a flat JSON object, preserving key order.
+func decodeObject(data []byte) ([]jsonField, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var fields []jsonField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name := tok.(string)
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case nil:
+			fields = append(fields, jsonField{name: name})
+		case string:
+			fields = append(fields, jsonField{name: name, value: v})
+		case map[string]any, []any:
+			return nil, fmt.Errorf("field %q: nested values are not supported", name)
+		default:
+			fields = append(fields, jsonField{name: name, value: string(raw)})
+		}
+	}
+	return fields, nil
+}
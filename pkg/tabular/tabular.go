@@ -0,0 +1,138 @@
+// Package tabular reads and writes named tables of strings as CSV, JSON Lines
+// and XLSX, the formats reference data travels in between spreadsheets and
+// environments. Every cell is a string; typing is left to the caller.
+package tabular
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+type Table struct {
+	Name   string
+	Header []string
+	Rows   [][]string
+}
+
+// Index returns the position of column in the header, or -1.
+func (t Table) Index(column string) int {
+	for i, h := range t.Header {
+		if h == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// Get returns the cell of row in column, or "" if either is missing.
+func (t Table) Get(row int, column string) string {
+	i := t.Index(column)
+	if i < 0 || row >= len(t.Rows) || i >= len(t.Rows[row]) {
+		return ""
+	}
+	return t.Rows[row][i]
+}
+
+// WriteCSV writes t with its header as the first record.
+func WriteCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// ReadCSV reads a table whose first record is the header. Records may have
+// fewer fields than the header, and a leading UTF-8 byte order mark, as
+// written by some spreadsheet programs, is ignored.
+func ReadCSV(r io.Reader, name string) (Table, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return Table{}, err
+	}
+	if len(records) == 0 {
+		return Table{}, errors.New("tabular: empty CSV")
+	}
+
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+
+	return Table{Name: name, Header: header, Rows: skipBlank(records[1:])}, nil
+}
+
+// WriteCSVZip writes each table as name.csv in a zip archive.
+func WriteCSVZip(w io.Writer, tables ...Table) error {
+	zw := zip.NewWriter(w)
+	for _, t := range tables {
+		f, err := zw.Create(t.Name + ".csv")
+		if err != nil {
+			return err
+		}
+		if err := WriteCSV(f, t); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ReadCSVZip reads every .csv file of a zip archive, naming each table after
+// its file.
+func ReadCSVZip(r io.ReaderAt, size int64) ([]Table, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []Table
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".csv") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		t, err := ReadCSV(rc, strings.TrimSuffix(path.Base(f.Name), path.Ext(f.Name)))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// IsZip reports whether data starts with a zip local file header, as both
+// zipped CSV and XLSX files do.
+func IsZip(data []byte) bool {
+	return len(data) >= 4 && string(data[:4]) == "PK\x03\x04"
+}
+
+func skipBlank(rows [][]string) [][]string {
+	kept := rows[:0]
+	for _, row := range rows {
+		for _, cell := range row {
+			if strings.TrimSpace(cell) != "" {
+				kept = append(kept, row)
+				break
+			}
+		}
+	}
+	return kept
+}
@@ -0,0 +1,237 @@
+package tabular
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var tables = []Table{
+	{
+		Name:   "language",
+		Header: []string{"iso_639_1", "name", "note"},
+		Rows: [][]string{
+			{"de", "Deutsch", `comma, "quote"`},
+			{"ja", "日本語", "line\nbreak"},
+			{"xx", "<&>", " leading space"},
+			{"yy", "", ""},
+		},
+	},
+	{
+		Name:   "country",
+		Header: []string{"iso3166_2_a1", "name"},
+		Rows: [][]string{
+			{"DE", "Germany"},
+		},
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		format string
+		write  func(io.Writer, ...Table) error
+		read   func([]byte) ([]Table, error)
+	}{
+		{
+			"csv zip",
+			WriteCSVZip,
+			func(data []byte) ([]Table, error) { return ReadCSVZip(bytes.NewReader(data), int64(len(data))) },
+		},
+		{
+			"jsonl",
+			func(w io.Writer, tables ...Table) error { return WriteJSONL(w, "table", tables...) },
+			func(data []byte) ([]Table, error) { return ReadJSONL(bytes.NewReader(data), "table", "") },
+		},
+		{
+			"xlsx",
+			WriteXLSX,
+			func(data []byte) ([]Table, error) { return ReadXLSX(bytes.NewReader(data), int64(len(data))) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(&buf, tables...); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			got, err := tt.read(buf.Bytes())
+			if err != nil {
+				t.Fatalf("read error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tables) {
+				t.Errorf("round trip = %q; want %q", got, tables)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Table
+		wantErr bool
+	}{
+		{
+			name:  "byte order mark and padded header",
+			input: "\ufeffid , name\n1,German\n",
+			want:  Table{Name: "t", Header: []string{"id", "name"}, Rows: [][]string{{"1", "German"}}},
+		},
+		{
+			name:  "blank and short records",
+			input: "id,name\n1,German\n,\n2\n",
+			want:  Table{Name: "t", Header: []string{"id", "name"}, Rows: [][]string{{"1", "German"}, {"2"}}},
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			input:   "id,name\n1,\"German\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadCSV(strings.NewReader(tt.input), "t")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ReadCSV(%q) = %q; want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCSV(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Table
+		wantErr bool
+	}{
+		{
+			name:  "scalars keep their JSON text",
+			input: `{"id":1,"active":true,"note":null,"name":"German"}`,
+			want:  []Table{{Name: "rows", Header: []string{"id", "active", "note", "name"}, Rows: [][]string{{"1", "true", "", "German"}}}},
+		},
+		{
+			name:  "later columns pad earlier rows",
+			input: "{\"id\":\"1\"}\n\n{\"id\":\"2\",\"name\":\"French\"}\n",
+			want:  []Table{{Name: "rows", Header: []string{"id", "name"}, Rows: [][]string{{"1", ""}, {"2", "French"}}}},
+		},
+		{
+			name:  "grouped by key",
+			input: "{\"table\":\"language\",\"id\":\"1\"}\n{\"table\":\"country\",\"id\":\"2\"}\n{\"id\":\"3\"}\n",
+			want: []Table{
+				{Name: "language", Header: []string{"id"}, Rows: [][]string{{"1"}}},
+				{Name: "country", Header: []string{"id"}, Rows: [][]string{{"2"}}},
+				{Name: "rows", Header: []string{"id"}, Rows: [][]string{{"3"}}},
+			},
+		},
+		{
+			name:    "nested value",
+			input:   `{"id":{"value":1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			input:   `["id"]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadJSONL(strings.NewReader(tt.input), "table", "rows")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ReadJSONL(%q) = %q; want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadJSONL(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteXLSXErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []Table
+	}{
+		{"no tables", nil},
+		{"long sheet name", []Table{{Name: strings.Repeat("x", MaxSheetNameLength+1)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := WriteXLSX(io.Discard, tt.tables...); err == nil {
+				t.Errorf("WriteXLSX(%q) succeeded; want an error", tt.tables)
+			}
+		})
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		name  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := columnName(tt.index); got != tt.name {
+			t.Errorf("columnName(%d) = %q; want %q", tt.index, got, tt.name)
+		}
+		if got, err := columnIndex(tt.name + "12"); err != nil || got != tt.index {
+			t.Errorf("columnIndex(%q) = %d, %v; want %d", tt.name+"12", got, err, tt.index)
+		}
+	}
+
+	if _, err := columnIndex("12"); err == nil {
+		t.Error(`columnIndex("12") succeeded; want an error`)
+	}
+}
+
+func TestIsZip(t *testing.T) {
+	var xlsx bytes.Buffer
+	if err := WriteXLSX(&xlsx, tables...); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"xlsx", xlsx.Bytes(), true},
+		{"csv", []byte("id,name\n"), false},
+		{"short", []byte("PK"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsZip(tt.data); got != tt.want {
+			t.Errorf("IsZip(%s) = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
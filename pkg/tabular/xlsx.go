@@ -0,0 +1,343 @@
+package tabular
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// XLSX support covers what reference data needs: one sheet per table, text
+// cells, and a header row. Formulas, styles and dates are not interpreted;
+// a cell reads as its stored value.
+
+// MaxSheetNameLength is the limit Excel puts on sheet names.
+const MaxSheetNameLength = 31
+
+const (
+	nsSpreadsheet   = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	nsRelationships = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	nsPackageRels   = "http://schemas.openxmlformats.org/package/2006/relationships"
+	relOffice       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	relWorksheet    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+)
+
+// WriteXLSX writes each table to its own worksheet, named after the table,
+// with the header as the first row. Cells are written as inline strings so
+// that no shared string table is needed.
+func WriteXLSX(w io.Writer, tables ...Table) error {
+	if len(tables) == 0 {
+		return errors.New("tabular: a workbook needs at least one table")
+	}
+
+	zw := zip.NewWriter(w)
+
+	var contentTypes, workbook, workbookRels bytes.Buffer
+
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+
+	workbook.WriteString(xml.Header)
+	fmt.Fprintf(&workbook, `<workbook xmlns="%s" xmlns:r="%s"><sheets>`, nsSpreadsheet, nsRelationships)
+
+	workbookRels.WriteString(xml.Header)
+	fmt.Fprintf(&workbookRels, `<Relationships xmlns="%s">`, nsPackageRels)
+
+	for i, t := range tables {
+		n := i + 1
+		name := t.Name
+		if name == "" {
+			name = "Sheet" + strconv.Itoa(n)
+		}
+		if len([]rune(name)) > MaxSheetNameLength {
+			return fmt.Errorf("tabular: sheet name %q is longer than %d characters", name, MaxSheetNameLength)
+		}
+
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(name), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="%s" Target="worksheets/sheet%d.xml"/>`, n, relWorksheet, n)
+
+		f, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", n))
+		if err != nil {
+			return err
+		}
+		if err := writeSheet(f, t); err != nil {
+			return err
+		}
+	}
+
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+
+	rootRels := xml.Header + fmt.Sprintf(`<Relationships xmlns="%s"><Relationship Id="rId1" Type="%s" Target="xl/workbook.xml"/></Relationships>`, nsPackageRels, relOffice)
+
+	parts := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(rootRels)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.Bytes()},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(p.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeSheet(w io.Writer, t Table) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<worksheet xmlns="%s"><sheetData>`, nsSpreadsheet)
+
+	rows := append([][]string{t.Header}, t.Rows...)
+	for r, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, value := range row {
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, columnName(c), r+1, escapeXML(value))
+		}
+		b.WriteString(`</row>`)
+
+		if b.Len() > 64*1024 {
+			if _, err := w.Write(b.Bytes()); err != nil {
+				return err
+			}
+			b.Reset()
+		}
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// ReadXLSX reads every worksheet of a workbook as a table named after the
+// sheet, taking the first row as the header.
+func ReadXLSX(r io.ReaderAt, size int64) ([]Table, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodePart(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodePart(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := map[string]string{}
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+
+	var shared []string
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []richText `xml:"si"`
+		}
+		if err := decodePart(files, "xl/sharedStrings.xml", &sst); err != nil {
+			return nil, err
+		}
+		for _, si := range sst.Items {
+			shared = append(shared, si.String())
+		}
+	}
+
+	var tables []Table
+	for _, sheet := range workbook.Sheets {
+		target, ok := targets[sheet.RID]
+		if !ok {
+			return nil, fmt.Errorf("tabular: sheet %q has no worksheet part", sheet.Name)
+		}
+		grid, err := readSheet(files, target, shared)
+		if err != nil {
+			return nil, fmt.Errorf("tabular: sheet %q: %w", sheet.Name, err)
+		}
+
+		t := Table{Name: sheet.Name}
+		if len(grid) > 0 {
+			t.Header = grid[0]
+			for i := range t.Header {
+				t.Header[i] = strings.TrimSpace(t.Header[i])
+			}
+			for _, row := range grid[1:] {
+				if len(row) < len(t.Header) {
+					row = append(row, make([]string, len(t.Header)-len(row))...)
+				}
+				t.Rows = append(t.Rows, row)
+			}
+			t.Rows = skipBlank(t.Rows)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt richText) String() string {
+	if len(rt.Runs) == 0 {
+		return rt.T
+	}
+	var b strings.Builder
+	for _, run := range rt.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+func readSheet(files map[string]*zip.File, name string, shared []string) ([][]string, error) {
+	var sheet struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string   `xml:"r,attr"`
+				T      string   `xml:"t,attr"`
+				V      string   `xml:"v"`
+				Inline richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodePart(files, name, &sheet); err != nil {
+		return nil, err
+	}
+
+	var grid [][]string
+	for i, row := range sheet.Rows {
+		rowIndex := i
+		if row.R > 0 {
+			rowIndex = row.R - 1
+		}
+		for len(grid) <= rowIndex {
+			grid = append(grid, nil)
+		}
+
+		var cells []string
+		for j, c := range row.Cells {
+			col := j
+			if c.R != "" {
+				var err error
+				if col, err = columnIndex(c.R); err != nil {
+					return nil, err
+				}
+			}
+
+			var value string
+			switch c.T {
+			case "s":
+				n, err := strconv.Atoi(c.V)
+				if err != nil || n < 0 || n >= len(shared) {
+					return nil, fmt.Errorf("cell %s: bad shared string index %q", c.R, c.V)
+				}
+				value = shared[n]
+			case "inlineStr":
+				value = c.Inline.String()
+			default:
+				value = c.V
+			}
+
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			cells[col] = value
+		}
+		grid[rowIndex] = cells
+	}
+	return grid, nil
+}
+
+func decodePart(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("tabular: missing %s, not an XLSX workbook", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// columnName returns the letters of a zero-based column index: A, ..., Z,
+// AA, ...
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// columnIndex returns the zero-based column of a cell reference such as
+// "AB12".
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("bad cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+func escapeXML(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
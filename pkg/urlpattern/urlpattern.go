@@ -0,0 +1,298 @@
+// Package urlpattern maps canonical paths to locale-specific URLs and back,
+// following the per-locale strategies configured for a site.
+package urlpattern
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+type Strategy string
+
+const (
+	PathPrefix Strategy = "path_prefix"
+	Subdomain  Strategy = "subdomain"
+	CCTLD      Strategy = "cctld"
+	QueryParam Strategy = "query_param"
+)
+
+// Strategies lists every strategy in the order Resolve tries them.
+var Strategies = []Strategy{PathPrefix, Subdomain, CCTLD, QueryParam}
+
+var (
+	ErrUnknownLocale = errors.New("unknown locale")
+	ErrNoMatch       = errors.New("url does not match any locale")
+)
+
+// Locale describes how a single locale is encoded in a URL. Value holds the
+// path segment, subdomain label or query parameter value; for ccTLD locales it
+// overrides the TLD taken from the locale's country.
+type Locale struct {
+	Tag      string   `json:"locale"`
+	Strategy Strategy `json:"strategy"`
+	Value    string   `json:"value"`
+	TLD      string   `json:"tld,omitempty"`
+}
+
+// Site is the URL configuration of a single site.
+type Site struct {
+	ID            int32    `json:"id"`
+	Name          string   `json:"name"`
+	Scheme        string   `json:"scheme"`
+	Host          string   `json:"host"`
+	QueryParam    string   `json:"query_param"`
+	DefaultLocale string   `json:"default_locale,omitempty"`
+	Locales       []Locale `json:"locales"`
+}
+
+// Validate checks that the locale carries everything its strategy needs.
+func (l Locale) Validate() error {
+	if l.Tag == "" {
+		return errors.New("locale is required")
+	}
+
+	switch l.Strategy {
+	case PathPrefix, Subdomain, QueryParam:
+		if l.Value == "" {
+			return fmt.Errorf("strategy %s requires a value", l.Strategy)
+		}
+		if l.Strategy != QueryParam && strings.ContainsAny(l.Value, "/.?#") {
+			return fmt.Errorf("invalid %s value %q", l.Strategy, l.Value)
+		}
+	case CCTLD:
+		if l.tld() == "" {
+			return errors.New("strategy cctld requires a country with a tld or an explicit value")
+		}
+	default:
+		return fmt.Errorf("unknown strategy %q", l.Strategy)
+	}
+
+	return nil
+}
+
+func (l Locale) tld() string {
+	if l.Value != "" {
+		return strings.ToLower(strings.Trim(l.Value, "."))
+	}
+	return strings.ToLower(strings.Trim(l.TLD, "."))
+}
+
+// Locale returns the configured locale matching tag, compared case-insensitively.
+func (s Site) Locale(tag string) (Locale, bool) {
+	for _, l := range s.Locales {
+		if strings.EqualFold(l.Tag, tag) {
+			return l, true
+		}
+	}
+	return Locale{}, false
+}
+
+// Build returns the absolute URL of canonicalPath in the given locale.
+func (s Site) Build(canonicalPath, tag string) (string, error) {
+	u, err := s.localize(canonicalPath, tag)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Path returns the path that requests for canonicalPath in the given locale
+// have on their host, without query or fragment. It only differs from
+// canonicalPath for the path_prefix strategy.
+func (s Site) Path(canonicalPath, tag string) (string, error) {
+	u, err := s.localize(canonicalPath, tag)
+	if err != nil {
+		return "", err
+	}
+	return u.EscapedPath(), nil
+}
+
+func (s Site) localize(canonicalPath, tag string) (*url.URL, error) {
+	l, ok := s.Locale(tag)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLocale, tag)
+	}
+
+	u, err := s.canonicalURL(canonicalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch l.Strategy {
+	case PathPrefix:
+		u.Path = "/" + l.Value + u.Path
+	case Subdomain:
+		u.Host = l.Value + "." + s.host()
+	case CCTLD:
+		u.Host = s.baseName() + "." + l.tld()
+	case QueryParam:
+		q := u.Query()
+		q.Set(s.queryParam(), l.Value)
+		u.RawQuery = q.Encode()
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", l.Strategy)
+	}
+
+	return u, nil
+}
+
+// Resolve splits an incoming URL into its locale and canonical path. URLs on
+// the site's own host that match no locale resolve to the default locale.
+func (s Site) Resolve(rawURL string) (Locale, string, error) {
+	u, err := parseURL(rawURL)
+	if err != nil {
+		return Locale{}, "", err
+	}
+
+	for _, strategy := range Strategies {
+		if l, canonical, ok := s.Match(u, strategy); ok {
+			return l, canonical, nil
+		}
+	}
+
+	if hostname(u) == s.host() && s.DefaultLocale != "" {
+		if l, ok := s.Locale(s.DefaultLocale); ok {
+			return l, requestURI(u), nil
+		}
+	}
+
+	return Locale{}, "", ErrNoMatch
+}
+
+// Match tries to resolve u using only locales configured with strategy.
+func (s Site) Match(u *url.URL, strategy Strategy) (Locale, string, bool) {
+	host := hostname(u)
+
+	for _, l := range s.Locales {
+		if l.Strategy != strategy {
+			continue
+		}
+
+		switch strategy {
+		case PathPrefix:
+			if host != s.host() {
+				continue
+			}
+			segment, rest := splitFirstSegment(u.Path)
+			if !strings.EqualFold(segment, l.Value) {
+				continue
+			}
+			c := *u
+			c.Path = rest
+			c.RawPath = ""
+			return l, requestURI(&c), true
+		case Subdomain:
+			if host == strings.ToLower(l.Value)+"."+s.host() {
+				return l, requestURI(u), true
+			}
+		case CCTLD:
+			if host == s.baseName()+"."+l.tld() {
+				return l, requestURI(u), true
+			}
+		case QueryParam:
+			if host != s.host() {
+				continue
+			}
+			q := u.Query()
+			if !strings.EqualFold(q.Get(s.queryParam()), l.Value) {
+				continue
+			}
+			q.Del(s.queryParam())
+			c := *u
+			c.RawQuery = q.Encode()
+			return l, requestURI(&c), true
+		}
+	}
+
+	return Locale{}, "", false
+}
+
+func (s Site) canonicalURL(canonicalPath string) (*url.URL, error) {
+	ref, err := url.Parse(canonicalPath)
+	if err != nil || ref.IsAbs() || ref.Host != "" {
+		return nil, fmt.Errorf("invalid canonical path %q", canonicalPath)
+	}
+	if !strings.HasPrefix(ref.Path, "/") {
+		ref.Path = "/" + ref.Path
+	}
+
+	return &url.URL{
+		Scheme:   s.scheme(),
+		Host:     s.host(),
+		Path:     ref.Path,
+		RawQuery: ref.RawQuery,
+		Fragment: ref.Fragment,
+	}, nil
+}
+
+func (s Site) scheme() string {
+	if s.Scheme == "" {
+		return "https"
+	}
+	return s.Scheme
+}
+
+func (s Site) host() string {
+	return strings.ToLower(s.Host)
+}
+
+func (s Site) queryParam() string {
+	if s.QueryParam == "" {
+		return "lang"
+	}
+	return s.QueryParam
+}
+
+// baseName returns the registrable name of the site host without its public
+// suffix, so that www.example.com and example.co.uk become example and can be
+// combined with a country TLD. Hosts without a public suffix, such as
+// localhost, are kept whole.
+func (s Site) baseName() string {
+	host := s.host()
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	suffix, _ := publicsuffix.PublicSuffix(domain)
+	return strings.TrimSuffix(domain, "."+suffix)
+}
+
+func parseURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", rawURL)
+	}
+	return u, nil
+}
+
+func hostname(u *url.URL) string {
+	host := u.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+func splitFirstSegment(path string) (string, string) {
+	trimmed := strings.TrimPrefix(path, "/")
+	if i := strings.Index(trimmed, "/"); i >= 0 {
+		return trimmed[:i], trimmed[i:]
+	}
+	return trimmed, "/"
+}
+
+func requestURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path
+}
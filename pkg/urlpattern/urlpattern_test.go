@@ -0,0 +1,190 @@
+package urlpattern
+
+import (
+	"errors"
+	"testing"
+)
+
+var testSite = Site{
+	ID:            1,
+	Host:          "example.com",
+	QueryParam:    "lang",
+	DefaultLocale: "en-US",
+	Locales: []Locale{
+		{Tag: "en-US", Strategy: PathPrefix, Value: "en"},
+		{Tag: "pt-BR", Strategy: PathPrefix, Value: "pt-br"},
+		{Tag: "pt-PT", Strategy: Subdomain, Value: "pt"},
+		{Tag: "de-DE", Strategy: CCTLD, TLD: "de"},
+		{Tag: "en-GB", Strategy: CCTLD, TLD: "gb", Value: "co.uk"},
+		{Tag: "fr-FR", Strategy: QueryParam, Value: "fr"},
+	},
+}
+
+func TestBuildResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		site      Site
+		canonical string
+		locale    string
+		want      string
+	}{
+		{"path prefix", testSite, "/about", "en-US", "https://example.com/en/about"},
+		{"path prefix root", testSite, "/", "pt-BR", "https://example.com/pt-br/"},
+		{"path prefix keeps query", testSite, "/search?q=a", "pt-BR", "https://example.com/pt-br/search?q=a"},
+		{"subdomain", testSite, "/about", "pt-PT", "https://pt.example.com/about"},
+		{"cctld from country", testSite, "/about", "de-DE", "https://example.de/about"},
+		{"cctld value overrides country", testSite, "/about", "en-GB", "https://example.co.uk/about"},
+		{"query param", testSite, "/about", "fr-FR", "https://example.com/about?lang=fr"},
+		{"query param merges query", testSite, "/search?q=a", "fr-FR", "https://example.com/search?lang=fr&q=a"},
+		{"locale is case insensitive", testSite, "/about", "pt-br", "https://example.com/pt-br/about"},
+		{"relative canonical path", testSite, "about", "en-US", "https://example.com/en/about"},
+
+		{"cctld on multi-label suffix", withHost(testSite, "example.co.uk"), "/about", "de-DE", "https://example.de/about"},
+		{"cctld on www host", withHost(testSite, "www.example.com"), "/about", "de-DE", "https://example.de/about"},
+		{"cctld on deep host", withHost(testSite, "shop.example.com.br"), "/about", "de-DE", "https://example.de/about"},
+		{"cctld on host without suffix", withHost(testSite, "localhost"), "/about", "de-DE", "https://localhost.de/about"},
+		{"custom scheme", Site{Scheme: "http", Host: "example.com", Locales: testSite.Locales}, "/", "en-US", "http://example.com/en/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.site.Build(tt.canonical, tt.locale)
+			if err != nil || got != tt.want {
+				t.Fatalf("Build(%q, %q) = %q, %v; want %q", tt.canonical, tt.locale, got, err, tt.want)
+			}
+
+			l, canonical, err := tt.site.Resolve(got)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error = %v", got, err)
+			}
+			want, _ := tt.site.Locale(tt.locale)
+			wantCanonical := tt.canonical
+			if wantCanonical[0] != '/' {
+				wantCanonical = "/" + wantCanonical
+			}
+			if l.Tag != want.Tag || canonical != wantCanonical {
+				t.Errorf("Resolve(%q) = %q, %q; want %q, %q", got, l.Tag, canonical, want.Tag, wantCanonical)
+			}
+		})
+	}
+}
+
+func withHost(s Site, host string) Site {
+	s.Host = host
+	return s
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		canonical string
+		locale    string
+		want      string
+	}{
+		{"/about-us", "en-US", "/en/about-us"},
+		{"/about-us?x=1", "pt-BR", "/pt-br/about-us"},
+		{"/about-us", "pt-PT", "/about-us"},
+		{"/about-us", "de-DE", "/about-us"},
+		{"/about-us", "fr-FR", "/about-us"},
+	}
+
+	for _, tt := range tests {
+		got, err := testSite.Path(tt.canonical, tt.locale)
+		if err != nil || got != tt.want {
+			t.Errorf("Path(%q, %q) = %q, %v; want %q", tt.canonical, tt.locale, got, err, tt.want)
+		}
+	}
+	if _, err := testSite.Path("/about-us", "ja-JP"); !errors.Is(err, ErrUnknownLocale) {
+		t.Errorf("Path with an unknown locale error = %v; want %v", err, ErrUnknownLocale)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		canonical string
+		locale    string
+		unknown   bool
+	}{
+		{"unknown locale", "/about", "ja-JP", true},
+		{"absolute url", "https://other.example/about", "en-US", false},
+		{"host only", "//other.example/about", "en-US", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testSite.Build(tt.canonical, tt.locale)
+			if err == nil {
+				t.Fatalf("Build(%q, %q) = %q; want an error", tt.canonical, tt.locale, got)
+			}
+			if errors.Is(err, ErrUnknownLocale) != tt.unknown {
+				t.Errorf("Build(%q, %q) error = %v; unknown locale %v", tt.canonical, tt.locale, err, tt.unknown)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		locale    string
+		canonical string
+		err       error
+	}{
+		{"path prefix is case insensitive", "https://EXAMPLE.com/PT-BR/about", "pt-BR", "/about", nil},
+		{"path prefix without trailing slash", "https://example.com/pt-br", "pt-BR", "/", nil},
+		{"path prefix needs a whole segment", "https://example.com/pt-brazil/about", "en-US", "/pt-brazil/about", nil},
+		{"port is ignored", "https://example.com:8443/en/about", "en-US", "/about", nil},
+		{"trailing dot is ignored", "https://example.com./en/about", "en-US", "/about", nil},
+		{"query param is removed", "https://example.com/about?lang=FR&q=a", "fr-FR", "/about?q=a", nil},
+		{"default locale", "https://example.com/about", "en-US", "/about", nil},
+		{"unknown subdomain", "https://ja.example.com/about", "", "", ErrNoMatch},
+		{"other host", "https://other.example/en/about", "", "", ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, canonical, err := testSite.Resolve(tt.url)
+			if !errors.Is(err, tt.err) || l.Tag != tt.locale || canonical != tt.canonical {
+				t.Errorf("Resolve(%q) = %q, %q, %v; want %q, %q, %v", tt.url, l.Tag, canonical, err, tt.locale, tt.canonical, tt.err)
+			}
+		})
+	}
+
+	noDefault := testSite
+	noDefault.DefaultLocale = ""
+	if _, _, err := noDefault.Resolve("https://example.com/about"); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Resolve without a default locale error = %v; want %v", err, ErrNoMatch)
+	}
+	if _, _, err := testSite.Resolve("/about"); err == nil || errors.Is(err, ErrNoMatch) {
+		t.Errorf("Resolve of a relative url error = %v; want an invalid url error", err)
+	}
+}
+
+func TestLocaleValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		ok     bool
+	}{
+		{"path prefix", Locale{Tag: "en", Strategy: PathPrefix, Value: "en"}, true},
+		{"subdomain", Locale{Tag: "en", Strategy: Subdomain, Value: "en"}, true},
+		{"query param may contain dots", Locale{Tag: "en", Strategy: QueryParam, Value: "en.v2"}, true},
+		{"cctld from country", Locale{Tag: "de", Strategy: CCTLD, TLD: ".de"}, true},
+		{"cctld from value", Locale{Tag: "de", Strategy: CCTLD, Value: "de"}, true},
+		{"missing tag", Locale{Strategy: PathPrefix, Value: "en"}, false},
+		{"missing value", Locale{Tag: "en", Strategy: PathPrefix}, false},
+		{"path prefix with slash", Locale{Tag: "en", Strategy: PathPrefix, Value: "en/us"}, false},
+		{"subdomain with dot", Locale{Tag: "en", Strategy: Subdomain, Value: "en.us"}, false},
+		{"cctld without tld", Locale{Tag: "de", Strategy: CCTLD}, false},
+		{"unknown strategy", Locale{Tag: "en", Strategy: "cookie", Value: "en"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.locale.Validate(); (err == nil) != tt.ok {
+				t.Errorf("Validate() = %v; want ok %v", err, tt.ok)
+			}
+		})
+	}
+}